@@ -0,0 +1,107 @@
+package structpages
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// WithBaseURL sets the scheme, host and optional path prefix, e.g.
+// "https://example.com" or "https://example.com/app", that AbsoluteRedirect
+// prepends to paths. Without it the request's Host and TLS state are used,
+// which behind a reverse proxy may name the proxy's upstream instead.
+func WithBaseURL(baseURL string) func(*StructPages) {
+	return func(r *StructPages) {
+		r.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithBaseURLFromHeader makes AbsoluteRedirect take the host from the
+// request header headerName ("X-Forwarded-Host" when empty) and the scheme
+// from X-Forwarded-Proto, for deployments whose proxy sets them. Requests
+// without the host header use WithBaseURL, or the request's own host.
+func WithBaseURLFromHeader(headerName string) func(*StructPages) {
+	if headerName == "" {
+		headerName = "X-Forwarded-Host"
+	}
+	return func(r *StructPages) {
+		r.baseURLHeader = headerName
+	}
+}
+
+// absoluteRedirectError is returned by AbsoluteRedirect.
+type absoluteRedirectError struct {
+	code int
+	path string
+}
+
+func (e *absoluteRedirectError) Error() string {
+	return fmt.Sprintf("redirect (%d) to %s", e.code, e.path)
+}
+
+// AbsoluteRedirect returns the error a Props method returns to redirect to
+// path with status code, like http.Redirect, but with an absolute Location
+// built from WithBaseURL or WithBaseURLFromHeader:
+//
+//	return Item{}, structpages.AbsoluteRedirect(http.StatusSeeOther, "/items/"+item.ID)
+//
+// HTMX requests get a 200 with the URL in HX-Redirect instead, as with
+// HXRedirect. A path that is already an absolute URL is used as is.
+func AbsoluteRedirect(code int, path string) error {
+	return &absoluteRedirectError{code: code, path: path}
+}
+
+// absoluteURL returns path prefixed with the base URL for r.
+func (sp *StructPages) absoluteURL(r *http.Request, path string) string {
+	if strings.Contains(path, "://") {
+		return path
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return sp.requestBaseURL(r) + path
+}
+
+// requestBaseURL returns the scheme and host, plus the path prefix of a
+// WithBaseURL base, that URLs for r are made absolute with.
+func (sp *StructPages) requestBaseURL(r *http.Request) string {
+	if sp.baseURLHeader != "" {
+		if host := r.Header.Get(sp.baseURLHeader); host != "" {
+			scheme := r.Header.Get("X-Forwarded-Proto")
+			if scheme == "" {
+				scheme = requestScheme(r)
+			}
+			return scheme + "://" + host
+		}
+	}
+	if sp.baseURL != "" {
+		return sp.baseURL
+	}
+	return requestScheme(r) + "://" + r.Host
+}
+
+func requestScheme(r *http.Request) string {
+	if r.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
+
+// handleAbsoluteRedirect answers a request whose Props returned
+// AbsoluteRedirect, possibly wrapped. It reports whether err was such an
+// error.
+func (sp *StructPages) handleAbsoluteRedirect(w http.ResponseWriter, r *http.Request, err error) bool {
+	var redirect *absoluteRedirectError
+	if !errors.As(err, &redirect) {
+		return false
+	}
+	url := sp.absoluteURL(r, redirect.path)
+	if r.Header.Get(htmxConfig(sp.pc).RequestHeader) == "true" {
+		w.Header().Set("HX-Redirect", url)
+		w.WriteHeader(http.StatusOK)
+		return true
+	}
+	http.Redirect(w, r, url, redirect.code)
+	return true
+}
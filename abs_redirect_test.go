@@ -0,0 +1,85 @@
+package structpages
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type absRedirectPage struct{}
+
+func (absRedirectPage) Props() (string, error) {
+	return "", fmt.Errorf("saved: %w", AbsoluteRedirect(http.StatusSeeOther, "/items/7"))
+}
+
+func (absRedirectPage) Page(s string) component { return testComponent{s} }
+
+type absRedirectPages struct {
+	Save absRedirectPage `route:"/save Save"`
+}
+
+func TestAbsoluteRedirect(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		headers map[string]string
+		tls     bool
+		want    string
+	}{
+		{"request host", nil, nil, false, "http://example.com/items/7"},
+		{"request host over TLS", nil, nil, true, "https://example.com/items/7"},
+		{"static base URL", []Option{WithBaseURL("https://app.example.org/base/")}, nil, false,
+			"https://app.example.org/base/items/7"},
+		{"forwarded headers", []Option{WithBaseURLFromHeader("")},
+			map[string]string{"X-Forwarded-Host": "public.example.net", "X-Forwarded-Proto": "https"}, false,
+			"https://public.example.net/items/7"},
+		{"forwarded host over plain HTTP", []Option{WithBaseURLFromHeader("X-Original-Host")},
+			map[string]string{"X-Original-Host": "public.example.net"}, false,
+			"http://public.example.net/items/7"},
+		{"no forwarded host falls back to the base URL",
+			[]Option{WithBaseURLFromHeader(""), WithBaseURL("https://app.example.org")}, nil, false,
+			"https://app.example.org/items/7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			if _, err := Mount(mux, absRedirectPages{}, "/", "App", tt.opts...); err != nil {
+				t.Fatalf("Mount: %v", err)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/save", http.NoBody)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			if tt.tls {
+				req.TLS = &tls.ConnectionState{}
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+			if rec.Code != http.StatusSeeOther {
+				t.Errorf("status = %d, want 303", rec.Code)
+			}
+			if got := rec.Header().Get("Location"); got != tt.want {
+				t.Errorf("Location = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAbsoluteRedirect_HTMX(t *testing.T) {
+	mux := http.NewServeMux()
+	if _, err := Mount(mux, absRedirectPages{}, "/", "App", WithBaseURL("https://app.example.org")); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/save", http.NoBody)
+	req.Header.Set("HX-Request", "true")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200", rec.Code)
+	}
+	if got, want := rec.Header().Get("HX-Redirect"), "https://app.example.org/items/7"; got != want {
+		t.Errorf("HX-Redirect = %q, want %q", got, want)
+	}
+}
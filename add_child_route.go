@@ -0,0 +1,133 @@
+package structpages
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// AddChildRoute parses childPage and mounts it below the page parentPage
+// identifies after Mount. parentPage is a page value, Ref, predicate or
+// []any chain, as for URLFor. The child becomes one of the parent's
+// Children and its routes are wrapped in the same middlewares a child field
+// of the parent would get: the global ones, then those of every Middlewares
+// method from the root down to the parent.
+//
+//	err := sp.AddChildRoute(adminPage{}, &reportsPage{}, "/reports", "Reports")
+//
+// route is a route tag as given to Mount ("/reports" or "GET /reports");
+// title, if not empty, replaces the tag's title. It is safe to call while
+// requests are served: the child is added to a copy of the page tree,
+// which then replaces the current one, so URLFor, ID and CurrentPage see
+// either the old tree or the new one. If the child can't be registered the
+// old tree is put back. It returns ErrNotMounted before Mount.
+func (sp *StructPages) AddChildRoute(parentPage, childPage any, route, title string) error {
+	sp.checkWritable()
+	sp = sp.current()
+	if !sp.mounted() {
+		return ErrNotMounted
+	}
+	chain, ok := parentPage.([]any)
+	if !ok {
+		chain = []any{parentPage}
+	}
+	if len(chain) == 0 {
+		return errors.New("AddChildRoute: parent page chain is empty")
+	}
+	sp.pc.treeMu.Lock()
+	defer sp.pc.treeMu.Unlock()
+	tree := sp.pc.tree.Load()
+	parent, err := sp.pc.resolveChain(chain)
+	if err != nil {
+		return fmt.Errorf("AddChildRoute: parent page: %w", err)
+	}
+	ancestors := []*PageNode{parent}
+	for n := parent.Parent; n != nil; n = n.Parent {
+		ancestors = append(ancestors, n)
+	}
+	slices.Reverse(ancestors)
+	if len(ancestors) > sp.pc.maxDepth {
+		return fmt.Errorf("AddChildRoute: page below %s would be at depth %d, deeper than the limit of %d (see WithMaxDepth)",
+			parent.Name, len(ancestors), sp.pc.maxDepth)
+	}
+
+	names := maps.Clone(sp.pc.pageNames)
+	sp.pc.depth = len(ancestors)
+	child, err := sp.pc.parsePageTree(route, "", childPage)
+	if err == nil && len(sp.pc.errs) > 0 {
+		err = &MultiError{Errors: sp.pc.errs}
+	}
+	sp.pc.depth, sp.pc.errs = 0, nil
+	if err != nil {
+		sp.pc.pageNames = names
+		return fmt.Errorf("AddChildRoute: %w", err)
+	}
+	if title != "" {
+		child.Title = title
+	}
+	sp.applyDefaultTitles(child)
+	sp.pc.tree.Store(sp.pc.withChild(tree, parent, child))
+	err = sp.addChild(ancestors, child)
+	if err != nil {
+		sp.pc.tree.Store(tree)
+		sp.pc.pageNames = names
+	}
+	sp.resetMatcher()
+	if err != nil {
+		return fmt.Errorf("AddChildRoute: %w", err)
+	}
+	return nil
+}
+
+// withChild returns a copy of t with child appended to the children of
+// parent, one of t's nodes. Ids are recomputed and child's route segments
+// parsed up front, so nothing in the returned tree changes once it is
+// stored.
+func (p *parseContext) withChild(t *pageTree, parent, child *PageNode) *pageTree {
+	copies := make(map[*PageNode]*PageNode)
+	root := cloneNode(t.root, nil, copies)
+	child.Parent = copies[parent]
+	child.Parent.Children = append(child.Parent.Children, child)
+	assignIDPaths(root)
+	for n := range child.All() {
+		if segments, err := p.getSegmentsCached(n.FullRoute()); err == nil {
+			n.routeSegments = segments
+		}
+	}
+	nodes := make(map[*PageNode]*PageNode, len(copies)+1)
+	for n := range root.All() {
+		nodes[n.key()] = n
+	}
+	return &pageTree{root: root, nodes: nodes}
+}
+
+// addChild checks the tree with child appended and registers child on sp's
+// mux, turning a mux panic into an error. Conflicts with the page tree are
+// found before anything is registered; a mux that fails part way through
+// keeps the routes it already took, as http.ServeMux can't remove them.
+func (sp *StructPages) addChild(ancestors []*PageNode, child *PageNode) (err error) {
+	if err := sp.checkIDCollisions(sp.pc); err != nil {
+		return err
+	}
+	if err := sp.checkRoutes(); err != nil {
+		return err
+	}
+	mw := slices.Clone(sp.rootMiddlewares)
+	for _, n := range ancestors {
+		if !sp.inEnv(n) {
+			return nil
+		}
+		mws, err := sp.pageMiddlewares(n)
+		if err != nil {
+			return err
+		}
+		mw = append(mw, mws...)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("registering %s: %v", child.Name, r)
+		}
+	}()
+	return sp.registerPageItem(sp.mux, child, mw)
+}
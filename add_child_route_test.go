@@ -0,0 +1,149 @@
+package structpages
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func chainMiddleware(name string) MiddlewareFunc {
+	return func(next http.Handler, _ *PageNode) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("X-Chain", name)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+type childSectionPage struct{}
+
+func (childSectionPage) Page() component { return testComponent{"section"} }
+
+func (childSectionPage) Middlewares() []MiddlewareFunc {
+	return []MiddlewareFunc{chainMiddleware("section")}
+}
+
+type childAdminPage struct {
+	Section childSectionPage `route:"/section Section"`
+}
+
+func (childAdminPage) Page() component { return testComponent{"admin"} }
+
+func (childAdminPage) Middlewares() []MiddlewareFunc {
+	return []MiddlewareFunc{chainMiddleware("admin")}
+}
+
+type childRootPages struct {
+	Admin childAdminPage `route:"/admin Admin"`
+}
+
+type childReportsPage struct{}
+
+func (childReportsPage) Page() component { return testComponent{"reports"} }
+
+type childExportPage struct{}
+
+func (childExportPage) Page() component { return testComponent{"export"} }
+
+func TestAddChildRoute(t *testing.T) {
+	mux := http.NewServeMux()
+	sp, err := Mount(mux, &childRootPages{}, "/", "App", WithMiddlewares(chainMiddleware("global")))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	if err := sp.AddChildRoute(childAdminPage{}, &childReportsPage{}, "/reports", "Reports"); err != nil {
+		t.Fatalf("AddChildRoute(admin): %v", err)
+	}
+	if err := sp.AddChildRoute(childSectionPage{}, &childExportPage{}, "GET /export", ""); err != nil {
+		t.Fatalf("AddChildRoute(section): %v", err)
+	}
+
+	tests := []struct {
+		path, body, chain string
+	}{
+		{"/admin/reports", "reports", "global,admin"},
+		{"/admin/section/export", "export", "global,admin,section"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+		chain := strings.Join(rec.Header().Values("X-Chain"), ",")
+		if rec.Body.String() != tt.body || chain != tt.chain {
+			t.Errorf("GET %s = %q with middlewares %q, want %q with %q", tt.path, rec.Body.String(), chain, tt.body, tt.chain)
+		}
+	}
+
+	if got, err := sp.URLFor(childExportPage{}); err != nil || got != "/admin/section/export" {
+		t.Errorf("URLFor(childExportPage) = %q, %v; want /admin/section/export", got, err)
+	}
+	pn, err := sp.pc.findPageNode(childReportsPage{})
+	if err != nil || pn.Title != "Reports" || pn.Parent.Name != "Admin" {
+		t.Errorf("reports node = %+v, %v; want title Reports below Admin", pn, err)
+	}
+}
+
+func TestAddChildRoute_InvalidParent(t *testing.T) {
+	sp, err := Mount(http.NewServeMux(), &childRootPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	err = sp.AddChildRoute(childReportsPage{}, &childExportPage{}, "/export", "Export")
+	if !errors.Is(err, ErrPageNotFound) || !strings.Contains(err.Error(), "childReportsPage") {
+		t.Errorf("AddChildRoute with an unmounted parent = %v, want ErrPageNotFound naming the type", err)
+	}
+	if err := sp.AddChildRoute(Ref("Missing"), &childExportPage{}, "/export", "Export"); err == nil {
+		t.Error("AddChildRoute with an unknown Ref: want an error")
+	}
+
+	unmounted, err := Parse(&childRootPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	err = unmounted.AddChildRoute(childAdminPage{}, &childExportPage{}, "/export", "")
+	if !errors.Is(err, ErrNotMounted) {
+		t.Errorf("AddChildRoute before Mount = %v, want ErrNotMounted", err)
+	}
+}
+
+func TestAddChildRoute_Match(t *testing.T) {
+	sp, err := Mount(http.NewServeMux(), &childRootPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	if _, _, err := sp.Match(http.MethodGet, "/admin/reports"); !errors.Is(err, ErrRouteNotFound) {
+		t.Fatalf("Match before AddChildRoute = %v, want ErrRouteNotFound", err)
+	}
+	if err := sp.AddChildRoute(childAdminPage{}, &childReportsPage{}, "/reports", "Reports"); err != nil {
+		t.Fatalf("AddChildRoute: %v", err)
+	}
+	if pn, _, err := sp.Match(http.MethodGet, "/admin/reports"); err != nil || pn.Title != "Reports" {
+		t.Errorf("Match after AddChildRoute = %v, %v; want Reports", pn, err)
+	}
+}
+
+func TestAddChildRoute_RegistrationFailureRollsBack(t *testing.T) {
+	mux := http.NewServeMux()
+	sp, err := Mount(mux, &childRootPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	// A route the page tree doesn't know about, so only the mux notices.
+	mux.Handle("/admin/reports", http.NotFoundHandler())
+	routes := len(sp.Export())
+
+	err = sp.AddChildRoute(childAdminPage{}, &childReportsPage{}, "/reports", "Reports")
+	if err == nil {
+		t.Fatal("AddChildRoute onto a taken pattern succeeded")
+	}
+	if got := len(sp.Export()); got != routes {
+		t.Errorf("Export has %d routes after the failure, want %d", got, routes)
+	}
+	if _, err := sp.URLFor(childReportsPage{}); err == nil {
+		t.Error("URLFor resolves the child that failed to register")
+	}
+	if err := sp.AddChildRoute(childAdminPage{}, &childReportsPage{}, "/monthly", "Reports"); err != nil {
+		t.Errorf("AddChildRoute after the rollback: %v", err)
+	}
+}
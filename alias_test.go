@@ -0,0 +1,114 @@
+package structpages
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type aliasAboutPage struct{}
+
+func (aliasAboutPage) Page() component { return testComponent{"about"} }
+
+func (aliasAboutPage) Aliases() []string { return []string{"/about-us"} }
+
+type aliasPostPage struct{}
+
+func (aliasPostPage) Page() component { return testComponent{"post"} }
+
+func (aliasPostPage) Aliases() []string { return []string{"/p/{slug}", "/articles/{slug}"} }
+
+type aliasBlogPages struct {
+	Post aliasPostPage `route:"GET /posts/{slug} Post"`
+}
+
+type aliasPages struct {
+	About aliasAboutPage `route:"/about About"`
+	Blog  aliasBlogPages `route:"/blog Blog"`
+}
+
+type aliasConflictPage struct{}
+
+func (aliasConflictPage) Page() component { return testComponent{"conflict"} }
+
+func (aliasConflictPage) Aliases() []string { return []string{"/about"} }
+
+type aliasConflictPages struct {
+	About    aliasAboutPage    `route:"/about About"`
+	Conflict aliasConflictPage `route:"/other Other"`
+}
+
+func TestAliases(t *testing.T) {
+	mux := http.NewServeMux()
+	sp, err := Mount(mux, &aliasPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	for path, body := range map[string]string{
+		"/about":               "about",
+		"/about-us":            "about",
+		"/blog/posts/hello":    "post",
+		"/blog/p/hello":        "post",
+		"/blog/articles/hello": "post",
+	} {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+		if rec.Code != http.StatusOK || rec.Body.String() != body {
+			t.Errorf("GET %s = %d %q, want 200 %q", path, rec.Code, rec.Body.String(), body)
+		}
+	}
+	// Aliases keep the page's method.
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/blog/p/hello", http.NoBody))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST /blog/p/hello = %d, want 405", rec.Code)
+	}
+
+	ctx := sp.PageContext(context.Background())
+	if got, err := URLFor(ctx, aliasPostPage{}, "hello"); err != nil || got != "/blog/posts/hello" {
+		t.Errorf("URLFor(aliasPostPage) = %q, %v; want the primary route", got, err)
+	}
+	tests := []struct {
+		page  any
+		alias int
+		want  string
+	}{
+		{aliasAboutPage{}, 0, "/about-us"},
+		{aliasPostPage{}, 0, "/blog/p/hello"},
+		{Ref("Blog.Post"), 1, "/blog/articles/hello"},
+	}
+	for _, tt := range tests {
+		if got, err := AliasURLFor(ctx, tt.page, tt.alias, "hello"); err != nil || got != tt.want {
+			t.Errorf("AliasURLFor(%T, %d) = %q, %v; want %q", tt.page, tt.alias, got, err, tt.want)
+		}
+	}
+	if _, err := AliasURLFor(ctx, aliasAboutPage{}, 1); err == nil {
+		t.Error("AliasURLFor with an out-of-range index: want an error")
+	}
+}
+
+func TestAliases_Conflict(t *testing.T) {
+	_, err := Mount(http.NewServeMux(), &aliasConflictPages{}, "/", "App")
+	if err == nil || !strings.Contains(err.Error(), `alias "/about"`) {
+		t.Errorf("Mount with an alias taken by another page = %v, want an alias conflict error", err)
+	}
+}
+
+func TestAliases_Match(t *testing.T) {
+	sp, err := Parse(&aliasPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	pn, params, err := sp.Match(http.MethodGet, "/blog/p/hello")
+	if err != nil || pn.Name != "Post" || params["slug"] != "hello" {
+		t.Errorf("Match alias = %v, %v, %v; want Post with slug=hello", pn, params, err)
+	}
+	if err := routeConflicts(sp); err != nil {
+		t.Errorf("routeConflicts = %v", err)
+	}
+	if _, err := Validate(&aliasConflictPages{}, "/", "App"); err == nil {
+		t.Error("Validate accepted an alias conflicting with a route")
+	}
+}
@@ -3,6 +3,8 @@ package structpages
 import (
 	"fmt"
 	"reflect"
+	"slices"
+	"strings"
 )
 
 type argRegistry map[reflect.Type]reflect.Value
@@ -20,8 +22,29 @@ func (args argRegistry) addArg(v any) error {
 	return nil
 }
 
+// getArg looks up a registered value for the requested type. It reports
+// false both when nothing matches and when the match is ambiguous; use
+// resolveArg to tell the two apart.
+//
 // note that p.args are always pointers
 func (args argRegistry) getArg(pt reflect.Type) (reflect.Value, bool) {
+	v, err := args.resolveArg(pt)
+	return v, err == nil && v.IsValid()
+}
+
+// resolveArg looks up a registered value for the requested type. The
+// resolution order is:
+//
+//  1. exact type match, with pointer/value coercion (*T registered, T
+//     requested and vice versa);
+//  2. for interface types, registered types implementing the interface —
+//     concrete types win over interface-typed registrations, and more than
+//     one remaining candidate is reported as an ambiguity error;
+//  3. any other registered type assignable to the requested type.
+//
+// A zero Value with a nil error means nothing matched.
+func (args argRegistry) resolveArg(pt reflect.Type) (reflect.Value, error) {
+	want := pt
 	st := pt
 	needsElem, needsPtr := false, false
 	if pt.Kind() != reflect.Pointer {
@@ -35,42 +58,105 @@ func (args argRegistry) getArg(pt reflect.Type) (reflect.Value, bool) {
 
 	if v, ok := args[pt]; ok {
 		if needsElem {
-			return v.Elem(), true
+			return v.Elem(), nil
 		}
-		return v, true
+		return v, nil
 	}
 
 	if v, ok := args[st]; ok {
 		if needsPtr {
 			// Check if the value is addressable before calling Addr()
 			if v.CanAddr() {
-				return v.Addr(), true
+				return v.Addr(), nil
 			}
 			// If not addressable, we can't convert to pointer
-			return reflect.Value{}, false
+			return reflect.Value{}, nil
 		}
-		return v, true
+		return v, nil
 	}
 
-	// Check assignability for less common cases
-	for t, v := range args {
-		// If looking for pointer type and found something assignable
-		if needsPtr && pt.AssignableTo(t) {
-			// We need to return a pointer, but can only do so if addressable
-			if v.CanAddr() {
-				return v.Addr(), true
-			}
-			// Skip non-addressable values when we need a pointer
-			continue
+	// Interface satisfaction: a registered *SQLStore fills a StoreInterface
+	// parameter even though it is keyed by its concrete type.
+	if want.Kind() == reflect.Interface {
+		candidates := implementingTypes(args.typesWhere(func(t reflect.Type) bool { return t.Implements(want) }), want)
+		switch len(candidates) {
+		case 0:
+		case 1:
+			return args[candidates[0]], nil
+		default:
+			return reflect.Value{}, ambiguousArgError(want, candidates)
+		}
+	}
+
+	// Check assignability for less common cases (e.g. chan T to <-chan T)
+	if types := args.typesWhere(func(t reflect.Type) bool { return t.AssignableTo(want) }); len(types) > 0 {
+		return args[types[0]], nil
+	}
+
+	return reflect.Value{}, nil
+}
+
+// types returns the registered types in a stable order so resolution never
+// depends on map iteration order.
+func (args argRegistry) types() []reflect.Type {
+	types := make([]reflect.Type, 0, len(args))
+	for t := range args {
+		types = append(types, t)
+	}
+	sortTypes(types)
+	return types
+}
+
+// typesWhere returns the registered types keep accepts, in the stable order
+// of types. Only the matches are sorted, so the usual lookup finding one or
+// none doesn't allocate and sort the whole registry.
+func (args argRegistry) typesWhere(keep func(reflect.Type) bool) []reflect.Type {
+	var types []reflect.Type
+	for t := range args {
+		if keep(t) {
+			types = append(types, t)
 		}
+	}
+	sortTypes(types)
+	return types
+}
 
-		// If looking for non-pointer type and found something assignable
-		if !needsPtr && st.AssignableTo(t) {
-			// For interface types, we typically don't need Elem()
-			// This handles concrete types being assignable to interface types
-			return v, true
+// implementingTypes returns the types in candidates that implement iface.
+// When both concrete and interface-typed candidates match, only the
+// concrete ones are kept: they are the most specific registration.
+func implementingTypes(candidates []reflect.Type, iface reflect.Type) []reflect.Type {
+	var concrete, abstract []reflect.Type
+	for _, t := range candidates {
+		if !t.Implements(iface) {
+			continue
 		}
+		if t.Kind() == reflect.Interface {
+			abstract = append(abstract, t)
+		} else {
+			concrete = append(concrete, t)
+		}
+	}
+	if len(concrete) > 0 {
+		return concrete
+	}
+	return abstract
+}
+
+// ambiguousArgError reports that more than one registered type satisfies
+// the requested interface.
+func ambiguousArgError(want reflect.Type, candidates []reflect.Type) error {
+	names := make([]string, len(candidates))
+	for i, t := range candidates {
+		names[i] = t.String()
 	}
+	return fmt.Errorf("ambiguous argument of type %s: satisfied by %d registered types: %s; "+
+		"request a concrete type or register a named type to disambiguate",
+		want, len(candidates), strings.Join(names, ", "))
+}
 
-	return reflect.Value{}, false
+// sortTypes orders types by their string form.
+func sortTypes(types []reflect.Type) {
+	slices.SortFunc(types, func(a, b reflect.Type) int {
+		return strings.Compare(a.String(), b.String())
+	})
 }
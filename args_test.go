@@ -1,7 +1,10 @@
 package structpages
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -129,12 +132,13 @@ func TestArgRegistry_getArg(t *testing.T) {
 			wantFound:  true,
 		},
 		{
-			name: "implementation not assignable to interface",
+			name: "implementation satisfies interface",
 			registry: argRegistry{
 				reflect.TypeOf(implVal): reflect.ValueOf(implVal),
 			},
 			lookupType: reflect.TypeOf((*testInterface)(nil)).Elem(),
-			wantFound:  false,
+			wantFound:  true,
+			wantValue:  implVal,
 		},
 		{
 			name:       "empty registry returns not found",
@@ -316,12 +320,12 @@ func TestArgRegistry_getArg_edgeCases(t *testing.T) {
 			wantFound:  false,
 		},
 		{
-			name: "interface assignability - value types not directly assignable",
+			name: "interface satisfaction - derived interface satisfies base",
 			registry: argRegistry{
 				reflect.TypeOf((*derivedInterface)(nil)).Elem(): reflect.ValueOf(fullImpl{}),
 			},
 			lookupType: reflect.TypeOf((*baseInterface)(nil)).Elem(),
-			wantFound:  false,
+			wantFound:  true,
 		},
 		{
 			name: "type stored with exact match in loop",
@@ -408,9 +412,9 @@ func TestArgRegistry_integration(t *testing.T) {
 		t.Error("Failed to retrieve non-pointer type")
 	}
 
-	// Test that interface retrieval fails because we didn't store interface type
-	if _, ok := registry.getArg(reflect.TypeOf((*testInterface)(nil)).Elem()); ok {
-		t.Error("Should not retrieve interface type when implementation was stored")
+	// The interface is satisfied by the stored *testImplementation
+	if v, ok := registry.getArg(reflect.TypeOf((*testInterface)(nil)).Elem()); !ok || v.Interface() != impl {
+		t.Error("Expected interface lookup to resolve to the stored implementation")
 	}
 }
 
@@ -813,3 +817,155 @@ func TestArgRegistry_getArg_remainingPaths(t *testing.T) {
 		t.Log("Remaining uncovered paths are theoretical edge cases in Go's type system")
 	})
 }
+
+// Types for interface-satisfaction DI tests
+type diStore interface {
+	Name() string
+}
+
+type diSQLStore struct{ dsn string }
+
+func (s *diSQLStore) Name() string { return "sql:" + s.dsn }
+
+type diMemStore struct{}
+
+func (diMemStore) Name() string { return "mem" }
+
+type diStorePage struct{}
+
+func (diStorePage) Page(name string) component { return testComponent{name} }
+
+func (diStorePage) Props(store diStore) (string, error) { return store.Name(), nil }
+
+type diStorePages struct {
+	diStorePage `route:"/ Store"`
+}
+
+func TestArgRegistry_resolveArg_interfaceSatisfaction(t *testing.T) {
+	storeType := reflect.TypeOf((*diStore)(nil)).Elem()
+
+	t.Run("concrete pointer satisfies interface", func(t *testing.T) {
+		registry := make(argRegistry)
+		sql := &diSQLStore{dsn: "db"}
+		if err := registry.addArg(sql); err != nil {
+			t.Fatal(err)
+		}
+		v, err := registry.resolveArg(storeType)
+		if err != nil {
+			t.Fatalf("resolveArg: %v", err)
+		}
+		if !v.IsValid() || v.Interface() != sql {
+			t.Fatalf("resolveArg = %v, want the registered *diSQLStore", v)
+		}
+	})
+
+	t.Run("value does not satisfy pointer-receiver interface", func(t *testing.T) {
+		registry := make(argRegistry)
+		if err := registry.addArg(diSQLStore{dsn: "db"}); err != nil {
+			t.Fatal(err)
+		}
+		v, err := registry.resolveArg(storeType)
+		if err != nil {
+			t.Fatalf("resolveArg: %v", err)
+		}
+		if v.IsValid() {
+			t.Fatalf("resolveArg = %v, want not found", v)
+		}
+	})
+
+	t.Run("two implementations are ambiguous", func(t *testing.T) {
+		registry := make(argRegistry)
+		if err := registry.addArg(&diSQLStore{}); err != nil {
+			t.Fatal(err)
+		}
+		if err := registry.addArg(diMemStore{}); err != nil {
+			t.Fatal(err)
+		}
+		_, err := registry.resolveArg(storeType)
+		if err == nil {
+			t.Fatal("expected ambiguity error")
+		}
+		for _, want := range []string{"ambiguous", "*structpages.diSQLStore", "structpages.diMemStore"} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("error %q does not mention %q", err, want)
+			}
+		}
+		if _, ok := registry.getArg(storeType); ok {
+			t.Error("getArg reported found for an ambiguous lookup")
+		}
+	})
+
+	t.Run("concrete preferred over interface registration", func(t *testing.T) {
+		registry := make(argRegistry)
+		sql := &diSQLStore{dsn: "db"}
+		registry[reflect.TypeOf((*derivedStore)(nil)).Elem()] = reflect.ValueOf(derivedStoreImpl{})
+		if err := registry.addArg(sql); err != nil {
+			t.Fatal(err)
+		}
+		v, err := registry.resolveArg(storeType)
+		if err != nil {
+			t.Fatalf("resolveArg: %v", err)
+		}
+		if v.Interface() != sql {
+			t.Fatalf("resolveArg = %v, want the concrete *diSQLStore", v.Interface())
+		}
+	})
+}
+
+func TestFindMatchingArg_skipsUsedImplementations(t *testing.T) {
+	storeType := reflect.TypeOf((*diStore)(nil)).Elem()
+	sql, mem := reflect.ValueOf(&diSQLStore{}), reflect.ValueOf(diMemStore{})
+	available := map[reflect.Type][]reflect.Value{sql.Type(): {sql}, mem.Type(): {mem}}
+	used := map[reflect.Value]bool{sql: true}
+
+	v, ok, err := (&parseContext{}).findMatchingArg(storeType, available, used)
+	if err != nil {
+		t.Fatalf("findMatchingArg: %v", err)
+	}
+	if !ok || v != mem {
+		t.Fatalf("findMatchingArg = %v, %v; want the unused diMemStore", v, ok)
+	}
+}
+
+type derivedStore interface {
+	diStore
+	Close()
+}
+
+type derivedStoreImpl struct{}
+
+func (derivedStoreImpl) Name() string { return "derived" }
+func (derivedStoreImpl) Close()       {}
+
+func TestProps_InterfaceArgInjection(t *testing.T) {
+	t.Run("registered pointer fills interface parameter", func(t *testing.T) {
+		mux := http.NewServeMux()
+		if _, err := Mount(mux, &diStorePages{}, "/", "App", WithArgs(&diSQLStore{dsn: "db"})); err != nil {
+			t.Fatalf("Mount: %v", err)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+		if rec.Code != http.StatusOK || rec.Body.String() != "sql:db" {
+			t.Fatalf("got %d %q, want 200 %q", rec.Code, rec.Body.String(), "sql:db")
+		}
+	})
+
+	t.Run("two implementations surface an ambiguity error", func(t *testing.T) {
+		var gotErr error
+		mux := http.NewServeMux()
+		_, err := Mount(mux, &diStorePages{}, "/", "App",
+			WithArgs(&diSQLStore{}, diMemStore{}),
+			WithErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+				gotErr = err
+				w.WriteHeader(http.StatusInternalServerError)
+			}))
+		if err != nil {
+			t.Fatalf("Mount: %v", err)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+		if gotErr == nil || !strings.Contains(gotErr.Error(), "ambiguous argument") {
+			t.Fatalf("error = %v, want ambiguity error", gotErr)
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package structpages
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// RequestBodyLimitMiddleware returns a middleware capping request bodies at
+// maxBytes, or for a page with a MaxRequestBodyBytes method at the positive
+// value it returns:
+//
+//	func (uploadPage) MaxRequestBodyBytes() int64 { return 32 << 20 }
+//
+//	structpages.WithMiddlewares(structpages.RequestBodyLimitMiddleware(1 << 20))
+//
+// The body is wrapped with http.MaxBytesReader, so reading past the limit
+// fails and the connection is closed after the response. An error-returning
+// Props or ServeHTTP that returns the read error, wrapped or not, gets a
+// 413 HTTPError passed to the error handler instead.
+func RequestBodyLimitMiddleware(maxBytes int64) MiddlewareFunc {
+	return func(next http.Handler, pn *PageNode) http.Handler {
+		limit := maxBytes
+		if pn.maxRequestBodyBytes > 0 {
+			limit = pn.maxRequestBodyBytes
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Body != nil && r.Body != http.NoBody {
+				r.Body = http.MaxBytesReader(w, r.Body, limit)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// bodyTooLarge turns err into a 413 HTTPError if it comes from reading past
+// a request body limit, and returns it unchanged otherwise.
+func bodyTooLarge(err error) error {
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		return &HTTPError{
+			Code: http.StatusRequestEntityTooLarge,
+			Err:  fmt.Errorf("request body exceeds the %d byte limit: %w", maxErr.Limit, err),
+		}
+	}
+	return err
+}
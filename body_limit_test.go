@@ -0,0 +1,98 @@
+package structpages
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type bodyEchoPage struct{}
+
+func (bodyEchoPage) Props(r *http.Request) (string, error) {
+	b, err := io.ReadAll(r.Body)
+	return string(b), err
+}
+
+func (bodyEchoPage) Page(s string) component { return testComponent{s} }
+
+type bigBodyPage struct{}
+
+func (bigBodyPage) Props(r *http.Request) (string, error) { return bodyEchoPage{}.Props(r) }
+
+func (bigBodyPage) Page(s string) component { return testComponent{s} }
+
+func (bigBodyPage) MaxRequestBodyBytes() int64 { return 20 }
+
+type bodyLimitPages struct {
+	Echo bodyEchoPage `route:"POST /echo Echo"`
+	Big  bigBodyPage  `route:"POST /big Big"`
+}
+
+func TestRequestBodyLimitMiddleware(t *testing.T) {
+	var gotErr error
+	mux := http.NewServeMux()
+	_, err := Mount(mux, bodyLimitPages{}, "/", "App",
+		WithMiddlewares(RequestBodyLimitMiddleware(10)),
+		WithErrorHandler(func(w http.ResponseWriter, _ *http.Request, err error) {
+			gotErr = err
+			var httpErr *HTTPError
+			if errors.As(err, &httpErr) {
+				http.Error(w, err.Error(), httpErr.Code)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	tests := []struct {
+		name     string
+		path     string
+		body     string
+		wantCode int
+		wantErr  string
+	}{
+		{"small body", "/echo", "hello", http.StatusOK, ""},
+		{"over the limit", "/echo", strings.Repeat("x", 11), http.StatusRequestEntityTooLarge, "10 byte limit"},
+		{"page limit overrides", "/big", strings.Repeat("x", 15), http.StatusOK, ""},
+		{"over the page limit", "/big", strings.Repeat("x", 21), http.StatusRequestEntityTooLarge, "20 byte limit"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotErr = nil
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body)))
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantCode, rec.Body.String())
+			}
+			if tt.wantErr == "" {
+				if rec.Body.String() != tt.body {
+					t.Errorf("body = %q, want %q", rec.Body.String(), tt.body)
+				}
+				return
+			}
+			var maxErr *http.MaxBytesError
+			if !errors.As(gotErr, &maxErr) || !strings.Contains(gotErr.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want a MaxBytesError mentioning %q", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+type badBodyLimitPage struct{}
+
+func (badBodyLimitPage) Page() component { return testComponent{""} }
+
+func (badBodyLimitPage) MaxRequestBodyBytes() int { return 1 }
+
+func TestMaxRequestBodyBytes_BadSignature(t *testing.T) {
+	_, err := Mount(http.NewServeMux(), struct {
+		P badBodyLimitPage `route:"/ P"`
+	}{}, "/", "App")
+	if err == nil || !strings.Contains(err.Error(), "must take no arguments and return an int64") {
+		t.Errorf("Mount = %v, want a signature error", err)
+	}
+}
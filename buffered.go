@@ -80,6 +80,23 @@ func (w *buffered) FlushError() error {
 // to access extended functionality like Hijack, etc.
 func (w *buffered) Unwrap() http.ResponseWriter { return w.ResponseWriter }
 
+// Push forwards an HTTP/2 server push to the underlying ResponseWriter. The
+// push is not buffered, so it is sent before the buffered response body.
+func (w *buffered) Push(target string, opts *http.PushOptions) error {
+	return push(w.ResponseWriter, target, opts)
+}
+
+// push initiates a server push on w, or returns http.ErrNotSupported when w
+// is not an http.Pusher (HTTP/1.x, or a client that disabled push). The
+// writers wrapping the response use it so a type assertion to http.Pusher
+// on them works as it does on the connection's own writer.
+func push(w http.ResponseWriter, target string, opts *http.PushOptions) error {
+	if p, ok := w.(http.Pusher); ok {
+		return p.Push(target, opts)
+	}
+	return http.ErrNotSupported
+}
+
 func (w *buffered) close() error {
 	if !w.headerSent {
 		w.ResponseWriter.WriteHeader(w.status)
@@ -1,6 +1,10 @@
 package structpages
 
-import "strings"
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
 
 // This file handles the bidirectional conversion between component method names
 // and HTML IDs/HTMX targets.
@@ -15,6 +19,10 @@ import "strings"
 // camelToKebab converts a CamelCase or camelCase string to kebab-case.
 // Used by IDFor to generate HTML IDs from method names.
 //
+// It works on runes, so non-ASCII letters are handled by their Unicode
+// case: "ÄnderungsPage" -> "änderungs-page". Letters without case, such
+// as CJK, are never split.
+//
 // Examples:
 //   - "UserList" -> "user-list"
 //   - "HTMLParser" -> "html-parser"
@@ -27,20 +35,20 @@ func camelToKebab(s string) string {
 	var result strings.Builder
 	result.Grow(len(s) + 5) // Preallocate with some extra space for hyphens
 
+	var prev rune
 	for i, r := range s {
-		if i > 0 && r >= 'A' && r <= 'Z' {
+		if i > 0 && unicode.IsUpper(r) {
 			// Add hyphen before uppercase letter (but not at start)
 			// Check if previous char was not uppercase to avoid "HTML" -> "h-t-m-l"
-			if i > 0 && s[i-1] >= 'a' && s[i-1] <= 'z' {
+			if unicode.IsLower(prev) {
 				result.WriteByte('-')
-			} else if i+1 < len(s) && s[i+1] >= 'a' && s[i+1] <= 'z' {
+			} else if next, _ := utf8.DecodeRuneInString(s[i+utf8.RuneLen(r):]); unicode.IsLower(next) {
 				// Handle "HTMLParser" -> "html-parser" (uppercase sequence followed by lowercase)
-				if i > 0 {
-					result.WriteByte('-')
-				}
+				result.WriteByte('-')
 			}
 		}
-		result.WriteRune(r | ('a' - 'A')) // Convert to lowercase using bit operation
+		result.WriteRune(unicode.ToLower(r))
+		prev = r
 	}
 
 	return result.String()
@@ -53,6 +61,7 @@ func camelToKebab(s string) string {
 //   - "todo-list" -> "TodoList"
 //   - "html-parser" -> "HtmlParser"
 //   - "content" -> "Content"
+//   - "änderungs-page" -> "ÄnderungsPage"
 func kebabToPascal(s string) string {
 	if s == "" {
 		return s
@@ -60,7 +69,8 @@ func kebabToPascal(s string) string {
 	parts := strings.Split(s, "-")
 	for i, part := range parts {
 		if part != "" {
-			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+			first, size := utf8.DecodeRuneInString(part)
+			parts[i] = string(unicode.ToUpper(first)) + part[size:]
 		}
 	}
 	return strings.Join(parts, "")
@@ -0,0 +1,57 @@
+package structpages
+
+import "errors"
+
+// WithCollectErrors makes Mount, Parse and Validate keep parsing the page
+// tree after an error, so a large tree reports every problem at once: a page
+// that fails is left out and parsing moves on to its siblings, and the
+// errors are returned together in a *MultiError. By default parsing stops
+// at the first error.
+func WithCollectErrors(collect bool) func(*StructPages) {
+	return func(sp *StructPages) {
+		sp.collectErrors = collect
+	}
+}
+
+// MultiError holds the errors WithCollectErrors collected, in the order the
+// pages were parsed. errors.Is and errors.As look through all of them.
+type MultiError struct {
+	Errors []error
+}
+
+// Error formats the errors one per line, as errors.Join does.
+func (e *MultiError) Error() string {
+	return errors.Join(e.Errors...).Error()
+}
+
+// Unwrap returns the collected errors for errors.Is and errors.As.
+func (e *MultiError) Unwrap() []error { return e.Errors }
+
+// fail records err, and the errors it joins, when collecting errors and
+// returns nil so parsing continues; otherwise it returns err.
+func (p *parseContext) fail(err error) error {
+	if !p.collectErrors {
+		return err
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		p.errs = append(p.errs, joined.Unwrap()...)
+	} else if err != nil {
+		p.errs = append(p.errs, err)
+	}
+	return nil
+}
+
+// mapErrors applies fn to err, or to each error err joins, keeping them
+// joined.
+func mapErrors(err error, fn func(error) error) error {
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return fn(err)
+	}
+	errs := joined.Unwrap()
+	mapped := make([]error, len(errs))
+	for i, err := range errs {
+		mapped[i] = fn(err)
+	}
+	return errors.Join(mapped...)
+}
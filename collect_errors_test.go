@@ -0,0 +1,84 @@
+package structpages
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+var errCollectUnavailable = errors.New("search backend unavailable")
+
+type collectDB struct{}
+
+type collectUsersPage struct{}
+
+func (collectUsersPage) Init(*collectDB) error { return nil }
+
+func (collectUsersPage) Page() component { return testComponent{"users"} }
+
+type collectAboutPage struct{}
+
+func (collectAboutPage) Aliases() []string { return []string{"about-us"} }
+
+func (collectAboutPage) Page() component { return testComponent{"about"} }
+
+type collectSearchPage struct{}
+
+func (collectSearchPage) Init() error {
+	return &HTTPError{Code: http.StatusServiceUnavailable, Err: errCollectUnavailable}
+}
+
+func (collectSearchPage) Page() component { return testComponent{"search"} }
+
+type collectHomePage struct{}
+
+func (collectHomePage) Page() component { return testComponent{"home"} }
+
+type collectSection struct {
+	Search collectSearchPage `route:"/search Search"`
+}
+
+type collectPages struct {
+	Home    collectHomePage  `route:"/{$} Home"`
+	Users   collectUsersPage `route:"/users Users"`
+	About   collectAboutPage `route:"/about About"`
+	Section collectSection   `route:"/section Section"`
+}
+
+func TestWithCollectErrors(t *testing.T) {
+	_, err := Mount(http.NewServeMux(), &collectPages{}, "/", "App", WithCollectErrors(true))
+	var multi *MultiError
+	if !errors.As(err, &multi) {
+		t.Fatalf("Mount = %v, want a *MultiError", err)
+	}
+	if len(multi.Errors) != 3 {
+		t.Fatalf("MultiError has %d errors, want 3:\n%v", len(multi.Errors), err)
+	}
+	for i, want := range []string{"field Users:", `field About: alias "about-us"`, "field Search:"} {
+		if !strings.Contains(multi.Errors[i].Error(), want) {
+			t.Errorf("Errors[%d] = %v, want it to contain %q", i, multi.Errors[i], want)
+		}
+	}
+	if lines := strings.Count(err.Error(), "\n"); lines != 2 {
+		t.Errorf("Error() has %d newlines, want one error per line:\n%v", lines, err)
+	}
+	if !errors.Is(err, errCollectUnavailable) {
+		t.Error("errors.Is(err, errCollectUnavailable) = false through MultiError")
+	}
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Code != http.StatusServiceUnavailable {
+		t.Errorf("errors.As(err, *HTTPError) = %v, want the Init error's 503", httpErr)
+	}
+}
+
+func TestWithCollectErrors_DefaultFailsFast(t *testing.T) {
+	_, err := Mount(http.NewServeMux(), &collectPages{}, "/", "App")
+	var multi *MultiError
+	if err == nil || errors.As(err, &multi) {
+		t.Fatalf("Mount = %v, want the first error alone", err)
+	}
+	if !strings.Contains(err.Error(), "field Users:") {
+		t.Errorf("Mount = %v, want the Users error", err)
+	}
+}
@@ -0,0 +1,41 @@
+package structpages
+
+import "net/http"
+
+// WithComponentFallbackChain sets the components an HTMX partial request
+// falls back to when its HX-Target matches none of the page's components
+// (with HTMXConfig.AllowFallback) or it has no HX-Target at all. Names are
+// tried in order and the first one the page defines is rendered; Page is
+// always the last resort:
+//
+//	structpages.WithComponentFallbackChain("PartialView", "Content")
+//
+// A page can set its own chain with a ComponentChain() []string method. Full
+// page loads, boosted and history-restore requests still render Page.
+func WithComponentFallbackChain(chain ...string) func(*StructPages) {
+	return func(r *StructPages) {
+		r.componentChain = chain
+	}
+}
+
+// ComponentFallbackTarget returns the target a partial request for pn falls
+// back to: the first component of its chain (the page's ComponentChain
+// method, else WithComponentFallbackChain) that pn defines, else Page.
+// Custom TargetSelectors can return it when they have no better match.
+func ComponentFallbackTarget(r *http.Request, pn *PageNode) RenderTarget {
+	return fallbackTarget(pcCtx.Value(r.Context()), pn)
+}
+
+func fallbackTarget(pc *parseContext, pn *PageNode) RenderTarget {
+	chain := pn.componentChain
+	if chain == nil && pc != nil {
+		chain = pc.componentChain
+	}
+	for _, name := range chain {
+		if method, ok := pn.Components[name]; ok {
+			return newMethodRenderTarget(name, &method)
+		}
+	}
+	pageMethod := pn.Components["Page"]
+	return newMethodRenderTarget("Page", &pageMethod)
+}
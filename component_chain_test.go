@@ -0,0 +1,130 @@
+package structpages
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type chainPartialPage struct{}
+
+func (chainPartialPage) Page() component        { return testComponent{"page"} }
+func (chainPartialPage) PartialView() component { return testComponent{"partial"} }
+func (chainPartialPage) Sidebar() component     { return testComponent{"sidebar"} }
+
+type chainPageOnly struct{}
+
+func (chainPageOnly) Page() component { return testComponent{"page"} }
+
+type chainOwnPage struct{}
+
+func (chainOwnPage) ComponentChain() []string { return []string{"Content"} }
+func (chainOwnPage) Page() component          { return testComponent{"page"} }
+func (chainOwnPage) PartialView() component   { return testComponent{"partial"} }
+func (chainOwnPage) Content() component       { return testComponent{"content"} }
+
+type fallbackChainPages struct {
+	Partial chainPartialPage `route:"/partial Partial"`
+	Only    chainPageOnly    `route:"/only Only"`
+	Own     chainOwnPage     `route:"/own Own"`
+}
+
+func fallbackChainGet(t *testing.T, mux http.Handler, path string, headers map[string]string) string {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, path, http.NoBody)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET %s: status %d: %s", path, rec.Code, rec.Body.String())
+	}
+	return rec.Body.String()
+}
+
+func TestWithComponentFallbackChain(t *testing.T) {
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &fallbackChainPages{}, "/", "App",
+		WithComponentFallbackChain("Content", "PartialView"))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	hx := map[string]string{"HX-Request": "true"}
+
+	tests := []struct {
+		name    string
+		path    string
+		headers map[string]string
+		want    string
+	}{
+		{"skips missing Content", "/partial", hx, "partial"},
+		{"Page is the last resort", "/only", hx, "page"},
+		{"page ComponentChain overrides option", "/own", hx, "content"},
+		{"matched target wins", "/partial", map[string]string{"HX-Request": "true", "HX-Target": "sidebar"}, "sidebar"},
+		{"full load renders Page", "/partial", nil, "page"},
+		{"boosted renders Page", "/partial", map[string]string{"HX-Request": "true", "HX-Boosted": "true"}, "page"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fallbackChainGet(t, mux, tt.path, tt.headers); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithComponentFallbackChain_AllowFallback(t *testing.T) {
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &fallbackChainPages{}, "/", "App",
+		WithComponentFallbackChain("PartialView"),
+		WithHTMXConfig(HTMXConfig{AllowFallback: true}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	got := fallbackChainGet(t, mux, "/partial", map[string]string{"HX-Request": "true", "HX-Target": "unknown"})
+	if got != "partial" {
+		t.Errorf("got %q, want partial", got)
+	}
+}
+
+func TestWithComponentFallbackChain_TargetSelector(t *testing.T) {
+	selector := func(r *http.Request, pn *PageNode) (RenderTarget, error) {
+		if r.Header.Get("X-Sidebar") != "" {
+			m := pn.Components["Sidebar"]
+			return newMethodRenderTarget("Sidebar", &m), nil
+		}
+		return ComponentFallbackTarget(r, pn), nil
+	}
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &fallbackChainPages{}, "/", "App",
+		WithTargetSelector(selector),
+		WithComponentFallbackChain("PartialView"))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	if got := fallbackChainGet(t, mux, "/partial", map[string]string{"X-Sidebar": "1"}); got != "sidebar" {
+		t.Errorf("got %q, want sidebar", got)
+	}
+	if got := fallbackChainGet(t, mux, "/partial", nil); got != "partial" {
+		t.Errorf("got %q, want partial", got)
+	}
+	if got := fallbackChainGet(t, mux, "/only", nil); got != "page" {
+		t.Errorf("got %q, want page", got)
+	}
+}
+
+type badComponentChainPage struct{}
+
+func (badComponentChainPage) ComponentChain() string { return "x" }
+func (badComponentChainPage) Page() component        { return testComponent{"x"} }
+
+func TestComponentChain_BadSignature(t *testing.T) {
+	_, err := Parse(&struct {
+		Bad badComponentChainPage `route:"/bad Bad"`
+	}{}, "/", "App")
+	if err == nil || !strings.Contains(err.Error(), "ComponentChain method on Bad must take no arguments") {
+		t.Fatalf("err = %v, want ComponentChain signature error", err)
+	}
+}
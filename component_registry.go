@@ -0,0 +1,95 @@
+package structpages
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+)
+
+// Component is anything structpages can render; templ.Component satisfies
+// it.
+type Component = component
+
+// ErrComponentNotFound is returned by ComponentRegistry.Get for a name that
+// was never registered.
+var ErrComponentNotFound = errors.New("structpages: component not found")
+
+var componentRegistryType = reflect.TypeOf((*ComponentRegistry)(nil))
+
+// ComponentRegistry holds named shared components such as headers, footers,
+// and breadcrumbs, built per request. Pass one to WithComponentRegistry and
+// declare a *ComponentRegistry parameter to use it from Props:
+//
+//	func (p home) Props(r *http.Request, reg *structpages.ComponentRegistry) (homeProps, error) {
+//	    header, err := reg.Get(r, "header")
+//	    if err != nil {
+//	        return homeProps{}, err
+//	    }
+//	    return homeProps{Header: header}, nil
+//	}
+//
+// The zero value is ready to use and safe for concurrent use.
+type ComponentRegistry struct {
+	mu         sync.RWMutex
+	components map[string]func(*http.Request) (Component, error)
+}
+
+// Register adds fn under name, replacing any component already registered
+// with that name.
+func (reg *ComponentRegistry) Register(name string, fn func(*http.Request) (Component, error)) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	if reg.components == nil {
+		reg.components = make(map[string]func(*http.Request) (Component, error))
+	}
+	reg.components[name] = fn
+}
+
+// Lookup returns the function registered under name.
+func (reg *ComponentRegistry) Lookup(name string) (func(*http.Request) (Component, error), bool) {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+	fn, ok := reg.components[name]
+	return fn, ok
+}
+
+// Get builds the component registered under name for r. It returns an error
+// wrapping ErrComponentNotFound if there is none.
+func (reg *ComponentRegistry) Get(r *http.Request, name string) (Component, error) {
+	fn, ok := reg.Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrComponentNotFound, name)
+	}
+	return fn(r)
+}
+
+// WithComponentRegistry registers reg for dependency injection, so page
+// methods can declare a *ComponentRegistry parameter, and makes it the
+// registry StructPages.RegisterGlobal adds to.
+func WithComponentRegistry(reg *ComponentRegistry) func(*StructPages) {
+	return func(r *StructPages) {
+		r.components = reg
+		r.args = append(r.args, reg)
+	}
+}
+
+// RegisterGlobal registers a shared component on the registry set by
+// WithComponentRegistry. Without one, it uses a *ComponentRegistry passed to
+// WithArgs, or creates a registry and registers it for injection.
+func (sp *StructPages) RegisterGlobal(name string, fn func(*http.Request) (Component, error)) {
+	sp.checkWritable()
+	sp.componentsOnce.Do(func() {
+		if sp.components != nil {
+			return
+		}
+		if v, err := sp.pc.resolveRegistered(componentRegistryType); err == nil && v.IsValid() {
+			sp.components = v.Interface().(*ComponentRegistry)
+			return
+		}
+		sp.components = &ComponentRegistry{}
+		_ = sp.AddArg(sp.components)
+	})
+	sp.components.Register(name, fn)
+}
@@ -0,0 +1,118 @@
+package structpages
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+type registryHomePage struct{}
+
+func (registryHomePage) Props(r *http.Request, reg *ComponentRegistry) (component, error) {
+	return reg.Get(r, "header")
+}
+
+func (registryHomePage) Page(c component) component { return c }
+
+type registryPages struct {
+	registryHomePage `route:"/ Home"`
+}
+
+func registryHeader(r *http.Request) (Component, error) {
+	return testComponent{"header for " + r.URL.Path}, nil
+}
+
+func TestComponentRegistry(t *testing.T) {
+	var reg ComponentRegistry
+	r := httptest.NewRequest(http.MethodGet, "/x", http.NoBody)
+
+	if _, ok := reg.Lookup("header"); ok {
+		t.Error("Lookup on empty registry succeeded")
+	}
+	if _, err := reg.Get(r, "header"); !errors.Is(err, ErrComponentNotFound) {
+		t.Errorf("Get missing = %v, want ErrComponentNotFound", err)
+	}
+
+	reg.Register("header", registryHeader)
+	fn, ok := reg.Lookup("header")
+	if !ok {
+		t.Fatal("Lookup after Register failed")
+	}
+	c, err := fn(r)
+	if err != nil || c.(testComponent).content != "header for /x" {
+		t.Errorf("component = %v, %v", c, err)
+	}
+}
+
+func TestComponentRegistry_Concurrent(t *testing.T) {
+	var reg ComponentRegistry
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			name := fmt.Sprintf("c%d", i)
+			reg.Register(name, registryHeader)
+			if _, ok := reg.Lookup(name); !ok {
+				t.Errorf("Lookup(%s) failed", name)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestWithComponentRegistry(t *testing.T) {
+	serve := func(t *testing.T, mux *http.ServeMux) *httptest.ResponseRecorder {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+		return rec
+	}
+
+	t.Run("injected into Props", func(t *testing.T) {
+		reg := &ComponentRegistry{}
+		reg.Register("header", registryHeader)
+		mux := http.NewServeMux()
+		if _, err := Mount(mux, &registryPages{}, "/", "App", WithComponentRegistry(reg)); err != nil {
+			t.Fatalf("Mount: %v", err)
+		}
+		if rec := serve(t, mux); rec.Body.String() != "header for /" {
+			t.Errorf("body = %q", rec.Body.String())
+		}
+	})
+
+	t.Run("RegisterGlobal without option", func(t *testing.T) {
+		var gotErr error
+		mux := http.NewServeMux()
+		sp, err := Mount(mux, &registryPages{}, "/", "App",
+			WithErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+				gotErr = err
+				w.WriteHeader(http.StatusInternalServerError)
+			}))
+		if err != nil {
+			t.Fatalf("Mount: %v", err)
+		}
+		if serve(t, mux); gotErr == nil {
+			t.Error("request without registry succeeded")
+		}
+		sp.RegisterGlobal("header", registryHeader)
+		if rec := serve(t, mux); rec.Body.String() != "header for /" {
+			t.Errorf("body = %q", rec.Body.String())
+		}
+	})
+
+	t.Run("RegisterGlobal reuses WithArgs registry", func(t *testing.T) {
+		reg := &ComponentRegistry{}
+		sp, err := Parse(&registryPages{}, "/", "App", WithArgs(reg))
+		if err != nil {
+			t.Fatalf("Parse: %v", err)
+		}
+		sp.RegisterGlobal("header", registryHeader)
+		if _, ok := reg.Lookup("header"); !ok {
+			t.Error("RegisterGlobal did not use the WithArgs registry")
+		}
+	})
+}
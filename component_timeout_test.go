@@ -0,0 +1,118 @@
+package structpages
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sleepComponent ignores ctx, like most templ components, and just takes
+// a while.
+type sleepComponent struct {
+	content string
+	delay   time.Duration
+}
+
+func (c sleepComponent) Render(ctx context.Context, w io.Writer) error {
+	time.Sleep(c.delay)
+	_, err := io.WriteString(w, c.content)
+	return err
+}
+
+type timeoutPage struct{}
+
+func (timeoutPage) Page() component { return testComponent{"fast page"} }
+
+func (timeoutPage) Details() component { return sleepComponent{"slow details", 50 * time.Millisecond} }
+
+func (timeoutPage) Props(r *http.Request, target RenderTarget) error {
+	if r.URL.Query().Has("direct") {
+		return RenderComponent(sleepComponent{"direct", 50 * time.Millisecond})
+	}
+	return nil
+}
+
+type timeoutPages struct {
+	timeoutPage `route:"/ Home"`
+}
+
+func TestWithComponentTimeout(t *testing.T) {
+	var calls []string
+	selector := func(pn *PageNode, name string) time.Duration {
+		calls = append(calls, pn.Name+"."+name)
+		switch name {
+		case "Details", "":
+			return 5 * time.Millisecond
+		default:
+			return 0
+		}
+	}
+	var gotErr error
+	mux := http.NewServeMux()
+	sp, err := Mount(mux, &timeoutPages{}, "/", "App",
+		WithComponentTimeout(selector),
+		WithErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+			gotErr = err
+			http.Error(w, "timeout", http.StatusGatewayTimeout)
+		}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	serve := func(path string, hxTarget string) *httptest.ResponseRecorder {
+		gotErr = nil
+		req := httptest.NewRequest(http.MethodGet, path, http.NoBody)
+		if hxTarget != "" {
+			req.Header.Set("HX-Request", "true")
+			req.Header.Set("HX-Target", hxTarget)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("zero duration means no limit", func(t *testing.T) {
+		rec := serve("/", "")
+		if rec.Code != http.StatusOK || rec.Body.String() != "fast page" || gotErr != nil {
+			t.Errorf("got %d %q (err %v), want 200 %q", rec.Code, rec.Body.String(), gotErr, "fast page")
+		}
+	})
+
+	t.Run("deadline exceeded", func(t *testing.T) {
+		id, err := sp.ID(timeoutPage.Details)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec := serve("/", id)
+		if rec.Code != http.StatusGatewayTimeout || strings.Contains(rec.Body.String(), "slow details") {
+			t.Fatalf("got %d %q, want 504 without the partial output", rec.Code, rec.Body.String())
+		}
+		var te *ComponentTimeoutError
+		if !errors.As(gotErr, &te) {
+			t.Fatalf("error = %v, want *ComponentTimeoutError", gotErr)
+		}
+		if te.Page != "timeoutPage" || te.Component != "Details" || te.Timeout != 5*time.Millisecond {
+			t.Errorf("ComponentTimeoutError = %+v", te)
+		}
+		if !errors.Is(gotErr, context.DeadlineExceeded) {
+			t.Error("error does not unwrap to context.DeadlineExceeded")
+		}
+	})
+
+	t.Run("direct RenderComponent", func(t *testing.T) {
+		serve("/?direct", "")
+		var te *ComponentTimeoutError
+		if !errors.As(gotErr, &te) || te.Component != "" {
+			t.Errorf("error = %v, want *ComponentTimeoutError with empty component", gotErr)
+		}
+	})
+
+	want := []string{"timeoutPage.Page", "timeoutPage.Details", "timeoutPage."}
+	if strings.Join(calls, " ") != strings.Join(want, " ") {
+		t.Errorf("selector calls = %v, want %v", calls, want)
+	}
+}
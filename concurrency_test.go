@@ -0,0 +1,157 @@
+package structpages
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestConcurrentRequests exercises the request-time reads of the page tree
+// (component lookup, Props, partial targeting) alongside post-mount arg
+// updates. Run with -race.
+func TestConcurrentRequests(t *testing.T) {
+	mux := http.NewServeMux()
+	sp, err := Mount(mux, &headersPages{}, "/", "App", WithArgs(&updateArgConfig{}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	partialID, err := sp.ID(headersIndexPage.Partial)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+				want := "index"
+				if i%2 == 1 {
+					req.Header.Set("HX-Request", "true")
+					req.Header.Set("HX-Target", partialID)
+					want = "partial"
+				}
+				rec := httptest.NewRecorder()
+				mux.ServeHTTP(rec, req)
+				if rec.Body.String() != want {
+					t.Errorf("body = %q, want %q", rec.Body.String(), want)
+					return
+				}
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range 50 {
+			if err := sp.UpdateArg(&updateArgConfig{}); err != nil {
+				t.Error(err)
+				return
+			}
+			if _, err := sp.URLFor(headersFramePage{}); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+	wg.Wait()
+}
+
+type raceHubPage struct{}
+
+// Props walks the tree from the current page and resolves a URL and an id,
+// the reads AddChildRoute must not race with.
+func (raceHubPage) Props(r *http.Request) (string, error) {
+	pn := CurrentPage(r.Context())
+	n := 0
+	for range pn.Parent.All() {
+		n++
+	}
+	if _, err := URLFor(r.Context(), raceHubPage{}); err != nil {
+		return "", err
+	}
+	if _, err := ID(r.Context(), raceHubPage.Page); err != nil {
+		return "", err
+	}
+	return strconv.Itoa(n), nil
+}
+
+func (raceHubPage) Page(string) component { return testComponent{"hub"} }
+
+type raceChildA struct{}
+
+func (raceChildA) Page() component { return testComponent{"a"} }
+
+type raceChildB struct{}
+
+func (raceChildB) Page() component { return testComponent{"b"} }
+
+type raceChildC struct{}
+
+func (raceChildC) Page() component { return testComponent{"c"} }
+
+type raceChildD struct{}
+
+func (raceChildD) Page() component { return testComponent{"d"} }
+
+type racePages struct {
+	Hub raceHubPage `route:"/hub Hub"`
+}
+
+// TestConcurrentAddChildRoute adds children while requests walk the tree
+// and resolve URLs and ids. Run with -race.
+func TestConcurrentAddChildRoute(t *testing.T) {
+	mux := http.NewServeMux()
+	sp, err := Mount(mux, &racePages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				rec := httptest.NewRecorder()
+				mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/hub", http.NoBody))
+				if rec.Code != http.StatusOK || rec.Body.String() != "hub" {
+					t.Errorf("got %d %q, want 200 hub", rec.Code, rec.Body.String())
+					return
+				}
+				if _, err := sp.URLFor(raceHubPage{}); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	for i, child := range []any{&raceChildA{}, &raceChildB{}, &raceChildC{}, &raceChildD{}} {
+		time.Sleep(time.Millisecond)
+		if err := sp.AddChildRoute(racePages{}, child, "/child"+strconv.Itoa(i), ""); err != nil {
+			t.Errorf("AddChildRoute: %v", err)
+		}
+	}
+	close(stop)
+	wg.Wait()
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/child3", http.NoBody))
+	if rec.Body.String() != "d" {
+		t.Errorf("GET /child3 = %q, want d", rec.Body.String())
+	}
+	if got := sp.pc.root().Children; len(got) != 5 {
+		t.Errorf("root has %d children, want 5", len(got))
+	}
+}
@@ -0,0 +1,105 @@
+package structpages
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"slices"
+)
+
+// ConfigFileDecoder splits the contents of a configuration file into its
+// top-level sections. For JSON that is json.Unmarshal into the map, which is
+// what WithConfigFile does when the decoder is nil; a YAML decoder can
+// re-encode each section as JSON.
+type ConfigFileDecoder func(data []byte) (map[string]json.RawMessage, error)
+
+// configFile is carried in StructPages.args so the file is read and its
+// values registered when the parse context is built, before Init methods
+// run.
+type configFile struct {
+	name      string
+	read      func() ([]byte, error)
+	decoder   ConfigFileDecoder
+	factories map[string]func(raw []byte) (any, error)
+}
+
+// WithConfigFile registers dependency injection args built from the
+// configuration file at path. Each factory is called with the raw bytes of
+// the file's top-level section of the same name, and the value it returns is
+// registered as if it had been passed to WithArgs:
+//
+//	structpages.Mount(mux, index{}, "/", "App",
+//	    structpages.WithConfigFile("config.json", nil, map[string]func([]byte) (any, error){
+//	        "database": func(raw []byte) (any, error) {
+//	            var cfg DatabaseConfig
+//	            return &cfg, json.Unmarshal(raw, &cfg)
+//	        },
+//	    }))
+//
+// A nil decoder reads the file as a JSON object. Sections without a factory
+// are ignored. Mount and Parse return an error naming the file when it can't
+// be read or decoded, a factory's section is missing, or a factory fails.
+func WithConfigFile(
+	path string, decoder ConfigFileDecoder, factories map[string]func(raw []byte) (any, error),
+) func(*StructPages) {
+	return withConfigFile(configFile{
+		name:      path,
+		read:      func() ([]byte, error) { return os.ReadFile(path) },
+		decoder:   decoder,
+		factories: factories,
+	})
+}
+
+// WithConfigFileFS is like WithConfigFile, reading path from fsys, such as
+// an embed.FS.
+func WithConfigFileFS(
+	fsys fs.FS, path string, decoder ConfigFileDecoder, factories map[string]func(raw []byte) (any, error),
+) func(*StructPages) {
+	return withConfigFile(configFile{
+		name:      path,
+		read:      func() ([]byte, error) { return fs.ReadFile(fsys, path) },
+		decoder:   decoder,
+		factories: factories,
+	})
+}
+
+func withConfigFile(c configFile) func(*StructPages) {
+	if c.decoder == nil {
+		c.decoder = func(data []byte) (map[string]json.RawMessage, error) {
+			var sections map[string]json.RawMessage
+			err := json.Unmarshal(data, &sections)
+			return sections, err
+		}
+	}
+	return func(sp *StructPages) {
+		sp.args = append(sp.args, c)
+	}
+}
+
+// addConfigFile reads c and registers the values its factories build.
+func (p *parseContext) addConfigFile(c configFile) error {
+	data, err := c.read()
+	if err != nil {
+		return fmt.Errorf("config file: %w", err)
+	}
+	sections, err := c.decoder(data)
+	if err != nil {
+		return fmt.Errorf("config file %s: %w", c.name, err)
+	}
+	for _, key := range slices.Sorted(maps.Keys(c.factories)) {
+		raw, ok := sections[key]
+		if !ok {
+			return fmt.Errorf("config file %s: no %q section", c.name, key)
+		}
+		v, err := c.factories[key](raw)
+		if err != nil {
+			return fmt.Errorf("config file %s: section %q: %w", c.name, key, err)
+		}
+		if err := p.args.addArg(v); err != nil {
+			return fmt.Errorf("config file %s: section %q: %w", c.name, key, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package structpages
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+type DatabaseConfig struct {
+	DSN      string `json:"dsn"`
+	MaxConns int    `json:"maxConns"`
+}
+
+type configDBPage struct{}
+
+func (configDBPage) Props(cfg *DatabaseConfig) (string, error) {
+	return cfg.DSN, nil
+}
+
+func (configDBPage) Page(dsn string) component { return testComponent{dsn} }
+
+var configFactories = map[string]func([]byte) (any, error){
+	"database": func(raw []byte) (any, error) {
+		var cfg DatabaseConfig
+		return &cfg, json.Unmarshal(raw, &cfg)
+	},
+}
+
+const configJSON = `{"database": {"dsn": "postgres://db/app", "maxConns": 4}, "cache": {"ttl": 60}}`
+
+func TestWithConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(configJSON), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		opt  Option
+	}{
+		{"file", WithConfigFile(path, nil, configFactories)},
+		{"fs", WithConfigFileFS(fstest.MapFS{
+			"conf/app.json": {Data: []byte(configJSON)},
+		}, "conf/app.json", nil, configFactories)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			sp, err := Mount(mux, &struct {
+				configDBPage `route:"/ Home"`
+			}{}, "/", "App", tt.opt)
+			if err != nil {
+				t.Fatalf("Mount: %v", err)
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+			if got := rec.Body.String(); got != "postgres://db/app" {
+				t.Errorf("body = %q, want the configured DSN", got)
+			}
+			if got := DITypes(sp); len(got) != 1 || got[0].String() != "*structpages.DatabaseConfig" {
+				t.Errorf("DITypes = %v, want [*structpages.DatabaseConfig]", got)
+			}
+		})
+	}
+}
+
+func TestWithConfigFile_Errors(t *testing.T) {
+	files := fstest.MapFS{
+		"config.json": {Data: []byte(configJSON)},
+		"broken.json": {Data: []byte(`{"database": `)},
+	}
+	failing := map[string]func([]byte) (any, error){
+		"cache": func([]byte) (any, error) { return nil, errors.New("redis unreachable") },
+	}
+	missing := map[string]func([]byte) (any, error){
+		"queue": func([]byte) (any, error) { return nil, nil },
+	}
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{"missing file", []Option{WithConfigFileFS(files, "nope.json", nil, configFactories)}, "nope.json"},
+		{"invalid json", []Option{WithConfigFileFS(files, "broken.json", nil, configFactories)},
+			"config file broken.json: unexpected end of JSON input"},
+		{"missing section", []Option{WithConfigFileFS(files, "config.json", nil, missing)},
+			`config file config.json: no "queue" section`},
+		{"factory error", []Option{WithConfigFileFS(files, "config.json", nil, failing)},
+			`config file config.json: section "cache": redis unreachable`},
+		{"duplicate arg", []Option{
+			WithArgs(&DatabaseConfig{}),
+			WithConfigFileFS(files, "config.json", nil, configFactories),
+		}, "duplicate type *structpages.DatabaseConfig"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Mount(http.NewServeMux(), &struct {
+				configDBPage `route:"/ Home"`
+			}{}, "/", "App", tt.opts...)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Mount error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package structpages
+
+import (
+	"cmp"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jackielii/ctxkey"
+)
+
+// negotiatedTargetCtx carries the RenderTarget chosen by
+// ContentNegotiationMiddleware, which takes precedence over the
+// TargetSelector.
+var negotiatedTargetCtx = ctxkey.New[RenderTarget]("structpages.negotiatedTarget", nil)
+
+// ContentNegotiationMiddleware returns a middleware that picks the component
+// to render from the request's Accept header. mappings maps a MIME type to
+// the component method rendering it:
+//
+//	structpages.WithMiddlewares(structpages.ContentNegotiationMiddleware(map[string]string{
+//	    "text/html":        "Page",
+//	    "application/json": "JSON",
+//	    "text/plain":       "Text",
+//	}))
+//
+// The Accept entries are tried by quality (most specific first on ties) and
+// the first MIME type the page has a component for wins; wildcards such as
+// */* or text/* match the mapped types in name order, text/html first. A
+// missing Accept header counts as */*. The response gets that Content-Type
+// and Vary: Accept, and a request no mapped component can satisfy gets 406.
+//
+// For text/html the TargetSelector still chooses the component, so HTMX
+// partials keep working; for any other type the mapped component is
+// rendered. Pages without any of the mapped components, such as ServeHTTP
+// pages, are left alone.
+func ContentNegotiationMiddleware(mappings map[string]string) MiddlewareFunc {
+	return func(next http.Handler, pn *PageNode) http.Handler {
+		var offers []string
+		for mimeType, name := range mappings {
+			if _, ok := pn.Components[name]; ok {
+				offers = append(offers, mimeType)
+			}
+		}
+		if len(offers) == 0 {
+			return next
+		}
+		slices.Sort(offers)
+		if i := slices.Index(offers, "text/html"); i > 0 {
+			offers = slices.Insert(slices.Delete(offers, i, i+1), 0, "text/html")
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept")
+			mimeType := negotiate(r.Header.Get("Accept"), offers)
+			if mimeType == "" {
+				http.Error(w, http.StatusText(http.StatusNotAcceptable), http.StatusNotAcceptable)
+				return
+			}
+			contentType := mimeType
+			if strings.HasPrefix(mimeType, "text/") {
+				contentType = mime.FormatMediaType(mimeType, map[string]string{"charset": "utf-8"})
+			}
+			w.Header().Set("Content-Type", contentType)
+			if mimeType != "text/html" {
+				name := mappings[mimeType]
+				method := pn.Components[name]
+				r = r.WithContext(negotiatedTargetCtx.WithValue(r.Context(), newMethodRenderTarget(name, &method)))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// acceptRange is one media range of an Accept header, e.g. text/* with
+// its quality.
+type acceptRange struct {
+	mimeType string
+	q        float64
+}
+
+// negotiate returns the first of offers accept prefers, or "" when accept
+// rules them all out. offers are in the order wildcards pick them.
+func negotiate(accept string, offers []string) string {
+	var ranges []acceptRange
+	for part := range strings.SplitSeq(cmp.Or(accept, "*/*"), ",") {
+		mimeType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		ranges = append(ranges, acceptRange{mimeType, q})
+	}
+	slices.SortStableFunc(ranges, func(a, b acceptRange) int {
+		return cmp.Or(-cmp.Compare(a.q, b.q), -cmp.Compare(specificity(a.mimeType), specificity(b.mimeType)))
+	})
+	for _, ar := range ranges {
+		if ar.q <= 0 {
+			break
+		}
+		for _, offer := range offers {
+			if rangeMatches(ar.mimeType, offer) && !refused(ranges, offer) {
+				return offer
+			}
+		}
+	}
+	return ""
+}
+
+// refused reports whether ranges rejects offer with q=0, e.g. the
+// application/json in "*/*, application/json;q=0".
+func refused(ranges []acceptRange, offer string) bool {
+	for _, ar := range ranges {
+		if ar.q <= 0 && ar.mimeType == offer {
+			return true
+		}
+	}
+	return false
+}
+
+// rangeMatches reports whether the media range r, which may be */* or
+// type/*, covers mimeType.
+func rangeMatches(r, mimeType string) bool {
+	if r == "*/*" || r == mimeType {
+		return true
+	}
+	typ, sub, _ := strings.Cut(r, "/")
+	return sub == "*" && strings.HasPrefix(mimeType, typ+"/")
+}
+
+// specificity ranks */* below type/* below a full MIME type.
+func specificity(r string) int {
+	switch {
+	case r == "*/*":
+		return 0
+	case strings.HasSuffix(r, "/*"):
+		return 1
+	}
+	return 2
+}
+
+// selectTarget returns the target ContentNegotiationMiddleware chose for the
+// request, or else the TargetSelector's.
+func (sp *StructPages) selectTarget(r *http.Request, pn *PageNode) (RenderTarget, error) {
+	if target := negotiatedTargetCtx.Value(r.Context()); target != nil {
+		return target, nil
+	}
+	return sp.targetSelector(r, pn)
+}
@@ -0,0 +1,123 @@
+package structpages
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type negotiatedPage struct{}
+
+func (negotiatedPage) Page() component { return testComponent{"<p>html</p>"} }
+func (negotiatedPage) JSON() component { return testComponent{`{"kind":"json"}`} }
+func (negotiatedPage) Text() component { return testComponent{"text"} }
+
+type htmlOnlyPage struct{}
+
+func (htmlOnlyPage) Page() component { return testComponent{"<p>html only</p>"} }
+
+type negotiationPages struct {
+	Both negotiatedPage `route:"/both Both"`
+	HTML htmlOnlyPage   `route:"/html HTML"`
+}
+
+func TestContentNegotiationMiddleware(t *testing.T) {
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &negotiationPages{}, "/", "App",
+		WithMiddlewares(ContentNegotiationMiddleware(map[string]string{
+			"text/html":        "Page",
+			"application/json": "JSON",
+			"text/plain":       "Text",
+		})))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	tests := []struct {
+		name, path, accept string
+		wantCode           int
+		wantType, wantBody string
+	}{
+		{
+			name: "HTML preferred", path: "/both", accept: "text/html,application/json;q=0.9",
+			wantCode: http.StatusOK, wantType: "text/html; charset=utf-8", wantBody: "<p>html</p>",
+		},
+		{
+			name: "JSON preferred", path: "/both", accept: "application/json, text/html;q=0.5",
+			wantCode: http.StatusOK, wantType: "application/json", wantBody: `{"kind":"json"}`,
+		},
+		{
+			name: "type wildcard", path: "/both", accept: "text/*;q=0.8, application/json;q=0.1",
+			wantCode: http.StatusOK, wantType: "text/html; charset=utf-8", wantBody: "<p>html</p>",
+		},
+		{
+			name: "most specific wins on equal quality", path: "/both", accept: "text/*, text/plain",
+			wantCode: http.StatusOK, wantType: "text/plain; charset=utf-8", wantBody: "text",
+		},
+		{
+			name: "*/* falls back to HTML", path: "/both", accept: "*/*",
+			wantCode: http.StatusOK, wantType: "text/html; charset=utf-8", wantBody: "<p>html</p>",
+		},
+		{
+			name: "no Accept header", path: "/both",
+			wantCode: http.StatusOK, wantType: "text/html; charset=utf-8", wantBody: "<p>html</p>",
+		},
+		{
+			name: "q=0 refuses a type", path: "/both", accept: "*/*, text/html;q=0",
+			wantCode: http.StatusOK, wantType: "application/json", wantBody: `{"kind":"json"}`,
+		},
+		{
+			name: "missing JSON component falls back to HTML", path: "/html", accept: "application/json, text/html;q=0.5",
+			wantCode: http.StatusOK, wantType: "text/html; charset=utf-8", wantBody: "<p>html only</p>",
+		},
+		{
+			name: "nothing acceptable", path: "/html", accept: "application/json",
+			wantCode: http.StatusNotAcceptable, wantType: "text/plain; charset=utf-8",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantCode, rec.Body.String())
+			}
+			if got := rec.Header().Get("Content-Type"); got != tt.wantType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.wantType)
+			}
+			if tt.wantBody != "" && rec.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", rec.Body.String(), tt.wantBody)
+			}
+			if got := rec.Header().Get("Vary"); got != "Accept" {
+				t.Errorf("Vary = %q, want Accept", got)
+			}
+		})
+	}
+}
+
+func TestContentNegotiationMiddleware_HTMXPartial(t *testing.T) {
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &struct {
+		Both negotiatedPage `route:"/both Both"`
+	}{}, "/", "App", WithMiddlewares(ContentNegotiationMiddleware(map[string]string{
+		"text/html":  "Page",
+		"text/plain": "Text",
+	})))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	// text/html leaves the choice to the TargetSelector, which picks the
+	// HX-Target component.
+	req := httptest.NewRequest(http.MethodGet, "/both", http.NoBody)
+	req.Header.Set("Accept", "text/html")
+	req.Header.Set("HX-Request", "true")
+	req.Header.Set("HX-Target", "both-text")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Body.String() != "text" {
+		t.Errorf("body = %q, want the Text component", rec.Body.String())
+	}
+}
@@ -0,0 +1,62 @@
+package structpages
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// contextFunc derives a request's context, see StructPages.WithContext.
+type contextFunc = func(ctx context.Context) context.Context
+
+// contextFuncs holds the functions added with WithContext. Requests load
+// the slice without locking; mu serializes the writers.
+type contextFuncs struct {
+	mu    sync.Mutex
+	funcs atomic.Pointer[[]contextFunc]
+}
+
+func (c *contextFuncs) add(fns ...contextFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var cur []contextFunc
+	if p := c.funcs.Load(); p != nil {
+		cur = *p
+	}
+	next := append(slices.Clip(cur), fns...)
+	c.funcs.Store(&next)
+}
+
+func (c *contextFuncs) list() []contextFunc {
+	if p := c.funcs.Load(); p != nil {
+		return *p
+	}
+	return nil
+}
+
+func (c *contextFuncs) apply(ctx context.Context) context.Context {
+	for _, fn := range c.list() {
+		ctx = fn(ctx)
+	}
+	return ctx
+}
+
+// WithContext adds fn to the functions every request's context passes
+// through before the middlewares run, for values all requests share, such
+// as feature flags loaded at startup:
+//
+//	sp.WithContext(func(ctx context.Context) context.Context {
+//	    return flagsKey.WithValue(ctx, flags)
+//	})
+//
+// The values are then in r.Context() for middlewares, Props and
+// ServeHTTP, and in the context of every component render. Functions run
+// in the order they were added; a call made while serving applies to the
+// requests that start after it returns. Unlike WithScopedArg, the values
+// are not injected into page methods. Groups and plugins created
+// afterwards start with sp's functions.
+func (sp *StructPages) WithContext(fn func(ctx context.Context) context.Context) {
+	sp.checkWritable()
+	sp.contextFuncs.add(fn)
+}
@@ -0,0 +1,63 @@
+package structpages
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jackielii/ctxkey"
+)
+
+var (
+	sharedFlagsKey  = ctxkey.New("structpages.test.flags", "")
+	sharedTenantKey = ctxkey.New("structpages.test.tenant", "")
+)
+
+type sharedCtxPage struct{}
+
+func (sharedCtxPage) Props(r *http.Request) (string, error) {
+	return sharedFlagsKey.Value(r.Context()), nil
+}
+
+func (sharedCtxPage) Page(fromProps string) component {
+	return fnComponent(func(ctx context.Context, w io.Writer) error {
+		_, err := fmt.Fprintf(w, "props=%s render=%s/%s", fromProps,
+			sharedFlagsKey.Value(ctx), sharedTenantKey.Value(ctx))
+		return err
+	})
+}
+
+func TestStructPagesWithContext(t *testing.T) {
+	var inMiddleware string
+	mw := func(next http.Handler, pn *PageNode) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			inMiddleware = sharedFlagsKey.Value(r.Context())
+			next.ServeHTTP(w, r)
+		})
+	}
+	mux := http.NewServeMux()
+	sp, err := Mount(mux, &struct {
+		sharedCtxPage `route:"/ Home"`
+	}{}, "/", "App", WithMiddlewares(mw))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	sp.WithContext(func(ctx context.Context) context.Context {
+		return sharedFlagsKey.WithValue(ctx, "beta")
+	})
+	sp.WithContext(func(ctx context.Context) context.Context {
+		return sharedTenantKey.WithValue(ctx, "acme-"+sharedFlagsKey.Value(ctx))
+	})
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	if got, want := rec.Body.String(), "props=beta render=beta/acme-beta"; got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+	if inMiddleware != "beta" {
+		t.Errorf("middleware saw %q, want %q", inMiddleware, "beta")
+	}
+}
@@ -0,0 +1,41 @@
+package structpages
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/jackielii/ctxkey"
+)
+
+var pageValueCtx = ctxkey.New[any]("structpages.pageValue", nil)
+
+// WithCurrentPage stores the page struct serving each request in its
+// context, where ContextPage retrieves it. It is off by default.
+func WithCurrentPage() func(*StructPages) {
+	return func(r *StructPages) {
+		r.pageValueInContext = true
+	}
+}
+
+// ContextPage returns the page struct serving the request ctx belongs to,
+// as stored by WithCurrentPage, if it has type T. It lets a component deep
+// in a page's render reach the page's fields without threading it through
+// every call:
+//
+//	if p, ok := structpages.ContextPage[userPage](ctx); ok {
+//	    // use p.Config
+//	}
+//
+// T is the type of the field the page is mounted from, so a page mounted
+// from a pointer field is retrieved as a pointer.
+func ContextPage[T any](ctx context.Context) (T, bool) {
+	v, ok := pageValueCtx.Value(ctx).(T)
+	return v, ok
+}
+
+func withPageValue(next http.Handler, pn *PageNode) http.Handler {
+	page := pn.Value.Interface()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(pageValueCtx.WithValue(r.Context(), page)))
+	})
+}
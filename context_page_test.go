@@ -0,0 +1,63 @@
+package structpages
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// ctxPageReport renders what ContextPage finds for ctxUserPage and for a
+// page type that doesn't own the render.
+func ctxPageReport() component {
+	return fnComponent(func(ctx context.Context, w io.Writer) error {
+		p, ok := ContextPage[*ctxUserPage](ctx)
+		_, wrong := ContextPage[ctxOtherPage](ctx)
+		label := ""
+		if ok {
+			label = p.label
+		}
+		_, err := fmt.Fprintf(w, "%v %q wrong=%v", ok, label, wrong)
+		return err
+	})
+}
+
+type ctxUserPage struct{ label string }
+
+func (p *ctxUserPage) Page() component { return ctxPageReport() }
+
+type ctxOtherPage struct{}
+
+func (ctxOtherPage) Page() component { return testComponent{"other"} }
+
+type ctxPages struct {
+	User  *ctxUserPage `route:"/user User"`
+	Other ctxOtherPage `route:"/other Other"`
+}
+
+func TestContextPage(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{"with WithCurrentPage", []Option{WithCurrentPage()}, `true "users" wrong=false`},
+		{"off by default", nil, `false "" wrong=false`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			opts := append([]Option{WithSingleton(&ctxUserPage{label: "users"})}, tt.opts...)
+			if _, err := Mount(mux, &ctxPages{}, "/", "App", opts...); err != nil {
+				t.Fatalf("Mount: %v", err)
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/user", http.NoBody))
+			if rec.Body.String() != tt.want {
+				t.Errorf("body = %q, want %q", rec.Body.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package structpages
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// CookieDecoder decodes request cookies into dst, a pointer to the Values
+// of a CookieJar. See WithCookieDecoder.
+type CookieDecoder interface {
+	Decode(cookies []*http.Cookie, dst any) error
+}
+
+// CookieEncoder is implemented by a CookieDecoder that can also turn a
+// value back into cookies, as needed for Props returning a CookieWriter.
+type CookieEncoder interface {
+	Encode(src any) ([]*http.Cookie, error)
+}
+
+// CookieErrors is the error a CookieDecoder returns for cookies it could
+// not decode, keyed by cookie name. The other fields are still decoded, and
+// the errors are handed to the page in CookieJar.Errors instead of failing
+// the request.
+type CookieErrors map[string]error
+
+func (e CookieErrors) Error() string {
+	names := make([]string, 0, len(e))
+	for name := range e {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	msgs := make([]string, len(names))
+	for i, name := range names {
+		msgs[i] = fmt.Sprintf("cookie %s: %v", name, e[name])
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// CookieJar is a Props parameter filled from the request cookies by the
+// WithCookieDecoder decoder. Errors holds the CookieErrors the decoder
+// reported, such as a missing cookie; it is nil when every cookie decoded.
+//
+//	func (p cartPage) Props(jar structpages.CookieJar[Prefs]) (CartProps, error) {
+//	    if err := jar.Errors["theme"]; err != nil {
+//	        jar.Values.Theme = "light"
+//	    }
+//	    ...
+//	}
+type CookieJar[T any] struct {
+	Values T
+	Errors map[string]error
+}
+
+func (j *CookieJar[T]) decodeFrom(d CookieDecoder, cookies []*http.Cookie) error {
+	var cookieErrs CookieErrors
+	err := d.Decode(cookies, &j.Values)
+	switch {
+	case err == nil:
+	case errors.As(err, &cookieErrs):
+		j.Errors = cookieErrs
+	default:
+		return err
+	}
+	return nil
+}
+
+// CookieWriter is a Props result whose Values are encoded with the
+// WithCookieDecoder decoder, which must implement CookieEncoder, and set as
+// response cookies before the page renders. It is not passed on to the
+// component.
+//
+//	func (p prefsPage) Props(r *http.Request) (structpages.CookieWriter[Prefs], PrefsProps, error) {
+//	    prefs := Prefs{Theme: r.FormValue("theme")}
+//	    return structpages.CookieWriter[Prefs]{Values: prefs}, PrefsProps{prefs}, nil
+//	}
+type CookieWriter[T any] struct {
+	Values T
+}
+
+func (c CookieWriter[T]) cookieValues() any { return c.Values }
+
+// cookieJar is implemented by *CookieJar[T] and cookieWriter by
+// CookieWriter[T], for any T.
+type cookieJar interface {
+	decodeFrom(CookieDecoder, []*http.Cookie) error
+}
+
+type cookieWriter interface{ cookieValues() any }
+
+var (
+	cookieJarType    = reflect.TypeFor[cookieJar]()
+	cookieWriterType = reflect.TypeFor[cookieWriter]()
+)
+
+// WithCookieDecoder sets the decoder that fills CookieJar parameters and,
+// if it implements CookieEncoder, writes CookieWriter results.
+func WithCookieDecoder(decoder CookieDecoder) func(*StructPages) {
+	return func(r *StructPages) {
+		r.cookieDecoder = decoder
+	}
+}
+
+// isCookieJar reports whether t is a CookieJar instantiation.
+func isCookieJar(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && reflect.PointerTo(t).Implements(cookieJarType)
+}
+
+// cookieJarArg fills a CookieJar parameter from the cookies of the request
+// among availableArgs. ok is false for other types, or when no request is
+// available. A decoder error other than CookieErrors is reported as an
+// *HTTPError with code 400.
+func (p *parseContext) cookieJarArg(
+	argType reflect.Type, availableArgs map[reflect.Type][]reflect.Value,
+) (reflect.Value, bool, error) {
+	if !isCookieJar(argType) {
+		return reflect.Value{}, false, nil
+	}
+	reqs := availableArgs[requestPointerType]
+	if len(reqs) == 0 {
+		return reflect.Value{}, false, nil
+	}
+	if p.cookieDecoder == nil {
+		return reflect.Value{}, false, fmt.Errorf("%s parameter needs WithCookieDecoder", argType)
+	}
+	r := reqs[0].Interface().(*http.Request)
+	jar := reflect.New(argType)
+	if err := jar.Interface().(cookieJar).decodeFrom(p.cookieDecoder, r.Cookies()); err != nil {
+		err = fmt.Errorf("decode cookies: %w", err)
+		return reflect.Value{}, false, &HTTPError{Code: http.StatusBadRequest, Err: err}
+	}
+	return jar.Elem(), true, nil
+}
+
+func isCookieWriter(v reflect.Value) bool { return v.Type().Implements(cookieWriterType) }
+
+// writeCookies sets the cookies of the CookieWriter values among results
+// on w and returns the other results.
+func (p *parseContext) writeCookies(w http.ResponseWriter, results []reflect.Value) ([]reflect.Value, error) {
+	if !slices.ContainsFunc(results, isCookieWriter) {
+		return results, nil
+	}
+	rest := results[:0:0]
+	for _, v := range results {
+		if !isCookieWriter(v) {
+			rest = append(rest, v)
+			continue
+		}
+		enc, ok := p.cookieDecoder.(CookieEncoder)
+		if !ok {
+			return nil, fmt.Errorf("%s result needs a WithCookieDecoder decoder implementing CookieEncoder", v.Type())
+		}
+		cookies, err := enc.Encode(v.Interface().(cookieWriter).cookieValues())
+		if err != nil {
+			return nil, fmt.Errorf("encode cookies: %w", err)
+		}
+		for _, c := range cookies {
+			http.SetCookie(w, c)
+		}
+	}
+	return rest, nil
+}
@@ -0,0 +1,173 @@
+package structpages
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// jsonCookies decodes and encodes one base64 JSON cookie per field, named
+// by the field's cookie tag.
+type jsonCookies struct{}
+
+func (jsonCookies) Decode(cookies []*http.Cookie, dst any) error {
+	v := reflect.ValueOf(dst).Elem()
+	errs := CookieErrors{}
+	for i := range v.NumField() {
+		name := v.Type().Field(i).Tag.Get("cookie")
+		c := findCookie(cookies, name)
+		if c == nil {
+			errs[name] = http.ErrNoCookie
+			continue
+		}
+		b, err := base64.URLEncoding.DecodeString(c.Value)
+		if err == nil {
+			err = json.Unmarshal(b, v.Field(i).Addr().Interface())
+		}
+		if err != nil {
+			errs[name] = err
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (jsonCookies) Encode(src any) ([]*http.Cookie, error) {
+	v := reflect.ValueOf(src)
+	var cookies []*http.Cookie
+	for i := range v.NumField() {
+		b, err := json.Marshal(v.Field(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		name := v.Type().Field(i).Tag.Get("cookie")
+		cookies = append(cookies, &http.Cookie{Name: name, Value: base64.URLEncoding.EncodeToString(b), Path: "/"})
+	}
+	return cookies, nil
+}
+
+func findCookie(cookies []*http.Cookie, name string) *http.Cookie {
+	for _, c := range cookies {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+type cookiePrefs struct {
+	Theme string `cookie:"theme"`
+	Cart  []int  `cookie:"cart"`
+}
+
+type cookieReadPage struct{}
+
+func (cookieReadPage) Props(jar CookieJar[cookiePrefs]) (string, error) {
+	return fmt.Sprintf("theme=%s cart=%v missing=%v",
+		jar.Values.Theme, jar.Values.Cart, errors.Is(jar.Errors["cart"], http.ErrNoCookie)), nil
+}
+
+func (cookieReadPage) Page(s string) component { return testComponent{s} }
+
+type cookieWritePage struct{}
+
+func (cookieWritePage) Props(r *http.Request) (CookieWriter[cookiePrefs], string, error) {
+	prefs := cookiePrefs{Theme: r.URL.Query().Get("theme"), Cart: []int{1, 2}}
+	return CookieWriter[cookiePrefs]{Values: prefs}, "saved", nil
+}
+
+func (cookieWritePage) Page(s string) component { return testComponent{s} }
+
+type cookiePages struct {
+	Read  cookieReadPage  `route:"/read Read"`
+	Write cookieWritePage `route:"/write Write"`
+}
+
+func encodedCookie(t *testing.T, name string, v any) *http.Cookie {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &http.Cookie{Name: name, Value: base64.URLEncoding.EncodeToString(b)}
+}
+
+func TestCookieJar(t *testing.T) {
+	mux := http.NewServeMux()
+	sp, err := Mount(mux, &cookiePages{}, "/", "App", WithCookieDecoder(jsonCookies{}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	if err := sp.checkArgs(); err != nil {
+		t.Errorf("checkArgs: %v", err)
+	}
+
+	t.Run("read", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/read", http.NoBody)
+		req.AddCookie(encodedCookie(t, "theme", "dark"))
+		req.AddCookie(encodedCookie(t, "cart", []int{7}))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		if got, want := rec.Body.String(), "theme=dark cart=[7] missing=false"; got != want {
+			t.Errorf("body = %q, want %q", got, want)
+		}
+	})
+	t.Run("missing", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/read", http.NoBody)
+		req.AddCookie(encodedCookie(t, "theme", "dark"))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		if got, want := rec.Body.String(), "theme=dark cart=[] missing=true"; got != want {
+			t.Errorf("body = %q, want %q", got, want)
+		}
+	})
+	t.Run("write", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/write?theme=light", http.NoBody))
+		if rec.Body.String() != "saved" {
+			t.Errorf("body = %q, want %q", rec.Body.String(), "saved")
+		}
+		var prefs cookiePrefs
+		if err := (jsonCookies{}).Decode(rec.Result().Cookies(), &prefs); err != nil {
+			t.Fatalf("decode response cookies: %v", err)
+		}
+		if prefs.Theme != "light" || !reflect.DeepEqual(prefs.Cart, []int{1, 2}) {
+			t.Errorf("cookies decode to %+v", prefs)
+		}
+	})
+}
+
+type decodeOnly struct{}
+
+func (decodeOnly) Decode([]*http.Cookie, any) error { return nil }
+
+func TestCookieJar_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		path string
+	}{
+		{"no decoder", nil, "/read"},
+		{"decoder without encoder", []Option{WithCookieDecoder(decodeOnly{})}, "/write"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			if _, err := Mount(mux, &cookiePages{}, "/", "App", tt.opts...); err != nil {
+				t.Fatalf("Mount: %v", err)
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+			if rec.Code != http.StatusInternalServerError {
+				t.Errorf("status = %d, want 500", rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package structpages
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSConfig configures CORSMiddleware.
+type CORSConfig struct {
+	// AllowedOrigins lists the origins allowed to make cross-origin
+	// requests; "*" allows any origin.
+	AllowedOrigins []string
+	// AllowedMethods answers preflight requests; empty means GET, HEAD
+	// and POST.
+	AllowedMethods []string
+	// AllowedHeaders lists the request headers preflight requests may ask
+	// for.
+	AllowedHeaders []string
+	// MaxAge is how long browsers may cache a preflight response; zero
+	// omits Access-Control-Max-Age.
+	MaxAge time.Duration
+}
+
+// CORSMiddleware returns a middleware answering CORS requests with defaults,
+// overridden per page by these meta tag keys:
+//
+//   - cors-origins, cors-methods, cors-headers: replace AllowedOrigins,
+//     AllowedMethods and AllowedHeaders, as space-separated lists (","
+//     separates meta entries)
+//   - cors-max-age: replaces MaxAge, as a time.Duration ("10m") or seconds
+//
+// For example:
+//
+//	type pages struct {
+//	    API apiPage `route:"/api/items Items" meta:"cors-origins:https://a.example.com https://b.example.com"`
+//	}
+//
+//	structpages.WithMiddlewares(structpages.CORSMiddleware(structpages.CORSConfig{
+//	    AllowedOrigins: []string{"https://app.example.com"},
+//	}))
+//
+// Requests without an Origin header pass through. A request from an origin
+// not allowed for the page gets 403. A preflight (OPTIONS with
+// Access-Control-Request-Method) gets the CORS headers and 204 without
+// reaching the page; its route must accept OPTIONS, so give CORS pages a
+// route without a method, or add an OPTIONS route.
+func CORSMiddleware(defaults CORSConfig) MiddlewareFunc {
+	return func(next http.Handler, pn *PageNode) http.Handler {
+		cfg := corsConfigFor(pn, defaults)
+		methods := strings.Join(cfg.AllowedMethods, ", ")
+		headers := strings.Join(cfg.AllowedHeaders, ", ")
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Add("Vary", "Origin")
+			if !slices.Contains(cfg.AllowedOrigins, origin) && !slices.Contains(cfg.AllowedOrigins, "*") {
+				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+				return
+			}
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			if r.Method != http.MethodOptions || r.Header.Get("Access-Control-Request-Method") == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Set("Access-Control-Allow-Methods", methods)
+			if headers != "" {
+				w.Header().Set("Access-Control-Allow-Headers", headers)
+			}
+			if cfg.MaxAge > 0 {
+				w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(cfg.MaxAge.Seconds())))
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
+
+// corsConfigFor applies pn's cors-* meta values and the method default to
+// cfg.
+func corsConfigFor(pn *PageNode, cfg CORSConfig) CORSConfig {
+	if v, ok := MetaValue(pn, "cors-origins"); ok {
+		cfg.AllowedOrigins = strings.Fields(v)
+	}
+	if v, ok := MetaValue(pn, "cors-methods"); ok {
+		cfg.AllowedMethods = strings.Fields(strings.ToUpper(v))
+	}
+	if v, ok := MetaValue(pn, "cors-headers"); ok {
+		cfg.AllowedHeaders = strings.Fields(v)
+	}
+	if v, ok := MetaValue(pn, "cors-max-age"); ok {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.MaxAge = d
+		} else if n, err := strconv.Atoi(v); err == nil {
+			cfg.MaxAge = time.Duration(n) * time.Second
+		}
+	}
+	if len(cfg.AllowedMethods) == 0 {
+		cfg.AllowedMethods = []string{http.MethodGet, http.MethodHead, http.MethodPost}
+	}
+	return cfg
+}
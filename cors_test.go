@@ -0,0 +1,115 @@
+package structpages
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type corsHTMLPage struct{}
+
+func (corsHTMLPage) Page() component { return testComponent{"html"} }
+
+type corsAPIPage struct{}
+
+func (corsAPIPage) Middlewares() []MiddlewareFunc {
+	return []MiddlewareFunc{func(next http.Handler, pn *PageNode) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Page-Middleware", "ran")
+			next.ServeHTTP(w, r)
+		})
+	}}
+}
+
+func (corsAPIPage) Page() component { return testComponent{"api"} }
+
+type corsPages struct {
+	HTML corsHTMLPage `route:"/html HTML"`
+	API  corsAPIPage  `route:"/api API" meta:"cors-origins:https://api.example.com;cors-methods:get put;cors-max-age:10m"`
+}
+
+func TestCORSMiddleware(t *testing.T) {
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &corsPages{}, "/", "App",
+		WithMiddlewares(CORSMiddleware(CORSConfig{
+			AllowedOrigins: []string{"https://app.example.com"},
+			AllowedHeaders: []string{"Content-Type", "HX-Request"},
+			MaxAge:         time.Minute,
+		})))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+
+	tests := []struct {
+		name, method, path, origin string
+		preflight                  bool
+		wantCode                   int
+		wantHeaders                map[string]string
+	}{
+		{
+			name: "no origin", method: http.MethodGet, path: "/html", wantCode: http.StatusOK,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name: "global config", method: http.MethodGet, path: "/html", origin: "https://app.example.com",
+			wantCode:    http.StatusOK,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": "https://app.example.com", "Vary": "Origin"},
+		},
+		{
+			name: "origin not allowed", method: http.MethodGet, path: "/html", origin: "https://evil.example.com",
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name: "meta overrides origins", method: http.MethodGet, path: "/api", origin: "https://app.example.com",
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name: "meta origin allowed", method: http.MethodGet, path: "/api", origin: "https://api.example.com",
+			wantCode: http.StatusOK,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin": "https://api.example.com",
+				"X-Page-Middleware":           "ran",
+			},
+		},
+		{
+			name: "global preflight", method: http.MethodOptions, path: "/html", origin: "https://app.example.com",
+			preflight: true, wantCode: http.StatusNoContent,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Methods": "GET, HEAD, POST",
+				"Access-Control-Allow-Headers": "Content-Type, HX-Request",
+				"Access-Control-Max-Age":       "60",
+			},
+		},
+		{
+			name: "meta preflight", method: http.MethodOptions, path: "/api", origin: "https://api.example.com",
+			preflight: true, wantCode: http.StatusNoContent,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Methods": "GET, PUT",
+				"Access-Control-Max-Age":       "600",
+				"X-Page-Middleware":            "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, http.NoBody)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if tt.preflight {
+				req.Header.Set("Access-Control-Request-Method", http.MethodPut)
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantCode)
+			}
+			for k, want := range tt.wantHeaders {
+				if got := rec.Header().Get(k); got != want {
+					t.Errorf("%s = %q, want %q", k, got, want)
+				}
+			}
+		})
+	}
+}
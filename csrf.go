@@ -0,0 +1,91 @@
+package structpages
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/jackielii/ctxkey"
+)
+
+// ErrCSRFTokenInvalid is wrapped in the 403 HTTPError WithCSRF reports when
+// a state-changing request carries a missing or wrong CSRF token.
+var ErrCSRFTokenInvalid = errors.New("structpages: invalid CSRF token")
+
+// TokenStore issues and checks CSRF tokens for WithCSRF, typically tied to a
+// session cookie.
+type TokenStore interface {
+	// Generate returns the token for the client making r.
+	Generate(r *http.Request) string
+	// Validate reports whether token is valid for the client making r.
+	Validate(r *http.Request, token string) bool
+}
+
+var csrfStoreCtx = ctxkey.New[TokenStore]("structpages.csrfStore", nil)
+
+// WithCSRF checks CSRF tokens on every POST, PUT, PATCH and DELETE request
+// to a page. The token is read from the X-CSRF-Token header, or else the
+// csrf_token form field, and passed to store.Validate; a request that fails
+// reaches the error handler as an *HTTPError with Code 403 wrapping
+// ErrCSRFTokenInvalid, before any WithMiddlewares middleware runs.
+//
+// HTMX requests are not checked by default: browsers only send the
+// HX-Request header cross-origin after a CORS preflight. Use
+// WithCSRFCheckHTMX to check them too. Get the token to render into forms
+// from CSRFToken.
+func WithCSRF(store TokenStore) func(*StructPages) {
+	return func(r *StructPages) {
+		r.csrfStore = store
+	}
+}
+
+// WithCSRFCheckHTMX makes WithCSRF check HTMX requests as well, e.g. with
+// hx-headers='{"X-CSRF-Token": "..."}' set on the body.
+func WithCSRFCheckHTMX() func(*StructPages) {
+	return func(r *StructPages) {
+		r.csrfCheckHTMX = true
+	}
+}
+
+// CSRFToken returns the CSRF token for the client making r, from the
+// TokenStore given to WithCSRF, or "" when CSRF protection is off.
+func CSRFToken(r *http.Request) string {
+	store := csrfStoreCtx.Value(r.Context())
+	if store == nil {
+		return ""
+	}
+	return store.Generate(r)
+}
+
+// csrfMiddleware implements WithCSRF. It makes the store available to
+// CSRFToken on every request and validates the token on state-changing
+// ones.
+func (sp *StructPages) csrfMiddleware(next http.Handler, _ *PageNode) http.Handler {
+	store := sp.csrfStore
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r = r.WithContext(csrfStoreCtx.WithValue(r.Context(), store))
+		if !csrfChecked(r.Method) ||
+			(!sp.csrfCheckHTMX && r.Header.Get(htmxConfig(sp.pc).RequestHeader) == "true") {
+			next.ServeHTTP(w, r)
+			return
+		}
+		token := r.Header.Get("X-CSRF-Token")
+		if token == "" {
+			token = r.FormValue("csrf_token")
+		}
+		if token == "" || !store.Validate(r, token) {
+			sp.onError(w, r, &HTTPError{Code: http.StatusForbidden, Err: ErrCSRFTokenInvalid})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// csrfChecked reports whether requests with method change state and so need
+// a CSRF token.
+func csrfChecked(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package structpages
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// cookieTokenStore derives the CSRF token from the session cookie with an
+// HMAC, so no server-side state is needed.
+type cookieTokenStore struct{ key []byte }
+
+func (s cookieTokenStore) Generate(r *http.Request) string {
+	c, err := r.Cookie("session")
+	if err != nil {
+		return ""
+	}
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(c.Value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s cookieTokenStore) Validate(r *http.Request, token string) bool {
+	want := s.Generate(r)
+	return want != "" && hmac.Equal([]byte(token), []byte(want))
+}
+
+type csrfForm struct{}
+
+func (csrfForm) Props(r *http.Request) (string, error) { return "token=" + CSRFToken(r), nil }
+func (csrfForm) Page(s string) component               { return testComponent{s} }
+
+type csrfSubmit struct{}
+
+func (csrfSubmit) Page() component { return testComponent{"saved"} }
+
+type csrfPages struct {
+	Form   csrfForm   `route:"GET /form Form"`
+	Submit csrfSubmit `route:"POST /submit Submit"`
+	Any    csrfSubmit `route:"/any Any"`
+}
+
+func TestCSRF(t *testing.T) {
+	store := cookieTokenStore{key: []byte("secret")}
+	var gotErr error
+	mount := func(t *testing.T, opts ...Option) *http.ServeMux {
+		t.Helper()
+		mux := http.NewServeMux()
+		opts = append(opts, WithCSRF(store), WithErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+			gotErr = err
+			http.Error(w, err.Error(), http.StatusForbidden)
+		}))
+		if _, err := Mount(mux, &csrfPages{}, "/", "App", opts...); err != nil {
+			t.Fatalf("Mount: %v", err)
+		}
+		return mux
+	}
+	session := &http.Cookie{Name: "session", Value: "abc"}
+	sessionReq := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	sessionReq.AddCookie(session)
+	token := store.Generate(sessionReq)
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		method   string
+		path     string
+		header   string
+		form     string
+		htmx     bool
+		wantCode int
+	}{
+		{name: "GET not checked", method: http.MethodGet, path: "/form", wantCode: http.StatusOK},
+		{name: "header token", method: http.MethodPost, path: "/submit", header: token, wantCode: http.StatusOK},
+		{name: "form token", method: http.MethodPost, path: "/submit", form: token, wantCode: http.StatusOK},
+		{name: "missing token", method: http.MethodPost, path: "/submit", wantCode: http.StatusForbidden},
+		{name: "wrong token", method: http.MethodPost, path: "/submit", header: "bogus", wantCode: http.StatusForbidden},
+		{name: "DELETE on route without method", method: http.MethodDelete, path: "/any", wantCode: http.StatusForbidden},
+		{name: "HTMX skipped", method: http.MethodPost, path: "/submit", htmx: true, wantCode: http.StatusOK},
+		{
+			name: "HTMX checked with override", opts: []Option{WithCSRFCheckHTMX()},
+			method: http.MethodPost, path: "/submit", htmx: true, wantCode: http.StatusForbidden,
+		},
+		{
+			name: "HTMX override with token", opts: []Option{WithCSRFCheckHTMX()},
+			method: http.MethodPost, path: "/submit", header: token, htmx: true, wantCode: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := mount(t, tt.opts...)
+			gotErr = nil
+			req := httptest.NewRequest(tt.method, tt.path, http.NoBody)
+			if tt.form != "" {
+				req = httptest.NewRequest(tt.method, tt.path,
+					strings.NewReader(url.Values{"csrf_token": {tt.form}}.Encode()))
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			}
+			req.AddCookie(session)
+			if tt.header != "" {
+				req.Header.Set("X-CSRF-Token", tt.header)
+			}
+			if tt.htmx {
+				req.Header.Set("HX-Request", "true")
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantCode, rec.Body.String())
+			}
+			if tt.wantCode == http.StatusForbidden {
+				var httpErr *HTTPError
+				if !errors.As(gotErr, &httpErr) || httpErr.Code != http.StatusForbidden ||
+					!errors.Is(gotErr, ErrCSRFTokenInvalid) {
+					t.Errorf("error handler got %v, want 403 HTTPError wrapping ErrCSRFTokenInvalid", gotErr)
+				}
+			}
+		})
+	}
+}
+
+func TestCSRFToken(t *testing.T) {
+	store := cookieTokenStore{key: []byte("secret")}
+	mux := http.NewServeMux()
+	if _, err := Mount(mux, &csrfPages{}, "/", "App", WithCSRF(store)); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/form", http.NoBody)
+	req.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if want := "token=" + store.Generate(req); rec.Body.String() != want {
+		t.Errorf("body = %q, want %q", rec.Body.String(), want)
+	}
+
+	if got := CSRFToken(httptest.NewRequest(http.MethodGet, "/", http.NoBody)); got != "" {
+		t.Errorf("CSRFToken without WithCSRF = %q, want empty", got)
+	}
+}
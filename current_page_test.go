@@ -77,3 +77,68 @@ func TestCurrentPage_NilOutsideRequest(t *testing.T) {
 		t.Errorf("PageContext: CurrentPage = %v, want nil", pn)
 	}
 }
+
+// cpHandler is a ServeHTTP page reporting the page CurrentPage returns.
+type cpHandler struct{}
+
+func (cpHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if pn := CurrentPage(r.Context()); pn != nil {
+		_, _ = io.WriteString(w, pn.Name+" "+pn.Meta["role"])
+	}
+}
+
+type cpAdmin struct {
+	Reports cpHandler `route:"/reports Reports" meta:"role:admin"`
+}
+
+type cpMiddlewareRoot struct {
+	Admin cpAdmin `route:"/admin Admin"`
+}
+
+func TestCurrentPage_SetBeforeMiddlewares(t *testing.T) {
+	var seen []string
+	mw := func(next http.Handler, node *PageNode) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if pn := CurrentPage(r.Context()); pn != node {
+				t.Errorf("middleware for %s: CurrentPage = %v", node.Name, pn)
+			}
+			seen = append(seen, node.Name)
+			next.ServeHTTP(w, r)
+		})
+	}
+	mux := http.NewServeMux()
+	if _, err := Mount(mux, &cpMiddlewareRoot{}, "/", "App", WithMiddlewares(mw)); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+
+	// Code wrapping the mux runs before structpages' middlewares.
+	outer := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if pn := CurrentPage(r.Context()); pn != nil {
+			t.Errorf("before the middlewares: CurrentPage = %v, want nil", pn)
+		}
+		mux.ServeHTTP(w, r)
+	})
+	rec := httptest.NewRecorder()
+	outer.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/reports", http.NoBody))
+
+	if got, want := rec.Body.String(), "Reports admin"; got != want {
+		t.Errorf("ServeHTTP page: body = %q, want %q", got, want)
+	}
+	if len(seen) != 1 || seen[0] != "Reports" {
+		t.Errorf("middleware ran for %v, want the leaf [Reports]", seen)
+	}
+}
+
+// TestCurrentPage_WildcardServeHTTPPage covers a ServeHTTP page behind a
+// {path...} wildcard, one of the routes Match resolves (see match_test.go).
+func TestCurrentPage_WildcardServeHTTPPage(t *testing.T) {
+	mux := http.NewServeMux()
+	if _, err := Mount(mux, &matchPages{}, "/", "App"); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/files/x", http.NoBody))
+	if rec.Code != http.StatusOK || rec.Body.String() != "Files" {
+		t.Errorf("got %d %q, want 200 Files", rec.Code, rec.Body.String())
+	}
+}
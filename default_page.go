@@ -0,0 +1,131 @@
+package structpages
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// WithDefaultPage serves page at the root route when the root page has no
+// handler of its own — a root struct that only groups child routes answers
+// 404 at "/" otherwise. page identifies a page of the tree as in URLFor, and
+// is served with its own middlewares, as if requested at its route:
+//
+//	type root struct {
+//	    Home  home  `route:"/home Home"`
+//	    Users users `route:"/users Users"`
+//	}
+//
+//	structpages.Mount(mux, root{}, "/", "App", structpages.WithDefaultPage(home{}))
+//	// GET / renders home; GET /missing is still a 404
+//
+// Only the root route itself is matched. Mount fails if page isn't in the
+// tree or has path parameters, if the root page has a handler or an index
+// child, or if WithRootRedirect is enabled as well.
+func WithDefaultPage(page any) func(*StructPages) {
+	return func(r *StructPages) {
+		r.defaultPage = page
+	}
+}
+
+// WithRootRedirect answers requests to a root page that has children but no
+// handler of its own with a 302 redirect to its first child: the first one,
+// in declaration order, that is registered, not reverted and has no path
+// parameters. It has no effect when the root page has a handler. Mount fails
+// if WithDefaultPage is set as well.
+//
+//	structpages.Mount(mux, root{}, "/", "App", structpages.WithRootRedirect(true))
+//	// GET / redirects to /home
+func WithRootRedirect(enabled bool) func(*StructPages) {
+	return func(r *StructPages) {
+		r.rootRedirect = enabled
+	}
+}
+
+// resolveDefaultPage checks the WithDefaultPage and WithRootRedirect options
+// and finds the default page's node, before register walks the tree.
+func (sp *StructPages) resolveDefaultPage() error {
+	if sp.defaultPage == nil {
+		return nil
+	}
+	if sp.rootRedirect {
+		return errors.New("structpages: WithDefaultPage and WithRootRedirect are mutually exclusive")
+	}
+	node, err := sp.pc.findPageNode(sp.defaultPage)
+	if err != nil {
+		return fmt.Errorf("WithDefaultPage: %w", err)
+	}
+	if strings.Contains(strings.ReplaceAll(node.FullRoute(), "{$}", ""), "{") {
+		return fmt.Errorf("WithDefaultPage: page %s has path parameters", node.Name)
+	}
+	sp.defaultNode = node
+	return nil
+}
+
+// registerRootFallback registers the WithDefaultPage or WithRootRedirect
+// handler for root. Children are registered first, so the default page's
+// handler has been captured by now.
+func (sp *StructPages) registerRootFallback(mux Mux, root *PageNode, hasHandler bool, mw []MiddlewareFunc) error {
+	switch {
+	case sp.defaultNode != nil:
+		if hasHandler {
+			return fmt.Errorf("WithDefaultPage: root page %s has its own handler", root.Name)
+		}
+		if idx := root.indexChild(); idx != nil {
+			return fmt.Errorf("WithDefaultPage: root page %s has index page %s", root.Name, idx.Name)
+		}
+		if sp.defaultHandler == nil {
+			return fmt.Errorf("WithDefaultPage: page %s is not registered", sp.defaultNode.Name)
+		}
+		mux.Handle(rootPattern(sp.routePrefix, root, sp.defaultNode.Method), sp.defaultHandler)
+	case sp.rootRedirect && !hasHandler && len(root.Children) > 0 && root.indexChild() == nil:
+		var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			target, ok := sp.firstChildURL(sp.pc.currentNode(root))
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			http.Redirect(w, r, target, http.StatusFound)
+		})
+		for _, middleware := range slices.Backward(mw) {
+			handler = middleware(handler, root)
+		}
+		handler = sp.withRevert(withHEAD(handler), root)
+		mux.Handle(rootPattern(sp.routePrefix, root, http.MethodGet), handler)
+	}
+	return nil
+}
+
+// firstChildURL returns the URL WithRootRedirect sends requests for root to.
+func (sp *StructPages) firstChildURL(root *PageNode) (string, bool) {
+	for _, child := range root.Children {
+		if !sp.inEnv(child) || sp.pc.isReverted(child) {
+			continue
+		}
+		target := child.urlTarget()
+		if !target.routable() && len(target.Children) == 0 {
+			continue
+		}
+		route := strings.Replace(target.FullRoute(), "{$}", "", 1)
+		if strings.Contains(route, "{") {
+			continue
+		}
+		return applyURLPrefix(sp.pc.urlPrefix, applyURLPrefix(sp.pc.routePrefix, route)), true
+	}
+	return "", false
+}
+
+// rootPattern returns the mux pattern for exactly root's route: a trailing
+// slash would otherwise match every path below it.
+func rootPattern(prefix string, root *PageNode, method string) string {
+	route := prefix + root.FullRoute()
+	if strings.HasSuffix(route, "/") {
+		route += "{$}"
+	}
+	if method == methodAll {
+		return route
+	}
+	return method + " " + route
+}
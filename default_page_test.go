@@ -0,0 +1,82 @@
+package structpages
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type defaultHomePage struct{}
+
+func (defaultHomePage) Page() component { return testComponent{"home"} }
+
+type defaultUsersPage struct{}
+
+func (defaultUsersPage) Page() component { return testComponent{"users"} }
+
+type defaultRootPages struct {
+	Home  defaultHomePage  `route:"/home Home"`
+	Users defaultUsersPage `route:"/users Users"`
+}
+
+func TestWithDefaultPage(t *testing.T) {
+	mux := http.NewServeMux()
+	if _, err := Mount(mux, defaultRootPages{}, "/", "App", WithDefaultPage(defaultUsersPage{})); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	for path, want := range map[string]struct {
+		code int
+		body string
+	}{
+		"/":        {http.StatusOK, "users"},
+		"/home":    {http.StatusOK, "home"},
+		"/missing": {http.StatusNotFound, ""},
+	} {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+		if rec.Code != want.code || want.body != "" && rec.Body.String() != want.body {
+			t.Errorf("GET %s = %d %q, want %d %q", path, rec.Code, rec.Body.String(), want.code, want.body)
+		}
+	}
+}
+
+func TestWithRootRedirect(t *testing.T) {
+	mux := http.NewServeMux()
+	if _, err := Mount(mux, defaultRootPages{}, "/", "App",
+		WithRootRedirect(true), WithRoutePrefix("/v2")); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v2/", http.NoBody))
+	if rec.Code != http.StatusFound || rec.Header().Get("Location") != "/v2/home" {
+		t.Errorf("GET /v2/ = %d Location %q, want 302 /v2/home", rec.Code, rec.Header().Get("Location"))
+	}
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v2/missing", http.NoBody))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("GET /v2/missing = %d, want 404", rec.Code)
+	}
+}
+
+func TestWithDefaultPage_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		page any
+		opts []Option
+		want string
+	}{
+		{"with root redirect", defaultRootPages{}, []Option{WithDefaultPage(defaultHomePage{}), WithRootRedirect(true)},
+			"mutually exclusive"},
+		{"page not in tree", defaultRootPages{}, []Option{WithDefaultPage(strayWidgetPage{})}, "no page node found"},
+		{"root has handler", defaultHomePage{}, []Option{WithDefaultPage(defaultHomePage{})}, "has its own handler"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Mount(http.NewServeMux(), tt.page, "/", "App", tt.opts...)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Mount error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package structpages
+
+import "strings"
+
+// WithDefaultTitle derives a title for every page whose route tag has none,
+// so navigation and breadcrumbs built from PageNode.Title have text for
+// each page. fn is called once per untitled page after parsing; a nil fn
+// uses DefaultTitle.
+//
+//	structpages.WithDefaultTitle(nil) // `route:"/user-settings"` → "User Settings"
+func WithDefaultTitle(fn func(pn *PageNode) string) func(*StructPages) {
+	if fn == nil {
+		fn = DefaultTitle
+	}
+	return func(sp *StructPages) {
+		sp.defaultTitle = fn
+	}
+}
+
+// DefaultTitle returns the last static segment of pn's route in Title Case,
+// with "-" and "_" read as spaces: "/admin/user-settings" gives "User
+// Settings". Path parameters are skipped; a route without a static segment
+// gives pn.Name.
+func DefaultTitle(pn *PageNode) string {
+	segments := strings.Split(strings.Trim(pn.Route, "/"), "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		seg := segments[i]
+		if seg == "" || strings.HasPrefix(seg, "{") {
+			continue
+		}
+		words := strings.FieldsFunc(seg, func(r rune) bool { return r == '-' || r == '_' })
+		for j, w := range words {
+			words[j] = strings.ToUpper(w[:1]) + w[1:]
+		}
+		if len(words) > 0 {
+			return strings.Join(words, " ")
+		}
+	}
+	return pn.Name
+}
+
+// applyDefaultTitles fills in the empty titles of pn and its descendants
+// with WithDefaultTitle's function.
+func (sp *StructPages) applyDefaultTitles(pn *PageNode) {
+	if sp.defaultTitle == nil {
+		return
+	}
+	for n := range pn.All() {
+		if n.Title == "" {
+			n.Title = sp.defaultTitle(n)
+		}
+	}
+}
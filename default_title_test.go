@@ -0,0 +1,87 @@
+package structpages
+
+import (
+	"strings"
+	"testing"
+)
+
+type titlePage struct{}
+
+func (titlePage) Page() component { return testComponent{"title"} }
+
+type titleSettings struct {
+	Profile titlePage `route:"/user-profile"`
+	Item    titlePage `route:"/items/{id}"`
+}
+
+type titlePages struct {
+	Home     titlePage     `route:"/{$} Welcome"`
+	Settings titleSettings `route:"/account_settings"`
+	Param    titlePage     `route:"/{slug}"`
+}
+
+func titles(sp *StructPages) map[string]string {
+	out := map[string]string{}
+	for pn := range sp.pc.root().All() {
+		out[pn.Name] = pn.Title
+	}
+	return out
+}
+
+func TestWithDefaultTitle(t *testing.T) {
+	sp, err := Parse(&titlePages{}, "/", "", WithDefaultTitle(nil))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	got := titles(sp)
+	want := map[string]string{
+		"titlePages": "titlePages",
+		"Home":       "Welcome",
+		"Settings":   "Account Settings",
+		"Profile":    "User Profile",
+		"Item":       "Items",
+		"Param":      "Param",
+	}
+	for name, title := range want {
+		if got[name] != title {
+			t.Errorf("%s: Title = %q, want %q", name, got[name], title)
+		}
+	}
+
+	var exported []string
+	for _, r := range sp.Export() {
+		exported = append(exported, r.PageName+"="+r.Title)
+	}
+	if s := strings.Join(exported, " "); !strings.Contains(s, "Profile=User Profile") {
+		t.Errorf("Export titles = %s, want Profile=User Profile", s)
+	}
+	if s := sp.pc.root().String(); !strings.Contains(s, "title: Account Settings") {
+		t.Errorf("String() lacks the derived title:\n%s", s)
+	}
+}
+
+func TestWithDefaultTitle_CustomFunc(t *testing.T) {
+	sp, err := Parse(&titlePages{}, "/", "App", WithDefaultTitle(func(pn *PageNode) string {
+		return "untitled " + pn.Name
+	}))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	got := titles(sp)
+	if got["Home"] != "Welcome" || got["titlePages"] != "App" {
+		t.Errorf("explicit titles were replaced: Home=%q root=%q", got["Home"], got["titlePages"])
+	}
+	if got["Profile"] != "untitled Profile" {
+		t.Errorf("Profile: Title = %q, want %q", got["Profile"], "untitled Profile")
+	}
+}
+
+func TestWithDefaultTitle_Unset(t *testing.T) {
+	sp, err := Parse(&titlePages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if got := titles(sp)["Profile"]; got != "" {
+		t.Errorf("Profile: Title = %q without WithDefaultTitle, want empty", got)
+	}
+}
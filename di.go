@@ -0,0 +1,168 @@
+package structpages
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"text/tabwriter"
+)
+
+// ErrArgNotFound is returned by UpdateArg when no argument of the given
+// type is registered.
+var ErrArgNotFound = errors.New("argument not found")
+
+// diAlias is carried in StructPages.args so aliases reach the registry
+// before Init methods run at parse time.
+type diAlias struct {
+	from, to reflect.Type
+}
+
+// WithDIAlias makes parameters of type From resolve to the value registered
+// for type To. It is useful when a method asks for an interface that several
+// registered values satisfy, or for a named type the registered value is
+// assignable to:
+//
+//	structpages.Mount(mux, index{}, "/", "App",
+//	    structpages.WithArgs(sqlStore, memCache),
+//	    structpages.WithDIAlias[Store, *SQLStore]())
+//
+// To must be assignable to From; Mount and Parse report an error otherwise.
+func WithDIAlias[From, To any]() Option {
+	return func(sp *StructPages) {
+		sp.args = append(sp.args, diAlias{from: reflect.TypeFor[From](), to: reflect.TypeFor[To]()})
+	}
+}
+
+func (p *parseContext) addAlias(a diAlias) error {
+	if !a.to.AssignableTo(a.from) {
+		return fmt.Errorf("invalid DI alias %s -> %s: %s is not assignable to %s", a.from, a.to, a.to, a.from)
+	}
+	if p.aliases == nil {
+		p.aliases = make(map[reflect.Type]reflect.Type)
+	}
+	if prev, ok := p.aliases[a.from]; ok {
+		return fmt.Errorf("duplicate DI alias for %s: already aliased to %s", a.from, prev)
+	}
+	p.aliases[a.from] = a.to
+	return nil
+}
+
+// resolveRegistered looks t up in the args registry, following a
+// WithDIAlias redirect first.
+func (p *parseContext) resolveRegistered(t reflect.Type) (reflect.Value, error) {
+	p.argsMu.RLock()
+	defer p.argsMu.RUnlock()
+	if to, ok := p.aliases[t]; ok {
+		v, err := p.args.resolveArg(to)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("alias %s -> %s: %w", t, to, err)
+		}
+		return v, nil
+	}
+	return p.args.resolveArg(t)
+}
+
+// DITypes returns the types registered for dependency injection via
+// WithArgs, in a stable order.
+func DITypes(sp *StructPages) []reflect.Type {
+	if sp == nil {
+		return nil
+	}
+	if sp = sp.current(); sp.pc == nil {
+		return nil
+	}
+	sp.pc.argsMu.RLock()
+	defer sp.pc.argsMu.RUnlock()
+	return sp.pc.args.types()
+}
+
+// DumpDI writes a table of everything registered for dependency injection
+// to w: each WithArgs value with its type, kind, value, and position among
+// all WithArgs arguments, followed by WithDIAlias redirects. Use it to debug
+// a parameter that resolves to the wrong value or not at all.
+func DumpDI(sp *StructPages, w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "TYPE\tKIND\tVALUE\tSOURCE")
+	if sp != nil {
+		sp = sp.current()
+		if sp.pc != nil {
+			sp.pc.argsMu.RLock()
+			defer sp.pc.argsMu.RUnlock()
+		}
+		pos := 0
+		var aliases []diAlias
+		for _, arg := range sp.args {
+			if a, ok := arg.(diAlias); ok {
+				aliases = append(aliases, a)
+				continue
+			}
+			if _, ok := arg.(configFile); ok {
+				continue
+			}
+			if f, ok := arg.(perRequestArg); ok {
+				_, _ = fmt.Fprintf(tw, "%s\t%s\tper request\tWithArgs[%d]\n", f.typ, typeKind(f.typ), pos)
+				pos++
+				continue
+			}
+			if arg != nil {
+				t := reflect.TypeOf(arg)
+				_, _ = fmt.Fprintf(tw, "%s\t%s\t%v\tWithArgs[%d]\n", t, typeKind(t), arg, pos)
+			}
+			pos++
+		}
+		for _, a := range aliases {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\talias of %s\tWithDIAlias\n", a.from, typeKind(a.from), a.to)
+		}
+	}
+	_ = tw.Flush()
+}
+
+func typeKind(t reflect.Type) string {
+	if t.Kind() == reflect.Interface {
+		return "interface"
+	}
+	return "concrete"
+}
+
+// UpdateArg replaces the registered argument whose type is the type of v,
+// e.g. to swap a database handle after a config reload. Requests started
+// after UpdateArg returns see the new value. It returns an error wrapping
+// ErrArgNotFound when no argument of that type was registered; use AddArg
+// to register a new one. Safe for concurrent use with serving requests.
+func (sp *StructPages) UpdateArg(v any) error {
+	sp.checkWritable()
+	sp = sp.current()
+	if v == nil {
+		return errors.New("structpages: UpdateArg called with nil")
+	}
+	typ := reflect.TypeOf(v)
+	sp.pc.argsMu.Lock()
+	defer sp.pc.argsMu.Unlock()
+	if _, ok := sp.pc.args[typ]; !ok {
+		return fmt.Errorf("%w: %s", ErrArgNotFound, typ)
+	}
+	sp.pc.args[typ] = reflect.ValueOf(v)
+	for i, arg := range sp.args {
+		if arg != nil && reflect.TypeOf(arg) == typ {
+			sp.args[i] = v
+		}
+	}
+	return nil
+}
+
+// AddArg registers a new dependency injection argument after Mount, as if
+// it had been passed to WithArgs. Registering a type twice is an error;
+// use UpdateArg to replace a value. Safe for concurrent use with serving
+// requests.
+func (sp *StructPages) AddArg(v any) error {
+	sp.checkWritable()
+	sp = sp.current()
+	sp.pc.argsMu.Lock()
+	defer sp.pc.argsMu.Unlock()
+	if err := sp.pc.args.addArg(v); err != nil {
+		return err
+	}
+	sp.args = append(sp.args, v)
+	return nil
+}
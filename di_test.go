@@ -0,0 +1,197 @@
+package structpages
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDumpDI(t *testing.T) {
+	sp, err := Parse(&diStorePages{}, "/", "App",
+		WithArgs(&diSQLStore{dsn: "db"}),
+		WithArgs(diMemStore{}, 42),
+		WithDIAlias[diStore, *diSQLStore]())
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	var buf bytes.Buffer
+	DumpDI(sp, &buf)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	var rows [][]string
+	for _, line := range lines {
+		rows = append(rows, strings.Fields(line))
+	}
+	want := [][]string{
+		{"TYPE", "KIND", "VALUE", "SOURCE"},
+		{"*structpages.diSQLStore", "concrete", "&{db}", "WithArgs[0]"},
+		{"structpages.diMemStore", "concrete", "{}", "WithArgs[1]"},
+		{"int", "concrete", "42", "WithArgs[2]"},
+		{"structpages.diStore", "interface", "alias", "of", "*structpages.diSQLStore", "WithDIAlias"},
+	}
+	if diff := cmp.Diff(want, rows); diff != "" {
+		t.Errorf("DumpDI mismatch (-want +got):\n%s\nfull output:\n%s", diff, buf.String())
+	}
+}
+
+func TestDITypes(t *testing.T) {
+	sp, err := Parse(&diStorePages{}, "/", "App", WithArgs(diMemStore{}, &diSQLStore{}))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	want := []reflect.Type{reflect.TypeFor[*diSQLStore](), reflect.TypeFor[diMemStore]()}
+	if got := DITypes(sp); !slices.Equal(got, want) {
+		t.Errorf("DITypes = %v, want %v", got, want)
+	}
+	if got := DITypes(nil); got != nil {
+		t.Errorf("DITypes(nil) = %v, want nil", got)
+	}
+}
+
+func TestWithDIAlias(t *testing.T) {
+	t.Run("alias resolves an otherwise ambiguous interface", func(t *testing.T) {
+		mux := http.NewServeMux()
+		_, err := Mount(mux, &diStorePages{}, "/", "App",
+			WithArgs(&diSQLStore{dsn: "db"}, diMemStore{}),
+			WithDIAlias[diStore, diMemStore]())
+		if err != nil {
+			t.Fatalf("Mount: %v", err)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+		if rec.Code != http.StatusOK || rec.Body.String() != "mem" {
+			t.Fatalf("got %d %q, want 200 %q", rec.Code, rec.Body.String(), "mem")
+		}
+	})
+
+	t.Run("alias target must be assignable", func(t *testing.T) {
+		_, err := Parse(&diStorePages{}, "/", "App", WithDIAlias[diStore, int]())
+		if err == nil || !strings.Contains(err.Error(), "int is not assignable to structpages.diStore") {
+			t.Fatalf("err = %v, want assignability error", err)
+		}
+	})
+
+	t.Run("duplicate alias", func(t *testing.T) {
+		_, err := Parse(&diStorePages{}, "/", "App",
+			WithDIAlias[diStore, diMemStore](), WithDIAlias[diStore, *diSQLStore]())
+		if err == nil || !strings.Contains(err.Error(), "duplicate DI alias") {
+			t.Fatalf("err = %v, want duplicate alias error", err)
+		}
+	})
+
+	t.Run("Validate follows aliases", func(t *testing.T) {
+		_, err := Validate(&diStorePages{}, "/", "App", WithDIAlias[diStore, *diSQLStore]())
+		if err == nil || !strings.Contains(err.Error(), "requires argument of type structpages.diStore") {
+			t.Fatalf("err = %v, want unresolved alias error", err)
+		}
+		if _, err := Validate(&diStorePages{}, "/", "App",
+			WithArgs(&diSQLStore{}, diMemStore{}), WithDIAlias[diStore, *diSQLStore]()); err != nil {
+			t.Fatalf("Validate: %v", err)
+		}
+	})
+}
+
+type updateArgConfig struct{ greeting string }
+
+type updateArgPage struct{}
+
+func (updateArgPage) Props(cfg *updateArgConfig) (string, error) { return cfg.greeting, nil }
+
+func (updateArgPage) Page(s string) component { return testComponent{s} }
+
+type updateArgPages struct {
+	updateArgPage `route:"/ Home"`
+}
+
+func TestStructPages_UpdateArg(t *testing.T) {
+	mux := http.NewServeMux()
+	sp, err := Mount(mux, &updateArgPages{}, "/", "App", WithArgs(&updateArgConfig{greeting: "hello"}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	get := func() string {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+		return rec.Body.String()
+	}
+	if got := get(); got != "hello" {
+		t.Fatalf("before UpdateArg: %q, want %q", got, "hello")
+	}
+	if err := sp.UpdateArg(&updateArgConfig{greeting: "bonjour"}); err != nil {
+		t.Fatalf("UpdateArg: %v", err)
+	}
+	if got := get(); got != "bonjour" {
+		t.Errorf("after UpdateArg: %q, want %q", got, "bonjour")
+	}
+
+	var buf bytes.Buffer
+	DumpDI(sp, &buf)
+	if !strings.Contains(buf.String(), "&{bonjour}") {
+		t.Errorf("DumpDI does not show the updated value:\n%s", buf.String())
+	}
+
+	if err := sp.UpdateArg(diMemStore{}); !errors.Is(err, ErrArgNotFound) {
+		t.Errorf("UpdateArg(unregistered) err = %v, want ErrArgNotFound", err)
+	}
+	if err := sp.UpdateArg(nil); err == nil {
+		t.Error("UpdateArg(nil) succeeded, want error")
+	}
+}
+
+func TestStructPages_AddArg(t *testing.T) {
+	mux := http.NewServeMux()
+	sp, err := Mount(mux, &updateArgPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	if err := sp.AddArg(&updateArgConfig{greeting: "added"}); err != nil {
+		t.Fatalf("AddArg: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	if rec.Body.String() != "added" {
+		t.Errorf("body = %q, want %q", rec.Body.String(), "added")
+	}
+	if err := sp.AddArg(&updateArgConfig{}); err == nil || !strings.Contains(err.Error(), "duplicate type") {
+		t.Errorf("AddArg(duplicate) err = %v, want duplicate type error", err)
+	}
+}
+
+// TestStructPages_UpdateArg_Concurrent is meaningful under go test -race.
+func TestStructPages_UpdateArg_Concurrent(t *testing.T) {
+	mux := http.NewServeMux()
+	sp, err := Mount(mux, &updateArgPages{}, "/", "App", WithArgs(&updateArgConfig{greeting: "v0"}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				rec := httptest.NewRecorder()
+				mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+				if rec.Code != http.StatusOK {
+					t.Errorf("status = %d", rec.Code)
+					return
+				}
+			}
+		}()
+	}
+	for i := range 50 {
+		if err := sp.UpdateArg(&updateArgConfig{greeting: fmt.Sprintf("v%d", i)}); err != nil {
+			t.Errorf("UpdateArg: %v", err)
+		}
+	}
+	wg.Wait()
+}
@@ -0,0 +1,49 @@
+package structpages
+
+import (
+	"reflect"
+	"slices"
+)
+
+// WithEagerProps controls whether a page runs all of its Props methods for
+// every request (enabled, the default) or only those the selected component
+// needs (disabled). The render target is always resolved before Props runs;
+// with eager Props off, execProps uses it to skip the WithPropsWaterfall
+// methods whose results the target's component doesn't take, directly or
+// through another method that runs:
+//
+//	func (p todoPage) TodosProps(db *DB) ([]Todo, error) { ... }
+//	func (p todoPage) UserProps(r *http.Request, db *DB) (*User, error) { ... }
+//	func (p todoPage) Page(todos []Todo, u *User) component { ... }
+//	func (p todoPage) TodoList(todos []Todo) component { ... }
+//
+// Here an HTMX request targeting TodoList runs only TodosProps. Props itself
+// always runs, as it may pick a different component with RenderComponent,
+// and so do all methods when the target is not a component method of the
+// page.
+func WithEagerProps(enabled bool) func(*StructPages) {
+	return func(r *StructPages) {
+		r.lazyProps = !enabled
+	}
+}
+
+// neededProps returns the methods of methods whose results are needed to
+// render target: those the target's component takes, the ones they take,
+// and so on, plus Props. methods is in execution order, which puts every
+// method before the ones taking its results.
+func neededProps(methods []reflect.Method, target RenderTarget) []reflect.Method {
+	mrt, ok := target.(*methodRenderTarget)
+	if !ok || !mrt.method.Func.IsValid() {
+		return methods
+	}
+	needed := []reflect.Method{mrt.method}
+	var kept []reflect.Method
+	for _, m := range slices.Backward(methods) {
+		if m.Name == "Props" || slices.ContainsFunc(needed, func(n reflect.Method) bool { return consumes(n, m) }) {
+			needed = append(needed, m)
+			kept = append(kept, m)
+		}
+	}
+	slices.Reverse(kept)
+	return kept
+}
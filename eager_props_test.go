@@ -0,0 +1,111 @@
+package structpages
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type lazyUser struct{ Name string }
+
+// lazyDB counts the queries the Props methods of lazyPage make.
+type lazyDB struct{ queries int }
+
+func (db *lazyDB) todos() []string { db.queries++; return []string{"a", "b"} }
+
+func (db *lazyDB) user() *lazyUser { db.queries++; return &lazyUser{Name: "kim"} }
+
+type lazyPage struct{}
+
+func (lazyPage) TodosProps(db *lazyDB) ([]string, error) { return db.todos(), nil }
+
+func (lazyPage) UserProps(db *lazyDB) (*lazyUser, error) { return db.user(), nil }
+
+func (lazyPage) Page(todos []string, u *lazyUser) component {
+	return testComponent{u.Name + ": " + strings.Join(todos, ",")}
+}
+
+func (lazyPage) TodoList(todos []string) component { return testComponent{strings.Join(todos, ",")} }
+
+type lazyPages struct {
+	Todos lazyPage `route:"/todos Todos"`
+}
+
+func TestWithEagerProps(t *testing.T) {
+	tests := []struct {
+		name        string
+		eager       bool
+		partial     bool
+		wantBody    string
+		wantQueries int
+	}{
+		{"eager page", true, false, "kim: a,b", 2},
+		{"eager partial", true, true, "a,b", 2},
+		{"lazy page", false, false, "kim: a,b", 2},
+		{"lazy partial", false, true, "a,b", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &lazyDB{}
+			mux := http.NewServeMux()
+			sp, err := Mount(mux, lazyPages{}, "/", "App",
+				WithArgs(db), WithPropsWaterfall(), WithEagerProps(tt.eager))
+			if err != nil {
+				t.Fatalf("Mount: %v", err)
+			}
+			req := httptest.NewRequest(http.MethodGet, "/todos", http.NoBody)
+			if tt.partial {
+				id, err := sp.ID(lazyPage.TodoList)
+				if err != nil {
+					t.Fatalf("ID: %v", err)
+				}
+				req.Header.Set("HX-Request", "true")
+				req.Header.Set("HX-Target", id)
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+			if rec.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", rec.Body.String(), tt.wantBody)
+			}
+			if db.queries != tt.wantQueries {
+				t.Errorf("queries = %d, want %d", db.queries, tt.wantQueries)
+			}
+		})
+	}
+}
+
+// lazyPropsPage's Props runs even when its result isn't needed by the
+// target, since it may redirect the render with RenderComponent.
+type lazyPropsPage struct{}
+
+func (lazyPropsPage) Props(db *lazyDB) (*lazyUser, error) { return db.user(), nil }
+
+func (lazyPropsPage) Page(u *lazyUser) component { return testComponent{u.Name} }
+
+func (lazyPropsPage) Count() component { return testComponent{"count"} }
+
+type lazyPropsPages struct {
+	Home lazyPropsPage `route:"/ Home"`
+}
+
+func TestWithEagerProps_PropsAlwaysRuns(t *testing.T) {
+	db := &lazyDB{}
+	mux := http.NewServeMux()
+	sp, err := Mount(mux, lazyPropsPages{}, "/", "App", WithArgs(db), WithEagerProps(false))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	id, err := sp.ID(lazyPropsPage.Count)
+	if err != nil {
+		t.Fatalf("ID: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set("HX-Request", "true")
+	req.Header.Set("HX-Target", id)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Body.String() != "count" || db.queries != 1 {
+		t.Errorf("body = %q, queries = %d; want \"count\", 1", rec.Body.String(), db.queries)
+	}
+}
@@ -0,0 +1,39 @@
+package structpages
+
+import (
+	"os"
+	"slices"
+)
+
+// WithEnv sets the environment the pages are mounted in, e.g. "development"
+// or "production". A page with an Environments() []string method is only
+// registered when env is in the returned list; pages below it are skipped
+// along with it, and skipped pages are left out of Export and Match. Pages
+// without the method are always registered.
+//
+//	func (debugPage) Environments() []string { return []string{"development"} }
+//
+// Without WithEnv the environment is empty, so pages declaring Environments
+// are not registered unless their list contains "".
+func WithEnv(env string) func(*StructPages) {
+	return func(r *StructPages) {
+		r.env = env
+	}
+}
+
+// WithEnvFromOS is WithEnv with the value of the environment variable
+// varName, read when the option is applied.
+func WithEnvFromOS(varName string) func(*StructPages) {
+	return WithEnv(os.Getenv(varName))
+}
+
+// inEnv reports whether pn and all its ancestors allow the environment set
+// by WithEnv.
+func (sp *StructPages) inEnv(pn *PageNode) bool {
+	for n := pn; n != nil; n = n.Parent {
+		if n.environments != nil && !slices.Contains(n.environments, sp.env) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,111 @@
+package structpages
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type envDebugPage struct{}
+
+func (envDebugPage) Environments() []string { return []string{"development", "test"} }
+func (envDebugPage) Page() component        { return testComponent{"debug"} }
+
+type envDebugChild struct{}
+
+func (envDebugChild) Page() component { return testComponent{"vars"} }
+
+type envDebugSection struct {
+	envDebugPage  `route:"/{$} Debug"`
+	envDebugChild `route:"/vars Vars"`
+}
+
+func (envDebugSection) Environments() []string { return []string{"development"} }
+
+type envHomePage struct{}
+
+func (envHomePage) Page() component { return testComponent{"home"} }
+
+type envPages struct {
+	envHomePage     `route:"/{$} Home"`
+	envDebugSection `route:"/debug/ DebugSection"`
+}
+
+func envStatus(mux http.Handler, path string) int {
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+	return rec.Code
+}
+
+func TestWithEnv(t *testing.T) {
+	tests := []struct {
+		env       string
+		debugCode int
+		routes    int
+	}{
+		{"development", http.StatusOK, 3},
+		{"production", http.StatusNotFound, 1},
+		{"", http.StatusNotFound, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.env, func(t *testing.T) {
+			mux := http.NewServeMux()
+			sp, err := Mount(mux, &envPages{}, "/", "App", WithEnv(tt.env))
+			if err != nil {
+				t.Fatalf("Mount: %v", err)
+			}
+			if got := envStatus(mux, "/"); got != http.StatusOK {
+				t.Errorf("GET / = %d, want 200", got)
+			}
+			for _, path := range []string{"/debug/", "/debug/vars"} {
+				if got := envStatus(mux, path); got != tt.debugCode {
+					t.Errorf("GET %s = %d, want %d", path, got, tt.debugCode)
+				}
+			}
+			if got := len(sp.Export()); got != tt.routes {
+				t.Errorf("Export() has %d routes, want %d", got, tt.routes)
+			}
+		})
+	}
+}
+
+func TestWithEnv_PageNotInSectionEnv(t *testing.T) {
+	// envDebugPage allows "test", but its section does not.
+	mux := http.NewServeMux()
+	sp, err := Mount(mux, &envPages{}, "/", "App", WithEnv("test"))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	if got := envStatus(mux, "/debug/"); got != http.StatusNotFound {
+		t.Errorf("GET /debug/ = %d, want 404", got)
+	}
+	if _, _, err := sp.Match(http.MethodGet, "/debug/"); err == nil {
+		t.Error("Match found a page outside the environment")
+	}
+}
+
+func TestWithEnvFromOS(t *testing.T) {
+	t.Setenv("STRUCTPAGES_TEST_ENV", "development")
+	mux := http.NewServeMux()
+	if _, err := Mount(mux, &envPages{}, "/", "App", WithEnvFromOS("STRUCTPAGES_TEST_ENV")); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	if got := envStatus(mux, "/debug/vars"); got != http.StatusOK {
+		t.Errorf("GET /debug/vars = %d, want 200", got)
+	}
+}
+
+type badEnvironmentsPage struct{}
+
+func (badEnvironmentsPage) Environments() string { return "development" }
+func (badEnvironmentsPage) Page() component      { return testComponent{"x"} }
+
+func TestEnvironments_BadSignature(t *testing.T) {
+	_, err := Parse(&struct {
+		Bad badEnvironmentsPage `route:"/bad Bad"`
+	}{}, "/", "App")
+	if err == nil || !strings.Contains(err.Error(), "Environments method on Bad must take no arguments") {
+		t.Fatalf("err = %v, want Environments signature error", err)
+	}
+}
@@ -0,0 +1,139 @@
+package structpages
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/jackielii/ctxkey"
+)
+
+// ErrorPage renders error responses as components. RenderError may set the
+// status code and headers on w; the component it returns is rendered like
+// a page's. See StructPages.RegisterErrorPage.
+type ErrorPage interface {
+	RenderError(w http.ResponseWriter, r *http.Request, err error) component
+}
+
+// NotFoundPage is implemented by an ErrorPage that also renders the 404
+// response for requests no page is registered for.
+type NotFoundPage interface {
+	RenderNotFound(w http.ResponseWriter, r *http.Request) component
+}
+
+// MethodNotAllowedPage is implemented by an ErrorPage that also renders the
+// 405 response for requests whose path is registered for other methods.
+type MethodNotAllowedPage interface {
+	RenderMethodNotAllowed(w http.ResponseWriter, r *http.Request) component
+}
+
+// errorPageErrCtx holds the error an ErrorPage is rendering, marking the
+// request so a failure rendering it goes to the error handler set at mount.
+var errorPageErrCtx = ctxkey.New[error]("structpages.errorPageErr", nil)
+
+// RegisterErrorPage makes page render the responses of the error handler,
+// in place of the one set with WithErrorHandler:
+//
+//	type errorPage struct{}
+//
+//	func (errorPage) RenderError(w http.ResponseWriter, r *http.Request, err error) component {
+//	    w.WriteHeader(statusOf(err))
+//	    return errorView(err)
+//	}
+//
+//	sp.RegisterErrorPage(errorPage{})
+//
+// The component is rendered into a buffer and gets the HTML Content-Type
+// unless RenderError set another. If rendering it fails, the response is
+// discarded and the mount-time error handler receives both errors. If page
+// implements NotFoundPage or MethodNotAllowedPage, requests served through
+// sp.ServeHTTP that match no route get its 404 or 405 component instead of
+// the mux's plain-text response. Register the error page before serving
+// requests.
+func (sp *StructPages) RegisterErrorPage(page ErrorPage) {
+	sp.checkWritable()
+	sp.errorPage = page
+}
+
+// withErrorPage returns onError rendering the registered ErrorPage, if any,
+// and falling back to fallback.
+func (sp *StructPages) withErrorPage(fallback errorHandler) errorHandler {
+	return func(w http.ResponseWriter, r *http.Request, err error) {
+		if sp.errorPage == nil {
+			fallback(w, r, err)
+			return
+		}
+		if orig := errorPageErrCtx.Value(r.Context()); orig != nil {
+			// The error page itself failed: drop what it wrote.
+			if bw, ok := w.(*buffered); ok {
+				bw.buf.Reset()
+				bw.status, bw.statusSet = http.StatusOK, false
+			}
+			fallback(w, r, fmt.Errorf("rendering error page for %w: %w", orig, err))
+			return
+		}
+		bw := newBuffered(w)
+		defer func() { _ = bw.close() }()
+		r = r.WithContext(errorPageErrCtx.WithValue(r.Context(), err))
+		sp.render(bw, r, sp.errorPage.RenderError(bw, r, err), nil, "RenderError")
+	}
+}
+
+// serveUnmatched serves r with h and, when the mux answers 404 or 405 for a
+// request matching no route, renders the ErrorPage's component for it
+// instead. It reports false when the error page renders neither.
+func (sp *StructPages) serveUnmatched(h *http.ServeMux, w http.ResponseWriter, r *http.Request) bool {
+	notFound, _ := sp.errorPage.(NotFoundPage)
+	notAllowed, _ := sp.errorPage.(MethodNotAllowedPage)
+	if notFound == nil && notAllowed == nil {
+		return false
+	}
+	if _, pattern := h.Handler(r); pattern != "" {
+		h.ServeHTTP(w, r)
+		return true
+	}
+	uw := &unmatchedWriter{ResponseWriter: w, intercept: func(code int) bool {
+		return code == http.StatusNotFound && notFound != nil ||
+			code == http.StatusMethodNotAllowed && notAllowed != nil
+	}}
+	h.ServeHTTP(uw, r)
+	if uw.code == 0 {
+		return true
+	}
+	w.Header().Del("Content-Type")
+	w.Header().Del("X-Content-Type-Options")
+	bw := newBuffered(w)
+	defer func() { _ = bw.close() }()
+	bw.WriteHeader(uw.code)
+	var comp component
+	if uw.code == http.StatusNotFound {
+		comp = notFound.RenderNotFound(bw, r)
+	} else {
+		comp = notAllowed.RenderMethodNotAllowed(bw, r)
+	}
+	r = r.WithContext(errorPageErrCtx.WithValue(r.Context(), &HTTPError{Code: uw.code}))
+	sp.render(bw, r, comp, nil, "")
+	return true
+}
+
+// unmatchedWriter discards the mux's response for the status codes
+// intercept accepts, recording the code.
+type unmatchedWriter struct {
+	http.ResponseWriter
+	intercept func(int) bool
+	code      int
+}
+
+func (w *unmatchedWriter) WriteHeader(code int) {
+	if w.intercept(code) {
+		w.code = code
+		return
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *unmatchedWriter) Write(b []byte) (int, error) {
+	if w.code != 0 {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
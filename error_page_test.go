@@ -0,0 +1,140 @@
+package structpages
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var errFailingPage = errors.New("failing page")
+
+type failingPage struct{}
+
+func (failingPage) Props() (string, error) { return "", errFailingPage }
+
+func (failingPage) Page(s string) component { return testComponent{s} }
+
+type okPage struct{}
+
+func (okPage) Page() component { return testComponent{"ok"} }
+
+type errorPagePages struct {
+	Fail failingPage `route:"/fail Fail"`
+	OK   okPage      `route:"GET /ok OK"`
+}
+
+// testErrorPage renders errors, 404s and 405s, and fails to render errors
+// containing "broken".
+type testErrorPage struct{}
+
+func (testErrorPage) RenderError(w http.ResponseWriter, _ *http.Request, err error) component {
+	w.WriteHeader(http.StatusServiceUnavailable)
+	if strings.Contains(err.Error(), "broken") {
+		return fnComponent(func(_ context.Context, w io.Writer) error {
+			_, _ = io.WriteString(w, "half an error page")
+			return errors.New("error page render failed")
+		})
+	}
+	return testComponent{"<h1>error: " + err.Error() + "</h1>"}
+}
+
+func (testErrorPage) RenderNotFound(_ http.ResponseWriter, r *http.Request) component {
+	return testComponent{"<h1>no page at " + r.URL.Path + "</h1>"}
+}
+
+func (testErrorPage) RenderMethodNotAllowed(w http.ResponseWriter, _ *http.Request) component {
+	return testComponent{"<h1>use " + w.Header().Get("Allow") + "</h1>"}
+}
+
+func mountErrorPage(t *testing.T, fallback *error) *StructPages {
+	t.Helper()
+	sp, err := Mount(http.NewServeMux(), errorPagePages{}, "/", "App",
+		WithErrorHandler(func(w http.ResponseWriter, _ *http.Request, err error) {
+			*fallback = err
+			http.Error(w, "fallback", http.StatusInternalServerError)
+		}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	sp.RegisterErrorPage(testErrorPage{})
+	return sp
+}
+
+func TestRegisterErrorPage(t *testing.T) {
+	var fallbackErr error
+	sp := mountErrorPage(t, &fallbackErr)
+	rec := httptest.NewRecorder()
+	sp.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/fail", http.NoBody))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503 set by RenderError", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/html; charset=utf-8" {
+		t.Errorf("Content-Type = %q, want text/html", ct)
+	}
+	if body := rec.Body.String(); !strings.Contains(body, "<h1>error: ") || !strings.Contains(body, "failing page") {
+		t.Errorf("body = %q, want the error page", body)
+	}
+	if fallbackErr != nil {
+		t.Errorf("fallback handler called with %v", fallbackErr)
+	}
+}
+
+type brokenErrorPage struct{}
+
+func (brokenErrorPage) Props() (string, error) { return "", errors.New("broken") }
+
+func (brokenErrorPage) Page(s string) component { return testComponent{s} }
+
+func TestRegisterErrorPage_RenderFails(t *testing.T) {
+	var fallbackErr error
+	mux := http.NewServeMux()
+	sp, err := Mount(mux, struct {
+		Broken brokenErrorPage `route:"/broken Broken"`
+	}{}, "/", "App", WithErrorHandler(func(w http.ResponseWriter, _ *http.Request, err error) {
+		fallbackErr = err
+		http.Error(w, "fallback", http.StatusInternalServerError)
+	}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	sp.RegisterErrorPage(testErrorPage{})
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/broken", http.NoBody))
+	if rec.Code != http.StatusInternalServerError || rec.Body.String() != "fallback\n" {
+		t.Errorf("got %d %q, want only the fallback's 500", rec.Code, rec.Body.String())
+	}
+	if fallbackErr == nil || !strings.Contains(fallbackErr.Error(), "broken") ||
+		!strings.Contains(fallbackErr.Error(), "error page render failed") {
+		t.Errorf("fallback error = %v, want both errors", fallbackErr)
+	}
+}
+
+func TestRegisterErrorPage_NotFoundAndMethodNotAllowed(t *testing.T) {
+	var fallbackErr error
+	sp := mountErrorPage(t, &fallbackErr)
+	tests := []struct {
+		name, method, path string
+		wantCode           int
+		wantBody           string
+	}{
+		{"not found", http.MethodGet, "/missing/page", http.StatusNotFound, "<h1>no page at /missing/page</h1>"},
+		{"method not allowed", http.MethodPost, "/ok", http.StatusMethodNotAllowed, "<h1>use GET, HEAD</h1>"},
+		{"matched route", http.MethodGet, "/ok", http.StatusOK, "ok"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			sp.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, http.NoBody))
+			if rec.Code != tt.wantCode || rec.Body.String() != tt.wantBody {
+				t.Errorf("got %d %q, want %d %q", rec.Code, rec.Body.String(), tt.wantCode, tt.wantBody)
+			}
+			if tt.wantCode != http.StatusOK && rec.Header().Get("Content-Type") != "text/html; charset=utf-8" {
+				t.Errorf("Content-Type = %q, want text/html", rec.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package structpages
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+)
+
+// RouteExport describes one registered route, as returned by
+// StructPages.Export.
+type RouteExport struct {
+	// Method is the HTTP method from the route tag, or "ALL" for routes
+	// without one.
+	Method string `json:"method"`
+	// Pattern is the full path pattern, including any WithRoutePrefix.
+	Pattern string `json:"pattern"`
+	// PageName is the PageNode name; PageType the Go type of the page.
+	// Both are empty for routes added by RegisterRoute.
+	PageName string `json:"pageName"`
+	PageType string `json:"pageType"`
+	// Title is the page title, empty for RegisterRoute routes.
+	Title string `json:"title,omitempty"`
+	// Components lists the page's component methods, sorted.
+	Components     []string          `json:"components,omitempty"`
+	HasProps       bool              `json:"hasProps"`
+	HasMiddlewares bool              `json:"hasMiddlewares"`
+	HasServeHTTP   bool              `json:"hasServeHTTP"`
+	Meta           map[string]string `json:"meta,omitempty"`
+	// Aliases are the full patterns of the page's Aliases routes.
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// Export returns every registered route in page-tree order, then the
+// RegisterRoute routes by pattern, for admin dashboards, capability
+// listings, or API documentation generators.
+func (sp *StructPages) Export() []RouteExport {
+	sp = sp.current()
+	var routes []RouteExport
+	for pn := range sp.pc.root().All() {
+		if pn.Route == "" || !pn.routable() || !sp.inEnv(pn) {
+			continue
+		}
+		pageType := pn.Value.Type()
+		if pageType.Kind() == reflect.Pointer {
+			pageType = pageType.Elem()
+		}
+		var components []string
+		if len(pn.Components) > 0 {
+			components = slices.Sorted(maps.Keys(pn.Components))
+		}
+		var aliases []string
+		for i := range pn.Aliases {
+			aliases = append(aliases, sp.routePrefix+pn.aliasRoute(i))
+		}
+		routes = append(routes, RouteExport{
+			Method:         pn.Method,
+			Pattern:        sp.routePrefix + pn.FullRoute(),
+			PageName:       pn.Name,
+			PageType:       pageType.String(),
+			Title:          pn.Title,
+			Components:     components,
+			HasProps:       len(pn.Props) > 0,
+			HasMiddlewares: pn.Middlewares != nil,
+			HasServeHTTP:   pn.hasServeHTTP(),
+			Meta:           maps.Clone(pn.Meta),
+			Aliases:        aliases,
+		})
+	}
+	routes = append(routes, sp.pc.routeExports(sp.routePrefix)...)
+	return append(routes, sp.pluginExports()...)
+}
+
+// ServeRouteExportHandler returns a handler serving Export as JSON. Mount it
+// wherever the inventory should live, typically behind authentication:
+//
+//	mux.Handle("GET /admin/routes", requireAdmin(sp.ServeRouteExportHandler()))
+func (sp *StructPages) ServeRouteExportHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := json.Marshal(sp.Export())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(b)
+	})
+}
@@ -0,0 +1,69 @@
+package structpages
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type exportFullPage struct{}
+
+func (exportFullPage) Props() (string, error) { return "", nil }
+
+func (exportFullPage) Page(string) component { return testComponent{"page"} }
+
+func (exportFullPage) Row(string) component { return testComponent{"row"} }
+
+func (exportFullPage) Middlewares() []MiddlewareFunc { return nil }
+
+type exportPages struct {
+	Full  exportFullPage     `route:"GET /items/{id} Item" meta:"auth:required"`
+	Plain headersHandlerPage `route:"/hook Hook"`
+}
+
+func TestStructPages_Export(t *testing.T) {
+	sp, err := Parse(&exportPages{}, "/", "App", WithRoutePrefix("/v1"))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	want := []RouteExport{
+		{
+			Method:         http.MethodGet,
+			Pattern:        "/v1/items/{id}",
+			PageName:       "Full",
+			PageType:       "structpages.exportFullPage",
+			Title:          "Item",
+			Components:     []string{"Page", "Row"},
+			HasProps:       true,
+			HasMiddlewares: true,
+			Meta:           map[string]string{"auth": "required"},
+		},
+		{
+			Method:       methodAll,
+			Pattern:      "/v1/hook",
+			PageName:     "Plain",
+			PageType:     "structpages.headersHandlerPage",
+			Title:        "Hook",
+			HasServeHTTP: true,
+		},
+	}
+	if diff := cmp.Diff(want, sp.Export()); diff != "" {
+		t.Errorf("Export mismatch (-want +got):\n%s", diff)
+	}
+
+	rec := httptest.NewRecorder()
+	sp.ServeRouteExportHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/routes", http.NoBody))
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	var got []RouteExport
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("JSON mismatch (-want +got):\n%s", diff)
+	}
+}
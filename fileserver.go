@@ -0,0 +1,66 @@
+package structpages
+
+import (
+	"cmp"
+	"io/fs"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// FileServerPage serves the files of FS under the route it is mounted on,
+// so static assets can live in the page tree next to the pages using them:
+//
+//	type pages struct {
+//		Assets structpages.FileServerPage `route:"/static/ Assets"`
+//	}
+//
+//	structpages.Mount(mux, &pages{Assets: structpages.FileServerPage{FS: assets}}, "/", "App")
+//
+// The route prefix is stripped before the file is looked up, so a request for
+// /static/app.css serves app.css from FS. The route may end in a {name...}
+// wildcard instead of a trailing slash; the wildcard value is then the file
+// name. URLFor(ctx, FileServerPage{}) returns the mount path.
+type FileServerPage struct {
+	FS fs.FS
+}
+
+var fileServerPageType = reflect.TypeFor[FileServerPage]()
+
+// ServeHTTP serves the requested file from p.FS, or 404 if FS is nil.
+func (p *FileServerPage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if p.FS == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if name, ok := wildcardFileName(r); ok {
+		http.ServeFileFS(w, r, p.FS, cmp.Or(name, "."))
+		return
+	}
+	prefix, _, _ := strings.Cut(patternPath(r.Pattern), "{")
+	prefix = strings.TrimSuffix(prefix, "/")
+	http.StripPrefix(prefix, http.FileServerFS(p.FS)).ServeHTTP(w, r)
+}
+
+// wildcardFileName returns the value of the trailing {name...} wildcard of
+// the matched pattern, if it has one.
+func wildcardFileName(r *http.Request) (string, bool) {
+	path := patternPath(r.Pattern)
+	i := strings.LastIndex(path, "{")
+	if i < 0 || !strings.HasSuffix(path, "...}") {
+		return "", false
+	}
+	return r.PathValue(path[i+1 : len(path)-len("...}")]), true
+}
+
+// patternPath returns the path part of a ServeMux pattern, dropping the
+// method and host.
+func patternPath(pattern string) string {
+	if _, rest, ok := strings.Cut(pattern, " "); ok {
+		pattern = strings.TrimSpace(rest)
+	}
+	if i := strings.Index(pattern, "/"); i > 0 {
+		pattern = pattern[i:]
+	}
+	return pattern
+}
@@ -0,0 +1,78 @@
+package structpages
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+//go:embed testdata/fileserver
+var fileServerTestFS embed.FS
+
+type fileServerPages struct {
+	Assets FileServerPage `route:"/static/ Assets"`
+	Files  FileServerPage `route:"GET /files/{path...} Files"`
+	Empty  FileServerPage `route:"/empty/ Empty"`
+}
+
+func TestFileServerPage(t *testing.T) {
+	fsys, err := fs.Sub(fileServerTestFS, "testdata/fileserver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	_, err = Mount(mux, &fileServerPages{
+		Assets: FileServerPage{FS: fsys},
+		Files:  FileServerPage{FS: fsys},
+	}, "/", "App")
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+
+	tests := []struct {
+		path string
+		code int
+	}{
+		{"/static/app.css", http.StatusOK},
+		{"/files/app.css", http.StatusOK},
+		{"/static/missing.css", http.StatusNotFound},
+		{"/empty/app.css", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+			if rec.Code != tt.code {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.code)
+			}
+			if tt.code != http.StatusOK {
+				return
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "text/css; charset=utf-8" {
+				t.Errorf("Content-Type = %q, want text/css", ct)
+			}
+			if got := rec.Body.String(); got != "body { color: red; }\n" {
+				t.Errorf("body = %q", got)
+			}
+		})
+	}
+
+}
+
+func TestFileServerPage_URLFor(t *testing.T) {
+	sp, err := Mount(http.NewServeMux(), &struct {
+		Assets FileServerPage `route:"/static/ Assets"`
+	}{Assets: FileServerPage{FS: fileServerTestFS}}, "/", "App")
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	url, err := sp.URLFor(FileServerPage{})
+	if err != nil {
+		t.Fatalf("URLFor: %v", err)
+	}
+	if url != "/static/" {
+		t.Errorf("URLFor = %q, want /static/", url)
+	}
+}
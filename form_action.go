@@ -0,0 +1,98 @@
+package structpages
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// FormAction is the action URL and method of a form submitting to a page.
+type FormAction struct {
+	URL    string
+	Method string
+}
+
+// FormActionFor returns the URL of page, built as URLFor builds it, and the
+// HTTP method its route is registered for:
+//
+//	fa, err := structpages.FormActionFor(ctx, CreateUserPage{}, http.MethodPost)
+//	<form method={ fa.Method } action={ fa.URL }>
+//
+// For a route registered without a method the form uses method, or POST if
+// method is empty. For a route with a method, method must be empty or
+// match it.
+func FormActionFor(ctx context.Context, page any, method string, args ...any) (FormAction, error) {
+	pc := pcCtx.Value(ctx)
+	if pc == nil {
+		return FormAction{}, errors.New("parse context not found in context")
+	}
+	url, err := URLFor(ctx, page, args...)
+	if err != nil {
+		return FormAction{}, err
+	}
+	routeMethod, err := pc.routeMethod(page)
+	if err != nil {
+		return FormAction{}, err
+	}
+	method = strings.ToUpper(method)
+	if routeMethod == methodAll {
+		if method == "" {
+			method = http.MethodPost
+		}
+		return FormAction{URL: url, Method: method}, nil
+	}
+	if method != "" && method != routeMethod {
+		return FormAction{}, fmt.Errorf("form action %s: route is registered for %s, not %s", url, routeMethod, method)
+	}
+	return FormAction{URL: url, Method: routeMethod}, nil
+}
+
+// MustFormAction is like FormActionFor but panics if the form action can't
+// be built.
+func MustFormAction(ctx context.Context, page any, method string, args ...any) FormAction {
+	fa, err := FormActionFor(ctx, page, method, args...)
+	if err != nil {
+		panic(fmt.Sprintf("structpages: FormActionFor(%s): %v", describeRef(page), err))
+	}
+	return fa
+}
+
+// routeMethod returns the method of the route page resolves to, as
+// resolveParts resolves it: "ALL" for a route registered without one.
+func (p *parseContext) routeMethod(page any) (string, error) {
+	if s, ok := page.(string); ok {
+		page = Ref(s)
+	}
+	parts, ok := page.([]any)
+	if !ok {
+		parts = []any{page}
+	}
+	var chain []any
+	for _, part := range parts {
+		if _, isString := part.(string); isString {
+			break
+		}
+		chain = append(chain, part)
+	}
+	if len(chain) == 0 {
+		return methodAll, nil
+	}
+	if ref, ok := chain[0].(Ref); ok && len(chain) == 1 {
+		p.routesMu.RLock()
+		method, ok := p.routes[string(ref)]
+		p.routesMu.RUnlock()
+		if ok {
+			return method, nil
+		}
+	}
+	node, err := p.resolveChain(chain)
+	if err != nil {
+		return "", err
+	}
+	if len(chain) == len(parts) {
+		node = node.urlTarget()
+	}
+	return node.Method, nil
+}
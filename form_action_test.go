@@ -0,0 +1,65 @@
+package structpages
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+type formEditPage struct{}
+
+func (formEditPage) Page() component { return testComponent{"edit"} }
+
+type formCreatePage struct{}
+
+func (formCreatePage) Page() component { return testComponent{"create"} }
+
+type formSearchPage struct{}
+
+func (formSearchPage) Page() component { return testComponent{"search"} }
+
+type formActionPages struct {
+	Edit   formEditPage   `route:"GET /users/{id}/edit Edit"`
+	Create formCreatePage `route:"POST /users Create"`
+	Search formSearchPage `route:"/search Search"`
+}
+
+func TestFormActionFor(t *testing.T) {
+	sp, err := Parse(&formActionPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	ctx := sp.PageContext(context.Background())
+	tests := []struct {
+		name   string
+		page   any
+		method string
+		args   []any
+		want   FormAction
+	}{
+		{"GET route", formEditPage{}, "", []any{42}, FormAction{"/users/42/edit", http.MethodGet}},
+		{"POST route", formCreatePage{}, http.MethodPost, nil, FormAction{"/users", http.MethodPost}},
+		{"ALL route", formSearchPage{}, "", nil, FormAction{"/search", http.MethodPost}},
+		{"ALL route with method", Ref("Search"), "get", nil, FormAction{"/search", http.MethodGet}},
+		{"query fragment", []any{formSearchPage{}, "?q={q}"}, "", []any{map[string]any{"q": "go"}},
+			FormAction{"/search?q=go", http.MethodPost}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormActionFor(ctx, tt.page, tt.method, tt.args...)
+			if err != nil || got != tt.want {
+				t.Errorf("FormActionFor = %+v, %v; want %+v", got, err, tt.want)
+			}
+		})
+	}
+
+	if _, err := FormActionFor(ctx, formCreatePage{}, http.MethodGet); err == nil {
+		t.Error("FormActionFor with a method the route isn't registered for: want an error")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("MustFormAction for an unmounted page did not panic")
+		}
+	}()
+	MustFormAction(ctx, testComponent{}, "")
+}
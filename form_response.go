@@ -0,0 +1,72 @@
+package structpages
+
+import (
+	"context"
+
+	"github.com/jackielii/ctxkey"
+)
+
+// FormErrors holds validation messages by form field name, as given to
+// FormResponse. The zero value has no errors.
+type FormErrors map[string][]string
+
+// Get returns the messages for field.
+func (e FormErrors) Get(field string) []string { return e[field] }
+
+// First returns the first message for field, or "".
+func (e FormErrors) First(field string) string {
+	if msgs := e[field]; len(msgs) > 0 {
+		return msgs[0]
+	}
+	return ""
+}
+
+// Has reports whether field has any messages.
+func (e FormErrors) Has(field string) bool { return len(e[field]) > 0 }
+
+var formErrorsCtx = ctxkey.New[FormErrors]("structpages.formErrors", nil)
+
+// CurrentFormErrors returns the FormErrors of the FormResponse being
+// rendered, or nil outside one. A templ component can read the messages
+// from its ctx this way when it was built before FormResponse was called.
+func CurrentFormErrors(ctx context.Context) FormErrors {
+	return formErrorsCtx.Value(ctx)
+}
+
+// FormResponse is RenderComponent for a form re-rendered with its
+// validation errors, typically in answer to an HTMX submit. target is
+// anything RenderComponent accepts without arguments; errs reaches it as a
+// FormErrors value — injected by type into a component method, passed as
+// the only argument to a component function, and available to every
+// component through CurrentFormErrors:
+//
+//	func (p signup) Props(r *http.Request, target structpages.RenderTarget) (SignupProps, error) {
+//	    if r.Method == http.MethodPost {
+//	        if errs := validate(r.Form); len(errs) > 0 {
+//	            return SignupProps{}, structpages.FormResponse(p.Form, errs)
+//	        }
+//	    }
+//	    return SignupProps{}, nil
+//	}
+//
+//	func (p signup) Form(errs structpages.FormErrors) component { ... }
+//
+// An empty errs renders the form as if it had no errors.
+func FormResponse(target any, errs map[string][]string) error {
+	formErrors := FormErrors(errs)
+	if formErrors == nil {
+		formErrors = FormErrors{}
+	}
+	var args []any
+	switch target.(type) {
+	case component, componentGetter:
+	default:
+		args = []any{formErrors}
+	}
+	op, err := resolveRenderOp(target, args)
+	if err != nil {
+		return err
+	}
+	op.formErrors = formErrors
+	return &errRenderComponent{op: op}
+}
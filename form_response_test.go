@@ -0,0 +1,125 @@
+package structpages
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// formErrorsComponent renders the errors it reads from ctx, like a templ
+// component built before FormResponse was called.
+type formErrorsComponent struct{}
+
+func (formErrorsComponent) Render(ctx context.Context, w io.Writer) error {
+	_, err := io.WriteString(w, renderFormErrors(CurrentFormErrors(ctx)))
+	return err
+}
+
+func renderFormErrors(errs FormErrors) string {
+	var sb strings.Builder
+	sb.WriteString("<form>")
+	for _, field := range []string{"email", "password"} {
+		if errs.Has(field) {
+			fmt.Fprintf(&sb, `<p class="error">%s: %s (%d)</p>`, field, errs.First(field), len(errs.Get(field)))
+		}
+	}
+	sb.WriteString("</form>")
+	return sb.String()
+}
+
+func signupForm(errs FormErrors) component { return testComponent{"func " + renderFormErrors(errs)} }
+
+type signupPage struct{}
+
+func (p signupPage) Props(r *http.Request) (string, error) {
+	if r.Method != http.MethodPost {
+		return "", nil
+	}
+	errs := map[string][]string{}
+	if !strings.Contains(r.FormValue("email"), "@") {
+		errs["email"] = append(errs["email"], "invalid address")
+	}
+	if pw := r.FormValue("password"); len(pw) < 8 {
+		errs["password"] = append(errs["password"], "too short")
+		if strings.ToLower(pw) == pw {
+			errs["password"] = append(errs["password"], "needs an upper-case letter")
+		}
+	}
+	switch r.URL.Query().Get("via") {
+	case "ctx":
+		return "", FormResponse(formErrorsComponent{}, errs)
+	case "func":
+		return "", FormResponse(signupForm, errs)
+	}
+	return "", FormResponse(p.Form, errs)
+}
+
+func (signupPage) Page() component { return testComponent{"full page"} }
+
+func (signupPage) Form(errs FormErrors) component { return testComponent{renderFormErrors(errs)} }
+
+type signupPages struct {
+	Signup signupPage `route:"/signup Signup"`
+}
+
+func TestFormResponse(t *testing.T) {
+	mux := http.NewServeMux()
+	if _, err := Mount(mux, &signupPages{}, "/", "App"); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	const valid, bad = "email=a@b.c&password=Secret123", "email=nope&password=short"
+	tests := []struct {
+		name, query, form, want string
+	}{
+		{"no errors", "", valid, "<form></form>"},
+		{
+			"validation errors", "", "email=nope&password=Secret123",
+			`<form><p class="error">email: invalid address (1)</p></form>`,
+		},
+		{
+			"multiple errors per field", "", bad,
+			`<form><p class="error">email: invalid address (1)</p><p class="error">password: too short (2)</p></form>`,
+		},
+		{
+			"errors from context", "?via=ctx", "email=nope&password=Secret123",
+			`<form><p class="error">email: invalid address (1)</p></form>`,
+		},
+		{"empty errors from context", "?via=ctx", valid, "<form></form>"},
+		{
+			"component function", "?via=func", "email=a@b.c&password=short1",
+			`func <form><p class="error">password: too short (2)</p></form>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/signup"+tt.query, strings.NewReader(tt.form))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+			if rec.Code != http.StatusOK || rec.Body.String() != tt.want {
+				t.Errorf("got %d %q, want %q", rec.Code, rec.Body.String(), tt.want)
+			}
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/signup", http.NoBody))
+	if rec.Body.String() != "full page" {
+		t.Errorf("GET body = %q, want full page", rec.Body.String())
+	}
+}
+
+func TestFormErrors(t *testing.T) {
+	var none FormErrors
+	if none.Has("email") || none.First("email") != "" || none.Get("email") != nil {
+		t.Error("nil FormErrors reports errors")
+	}
+	errs := FormErrors{"email": {"required", "invalid"}}
+	if !errs.Has("email") || errs.First("email") != "required" || len(errs.Get("email")) != 2 {
+		t.Errorf("FormErrors accessors = %v %q %v", errs.Has("email"), errs.First("email"), errs.Get("email"))
+	}
+}
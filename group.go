@@ -0,0 +1,49 @@
+package structpages
+
+import (
+	"errors"
+	"slices"
+)
+
+// Group returns a StructPages for mounting a separate page tree under
+// prefix. It is built from the same options as sp — error handler,
+// middlewares, target selector and so on, plus the DI args and error page sp
+// has at the time of the call — followed by opts, so middlewares added with
+// WithMiddlewares in opts run after sp's. Routes and URLFor results carry
+// sp's route prefix followed by prefix.
+//
+// The group has no page tree until MountPage:
+//
+//	admin := sp.Group("/admin", structpages.WithMiddlewares(requireAuth))
+//	if err := admin.MountPage(mux, adminPages{}, "/", "Admin"); err != nil {
+//	    return err
+//	}
+//	admin.URLFor(usersPage{}) // "/admin/users"
+//
+// Groups nest: admin.Group("/reports") serves under /admin/reports.
+func (sp *StructPages) Group(prefix string, opts ...Option) *StructPages {
+	inherit := func(r *StructPages) {
+		r.args = slices.Clone(sp.args) // includes args added with AddArg
+		r.components = sp.components
+		r.errorPage = sp.errorPage
+		r.contextFuncs.add(sp.contextFuncs.list()...)
+		// The default page belongs to sp's tree, not the group's.
+		r.defaultPage, r.rootRedirect = nil, false
+		r.routePrefix += prefix
+	}
+	return configure(slices.Concat(sp.options, []Option{inherit}, opts))
+}
+
+// MountPage parses page as the root of sp's page tree and registers it onto
+// mux, like the package-level Mount with the options sp was built with. It
+// is how a Group is given its pages, and fails if sp already has a tree.
+func (sp *StructPages) MountPage(mux Mux, page any, route, title string) error {
+	sp.checkWritable()
+	if sp.pc != nil {
+		return errors.New("structpages: MountPage called on a StructPages that already has a page tree")
+	}
+	if err := sp.parse(page, route, title); err != nil {
+		return err
+	}
+	return sp.Mount(mux)
+}
@@ -0,0 +1,110 @@
+package structpages
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type groupDB struct{ name string }
+
+type groupHome struct{}
+
+func (groupHome) Page() component { return testComponent{"home"} }
+
+type groupUsers struct{}
+
+func (groupUsers) Props(r *http.Request, db *groupDB) (string, error) {
+	return "users from " + db.name + " via " + r.Header.Get("X-Trace"), nil
+}
+
+func (groupUsers) Page(s string) component { return testComponent{s} }
+
+type groupPublicPages struct {
+	groupHome `route:"/{$} Home"`
+}
+
+type groupAdminPages struct {
+	groupUsers `route:"/users Users"`
+}
+
+// traceMiddleware appends name to the X-Trace request header, recording the
+// order middlewares run in.
+func traceMiddleware(name string) MiddlewareFunc {
+	return func(next http.Handler, _ *PageNode) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r.Header.Set("X-Trace", strings.TrimPrefix(r.Header.Get("X-Trace")+","+name, ","))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func TestGroup(t *testing.T) {
+	mux := http.NewServeMux()
+	sp, err := Mount(mux, &groupPublicPages{}, "/", "App",
+		WithArgs(&groupDB{name: "main"}), WithMiddlewares(traceMiddleware("global")))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	admin := sp.Group("/admin", WithMiddlewares(traceMiddleware("admin")))
+	if err := admin.MountPage(mux, &groupAdminPages{}, "/", "Admin"); err != nil {
+		t.Fatalf("MountPage: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/users", http.NoBody))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	if got, want := rec.Body.String(), "users from main via global,admin"; got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users", http.NoBody))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("unprefixed route status = %d, want 404", rec.Code)
+	}
+
+	if got, err := admin.URLFor(groupUsers{}); err != nil || got != "/admin/users" {
+		t.Errorf("admin.URLFor = %q, %v; want /admin/users", got, err)
+	}
+	if got, err := sp.URLFor(groupHome{}); err != nil || got != "/" {
+		t.Errorf("sp.URLFor = %q, %v; want /", got, err)
+	}
+}
+
+func TestGroup_Nested(t *testing.T) {
+	mux := http.NewServeMux()
+	sp, err := Mount(mux, &groupPublicPages{}, "/", "App", WithRoutePrefix("/v1"))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	// args added after Mount are shared too
+	if err := sp.AddArg(&groupDB{name: "late"}); err != nil {
+		t.Fatalf("AddArg: %v", err)
+	}
+	reports := sp.Group("/admin").Group("/reports")
+	if err := reports.MountPage(mux, &groupAdminPages{}, "/", "Reports"); err != nil {
+		t.Fatalf("MountPage: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/admin/reports/users", http.NoBody))
+	if got, want := rec.Body.String(), "users from late via "; got != want {
+		t.Errorf("body = %q, want %q (status %d)", got, want, rec.Code)
+	}
+	if got, _ := reports.URLFor(groupUsers{}); got != "/v1/admin/reports/users" {
+		t.Errorf("URLFor = %q, want /v1/admin/reports/users", got)
+	}
+}
+
+func TestGroup_MountPageTwice(t *testing.T) {
+	g := (&StructPages{}).Group("/admin")
+	if err := g.MountPage(http.NewServeMux(), &groupAdminPages{}, "/", "Admin"); err != nil {
+		t.Fatalf("MountPage: %v", err)
+	}
+	if err := g.MountPage(http.NewServeMux(), &groupAdminPages{}, "/", "Admin"); err == nil {
+		t.Error("second MountPage succeeded, want error")
+	}
+}
@@ -0,0 +1,89 @@
+package structpages
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// headWriter answers a HEAD request with the headers and status a GET would
+// produce, discarding the body. The status is held back until the handler
+// returns so Content-Length can be set to the number of bytes the body would
+// have had.
+type headWriter struct {
+	http.ResponseWriter
+	status      int
+	written     int64
+	wroteHeader bool
+}
+
+func (w *headWriter) Write(b []byte) (int, error) {
+	if w.written == 0 && len(b) > 0 && !w.wroteHeader {
+		// Sniff like net/http would for the GET body.
+		if h := w.ResponseWriter.Header(); h.Get("Content-Type") == "" {
+			h.Set("Content-Type", http.DetectContentType(b))
+		}
+	}
+	w.written += int64(len(b))
+	return len(b), nil
+}
+
+func (w *headWriter) WriteHeader(statusCode int) {
+	if w.status == 0 {
+		w.status = statusCode
+	}
+}
+
+// Flush sends the headers early (e.g. for streaming handlers); the
+// Content-Length is then unknown and left unset.
+func (w *headWriter) Flush() {
+	w.sendHeader(false)
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the underlying ResponseWriter for http.ResponseController.
+func (w *headWriter) Unwrap() http.ResponseWriter { return w.ResponseWriter }
+
+// Push forwards an HTTP/2 server push to the underlying ResponseWriter.
+func (w *headWriter) Push(target string, opts *http.PushOptions) error {
+	return push(w.ResponseWriter, target, opts)
+}
+
+func (w *headWriter) sendHeader(setLength bool) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	status := w.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	h := w.ResponseWriter.Header()
+	if setLength && h.Get("Content-Length") == "" && bodyAllowed(status) {
+		h.Set("Content-Length", strconv.FormatInt(w.written, 10))
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// bodyAllowed reports whether a response with the given status may carry a
+// body, and therefore a meaningful Content-Length (RFC 9110, section 8.6).
+func bodyAllowed(status int) bool {
+	return status >= 200 && status != http.StatusNoContent && status != http.StatusNotModified
+}
+
+// withHEAD serves HEAD requests that reach a GET (or method-less) handler.
+// http.ServeMux already routes HEAD to "GET /path" patterns, so no separate
+// "HEAD /path" registration is needed (and one would conflict with sibling
+// GET routes such as "/users/{id}" next to "/users/new").
+func withHEAD(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+		hw := &headWriter{ResponseWriter: w}
+		next.ServeHTTP(hw, r)
+		hw.sendHeader(true)
+	})
+}
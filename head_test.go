@@ -0,0 +1,146 @@
+package structpages
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+type headIndexPage struct{}
+
+func (headIndexPage) Page() component { return testComponent{"index page"} }
+
+type headPropsPage struct{}
+
+func (headPropsPage) Props(w http.ResponseWriter) (string, error) {
+	w.Header().Set("X-Props", "set")
+	return "props value", nil
+}
+
+func (headPropsPage) Page(s string) component { return testComponent{s} }
+
+type headItemPage struct{}
+
+func (headItemPage) Page() component { return testComponent{"item"} }
+
+type headNewPage struct{}
+
+func (headNewPage) Page() component { return testComponent{"new"} }
+
+type headAllPage struct{}
+
+func (headAllPage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Method", r.Method)
+	_, _ = w.Write([]byte("all methods"))
+}
+
+type headPostPage struct{}
+
+func (headPostPage) Page() component { return testComponent{"post"} }
+
+type headPages struct {
+	Index headIndexPage `route:"/{$} Index"`
+	Props headPropsPage `route:"/props Props"`
+	Item  headItemPage  `route:"/items/{id} Item"`
+	New   headNewPage   `route:"/items/new New"`
+	All   headAllPage   `route:"ALL /all All"`
+	Post  headPostPage  `route:"POST /post Post"`
+}
+
+func TestHEAD(t *testing.T) {
+	mux := http.NewServeMux()
+	if _, err := Mount(mux, &headPages{}, "/", "App"); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+
+	for _, path := range []string{"/", "/props", "/items/1", "/items/new", "/all"} {
+		t.Run(path, func(t *testing.T) {
+			get := httptest.NewRecorder()
+			mux.ServeHTTP(get, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+			if get.Code != http.StatusOK || get.Body.Len() == 0 {
+				t.Fatalf("GET %s = %d %q, want 200 with a body", path, get.Code, get.Body.String())
+			}
+
+			head := httptest.NewRecorder()
+			mux.ServeHTTP(head, httptest.NewRequest(http.MethodHead, path, http.NoBody))
+			if head.Code != get.Code {
+				t.Errorf("HEAD status = %d, want %d", head.Code, get.Code)
+			}
+			if head.Body.Len() != 0 {
+				t.Errorf("HEAD body = %q, want empty", head.Body.String())
+			}
+			if want := strconv.Itoa(get.Body.Len()); head.Header().Get("Content-Length") != want {
+				t.Errorf("HEAD Content-Length = %q, want %q", head.Header().Get("Content-Length"), want)
+			}
+			for k := range get.Header() {
+				if k == "X-Method" {
+					continue
+				}
+				if head.Header().Get(k) != get.Header().Get(k) {
+					t.Errorf("HEAD header %s = %q, want %q", k, head.Header().Get(k), get.Header().Get(k))
+				}
+			}
+		})
+	}
+
+	t.Run("props headers", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodHead, "/props", http.NoBody))
+		if got := rec.Header().Get("X-Props"); got != "set" {
+			t.Errorf("X-Props = %q, want %q", got, "set")
+		}
+	})
+
+	t.Run("non-GET routes do not answer HEAD", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodHead, "/post", http.NoBody))
+		if rec.Code != http.StatusMethodNotAllowed {
+			t.Errorf("HEAD /post status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+type patternRecorder struct {
+	*http.ServeMux
+	patterns []string
+}
+
+func (m *patternRecorder) Handle(pattern string, h http.Handler) {
+	m.patterns = append(m.patterns, pattern)
+	m.ServeMux.Handle(pattern, h)
+}
+
+// HEAD is served by the GET (or method-less) pattern itself, so no extra
+// pattern is registered; a "HEAD /items/{id}" pattern would conflict with
+// "GET /items/new".
+func TestHEAD_NoExtraRegistration(t *testing.T) {
+	mux := &patternRecorder{ServeMux: http.NewServeMux()}
+	if _, err := Mount(mux, &headPages{}, "/", "App"); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	seen := map[string]int{}
+	for _, p := range mux.patterns {
+		seen[p]++
+		if len(p) > 5 && p[:5] == "HEAD " {
+			t.Errorf("unexpected HEAD pattern %q", p)
+		}
+	}
+	if seen["/all"] != 1 {
+		t.Errorf("ALL route registered %d times, want 1 (patterns: %v)", seen["/all"], mux.patterns)
+	}
+}
+
+func TestHeadWriter_StatusWithoutBody(t *testing.T) {
+	h := withHEAD(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodHead, "/", http.NoBody))
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	if got := rec.Header().Get("Content-Length"); got != "" {
+		t.Errorf("Content-Length = %q, want unset for 204", got)
+	}
+}
@@ -1,7 +1,9 @@
 package structpages
 
 import (
+	"fmt"
 	"net/http"
+	"reflect"
 	"strings"
 )
 
@@ -13,7 +15,14 @@ import (
 //   - HX-Target: "content" -> returns methodRenderTarget for Content() method
 //   - HX-Target: "index-page-todo-list" -> returns methodRenderTarget for TodoList() method
 //   - HX-Target: "user-stats-widget" (no method match) -> returns functionRenderTarget for lazy evaluation
-//   - No HX-Target or non-HTMX request -> returns methodRenderTarget for Page() method
+//   - No HX-Target -> returns the fallback chain's first component, else Page()
+//     (see [WithComponentFallbackChain])
+//   - Non-HTMX request -> returns methodRenderTarget for Page() method
+//   - HX-History-Restore-Request: true -> returns methodRenderTarget for Page() method,
+//     since the response replaces the whole document (see [WithHTMXHistoryEnabled])
+//   - HX-Boosted: true -> returns methodRenderTarget for Page() method
+//
+// The header names can be changed with [WithHTMXConfig].
 //
 // This selector works with htmx 1.x and 2.x, where HX-Target carries the bare
 // element id. For htmx 4, use [HTMXv4RenderTarget] instead.
@@ -21,11 +30,18 @@ import (
 // This is the default TargetSelector for StructPages, making IDFor work
 // seamlessly with HTMX out of the box.
 func HTMXRenderTarget(r *http.Request, pn *PageNode) (RenderTarget, error) {
-	if r.Header.Get("HX-Request") == "true" {
-		hxTarget := r.Header.Get("HX-Target")
+	pc := pcCtx.Value(r.Context())
+	cfg := htmxConfig(pc)
+	if r.Header.Get(cfg.RequestHeader) == "true" && r.Header.Get(cfg.BoostHeader) != "true" {
+		if r.Header.Get(cfg.HistoryRestoreHeader) == "true" {
+			if target, ok, err := historyRestoreTarget(r, pn, pc); ok || err != nil {
+				return target, err
+			}
+		}
+		hxTarget := r.Header.Get(cfg.TargetHeader)
 		if hxTarget != "" {
 			// Try to match against registered method components
-			componentName := matchComponentByTarget(hxTarget, pn, pcCtx.Value(r.Context()))
+			componentName := matchComponentByTarget(hxTarget, pn, pc)
 			if componentName != "" {
 				method := pn.Components[componentName]
 				return newMethodRenderTarget(componentName, &method), nil
@@ -33,8 +49,11 @@ func HTMXRenderTarget(r *http.Request, pn *PageNode) (RenderTarget, error) {
 
 			// No method match - assume it's a standalone function
 			// Store raw hxTarget for lazy evaluation in Is()
-			return newFunctionRenderTarget(hxTarget, pn.Name), nil
+			if !cfg.AllowFallback {
+				return newFunctionRenderTarget(hxTarget, pn.Name), nil
+			}
 		}
+		return fallbackTarget(pc, pn), nil
 	}
 
 	// Default: render "Page" component
@@ -44,6 +63,30 @@ func HTMXRenderTarget(r *http.Request, pn *PageNode) (RenderTarget, error) {
 	return newMethodRenderTarget("Page", &pageMethod), nil
 }
 
+// historyRestoreTarget picks the target for an HX-History-Restore-Request.
+// A page's HXHistoryRestore method wins when present and returns non-nil;
+// otherwise the full Page is selected unless WithHTMXHistoryEnabled(false)
+// turned the check off. ok is false when normal HX-Target matching should
+// proceed.
+func historyRestoreTarget(r *http.Request, pn *PageNode, pc *parseContext) (RenderTarget, bool, error) {
+	if pn.hxHistoryRestore != nil && pc != nil {
+		res, err := pc.callMethod(pn, pn.hxHistoryRestore, reflect.ValueOf(r))
+		if err != nil {
+			return nil, false, fmt.Errorf("error calling HXHistoryRestore method on %s: %w", pn.Name, err)
+		}
+		if len(res) == 1 {
+			if target, isTarget := res[0].Interface().(RenderTarget); isTarget && target != nil {
+				return target, true, nil
+			}
+		}
+	}
+	if pc != nil && pc.htmxHistoryDisabled {
+		return nil, false, nil
+	}
+	pageMethod := pn.Components["Page"]
+	return newMethodRenderTarget("Page", &pageMethod), true, nil
+}
+
 // HTMXv4RenderTarget is the htmx 4 variant of [HTMXRenderTarget].
 //
 // htmx 4 reshaped two request headers we care about:
@@ -73,15 +116,20 @@ func HTMXRenderTarget(r *http.Request, pn *PageNode) (RenderTarget, error) {
 //	sp, err := structpages.Mount(mux, root{}, "/", "App",
 //	    structpages.WithTargetSelector(structpages.HTMXv4RenderTarget))
 func HTMXv4RenderTarget(r *http.Request, pn *PageNode) (RenderTarget, error) {
-	if r.Header.Get("HX-Request") == "true" &&
+	pc := pcCtx.Value(r.Context())
+	cfg := htmxConfig(pc)
+	if r.Header.Get(cfg.RequestHeader) == "true" &&
 		r.Header.Get("HX-Request-Type") != "full" {
-		if key := htmxv4TargetKey(r.Header.Get("HX-Target")); key != "" {
-			if componentName := matchComponentByTarget(key, pn, pcCtx.Value(r.Context())); componentName != "" {
+		if key := htmxv4TargetKey(r.Header.Get(cfg.TargetHeader)); key != "" {
+			if componentName := matchComponentByTarget(key, pn, pc); componentName != "" {
 				method := pn.Components[componentName]
 				return newMethodRenderTarget(componentName, &method), nil
 			}
-			return newFunctionRenderTarget(key, pn.Name), nil
+			if !cfg.AllowFallback {
+				return newFunctionRenderTarget(key, pn.Name), nil
+			}
 		}
+		return fallbackTarget(pc, pn), nil
 	}
 
 	pageMethod := pn.Components["Page"]
@@ -0,0 +1,54 @@
+package structpages
+
+import "cmp"
+
+// HTMXConfig holds the request header names the HTMX target selectors read,
+// and how they treat targets that match no component. Empty header names
+// fall back to the htmx defaults, so the zero value is the stock behavior.
+type HTMXConfig struct {
+	// RequestHeader marks an HTMX request when set to "true".
+	// Default "HX-Request".
+	RequestHeader string
+	// TargetHeader carries the id of the element being swapped.
+	// Default "HX-Target".
+	TargetHeader string
+	// BoostHeader marks a boosted link or form when set to "true"; boosted
+	// requests always render the full Page. Default "HX-Boosted".
+	BoostHeader string
+	// HistoryRestoreHeader marks a history-restore request when set to
+	// "true" (see WithHTMXHistoryEnabled). Default
+	// "HX-History-Restore-Request".
+	HistoryRestoreHeader string
+	// AllowFallback selects the Page component when the target header
+	// matches no component method, so Props sees target.Is(Page) rather
+	// than a standalone function target it must handle itself.
+	AllowFallback bool
+}
+
+func (c HTMXConfig) withDefaults() HTMXConfig {
+	c.RequestHeader = cmp.Or(c.RequestHeader, "HX-Request")
+	c.TargetHeader = cmp.Or(c.TargetHeader, "HX-Target")
+	c.BoostHeader = cmp.Or(c.BoostHeader, "HX-Boosted")
+	c.HistoryRestoreHeader = cmp.Or(c.HistoryRestoreHeader, "HX-History-Restore-Request")
+	return c
+}
+
+// htmxConfig returns the configuration of pc, or the defaults when the
+// selector runs without a parse context.
+func htmxConfig(pc *parseContext) HTMXConfig {
+	if pc == nil {
+		return HTMXConfig{}.withDefaults()
+	}
+	return pc.htmx
+}
+
+// WithHTMXConfig sets the header names and fallback behavior used by
+// HTMXRenderTarget and HTMXv4RenderTarget, e.g. to adapt to a proxy that
+// renames headers or to test HTMX handling with custom names:
+//
+//	structpages.WithHTMXConfig(structpages.HTMXConfig{RequestHeader: "X-Partial"})
+func WithHTMXConfig(cfg HTMXConfig) func(*StructPages) {
+	return func(r *StructPages) {
+		r.htmxConfig = cfg
+	}
+}
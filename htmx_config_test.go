@@ -0,0 +1,106 @@
+package structpages
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type fallbackTargetPage struct{}
+
+func (fallbackTargetPage) Props(target RenderTarget) (string, error) {
+	if target.Is(fallbackTargetPage.Page) {
+		return "page target", nil
+	}
+	return "other target", nil
+}
+
+func (fallbackTargetPage) Page(s string) component { return testComponent{s} }
+
+func TestHTMXConfig_AllowFallback(t *testing.T) {
+	for allow, want := range map[bool]string{false: "other target", true: "page target"} {
+		mux := http.NewServeMux()
+		_, err := Mount(mux, &struct {
+			fallbackTargetPage `route:"/ Home"`
+		}{}, "/", "App", WithHTMXConfig(HTMXConfig{AllowFallback: allow}))
+		if err != nil {
+			t.Fatalf("Mount: %v", err)
+		}
+		req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+		req.Header.Set("HX-Request", "true")
+		req.Header.Set("HX-Target", "unknown-widget")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		if rec.Body.String() != want {
+			t.Errorf("AllowFallback=%v: body = %q, want %q", allow, rec.Body.String(), want)
+		}
+	}
+}
+
+func TestWithHTMXConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector TargetSelector
+		cfg      HTMXConfig
+		headers  map[string]string
+		want     string
+	}{
+		{
+			name:    "custom request header",
+			cfg:     HTMXConfig{RequestHeader: "X-Partial"},
+			headers: map[string]string{"X-Partial": "true", "HX-Target": "partial"},
+			want:    "partial",
+		},
+		{
+			name:    "default request header ignored once overridden",
+			cfg:     HTMXConfig{RequestHeader: "X-Partial"},
+			headers: map[string]string{"HX-Request": "true", "HX-Target": "partial"},
+			want:    "index",
+		},
+		{
+			name:    "custom target header",
+			cfg:     HTMXConfig{TargetHeader: "X-Swap"},
+			headers: map[string]string{"HX-Request": "true", "X-Swap": "partial"},
+			want:    "partial",
+		},
+		{
+			name:    "boosted renders page",
+			headers: map[string]string{"HX-Request": "true", "HX-Boosted": "true", "HX-Target": "partial"},
+			want:    "index",
+		},
+		{
+			name:    "custom history restore header",
+			cfg:     HTMXConfig{HistoryRestoreHeader: "X-Restore"},
+			headers: map[string]string{"HX-Request": "true", "X-Restore": "true", "HX-Target": "partial"},
+			want:    "index",
+		},
+		{
+			name:     "htmx 4 selector",
+			selector: HTMXv4RenderTarget,
+			cfg:      HTMXConfig{RequestHeader: "X-Partial"},
+			headers:  map[string]string{"X-Partial": "true", "HX-Target": "div#partial"},
+			want:     "partial",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []Option{WithHTMXConfig(tt.cfg)}
+			if tt.selector != nil {
+				opts = append(opts, WithTargetSelector(tt.selector))
+			}
+			mux := http.NewServeMux()
+			if _, err := Mount(mux, &headersPages{}, "/", "App", opts...); err != nil {
+				t.Fatalf("Mount: %v", err)
+			}
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+			if rec.Code != http.StatusOK || rec.Body.String() != tt.want {
+				t.Errorf("got %d %q, want 200 %q", rec.Code, rec.Body.String(), tt.want)
+			}
+		})
+	}
+}
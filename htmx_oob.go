@@ -0,0 +1,46 @@
+package structpages
+
+import (
+	"context"
+	"html"
+	"io"
+)
+
+// HxOOBComponent renders Inner inside a div that HTMX swaps out of band:
+// whatever the request targets, HTMX swaps the div into the element with
+// the same ID, using Swap as the hx-swap-oob strategy ("true" when empty,
+// which is outerHTML). Return it alongside the main content to update
+// other parts of the page from one response; structpages writes the
+// markup through untouched.
+type HxOOBComponent struct {
+	ID    string
+	Swap  string
+	Inner Component
+}
+
+// HxOOBWrap returns comp wrapped for an out-of-band swap into the element
+// with ID target:
+//
+//	<div id="target" hx-swap-oob="swap">comp</div>
+func HxOOBWrap(target, swap string, comp Component) Component {
+	return HxOOBComponent{ID: target, Swap: swap, Inner: comp}
+}
+
+// Render implements the component interface.
+func (c HxOOBComponent) Render(ctx context.Context, w io.Writer) error {
+	swap := c.Swap
+	if swap == "" {
+		swap = "true"
+	}
+	_, err := io.WriteString(w, `<div id="`+html.EscapeString(c.ID)+`" hx-swap-oob="`+html.EscapeString(swap)+`">`)
+	if err != nil {
+		return err
+	}
+	if c.Inner != nil {
+		if err := c.Inner.Render(ctx, w); err != nil {
+			return err
+		}
+	}
+	_, err = io.WriteString(w, "</div>")
+	return err
+}
@@ -0,0 +1,79 @@
+package structpages
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHxOOBWrap(t *testing.T) {
+	tests := []struct {
+		name string
+		comp Component
+		want string
+	}{
+		{"swap", HxOOBWrap("count", "innerHTML", testComponent{"3"}),
+			`<div id="count" hx-swap-oob="innerHTML">3</div>`},
+		{"default swap", HxOOBWrap("count", "", testComponent{"3"}),
+			`<div id="count" hx-swap-oob="true">3</div>`},
+		{"escaped", HxOOBWrap(`a"b`, "beforeend:#x", nil),
+			`<div id="a&#34;b" hx-swap-oob="beforeend:#x"></div>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.comp.Render(context.Background(), &buf); err != nil {
+				t.Fatalf("Render: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("got %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+// oobList renders its components one after the other.
+type oobList []Component
+
+func (l oobList) Render(ctx context.Context, w io.Writer) error {
+	for _, c := range l {
+		if err := c.Render(ctx, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type oobPage struct{}
+
+func (oobPage) Page() component {
+	return oobList{
+		testComponent{"<li>new todo</li>"},
+		HxOOBWrap("todo-count", "innerHTML", testComponent{"4"}),
+		HxOOBComponent{ID: "flash", Inner: testComponent{"Saved"}},
+	}
+}
+
+type oobPages struct {
+	Todos oobPage `route:"/todos Todos"`
+}
+
+func TestHxOOBComponent_Served(t *testing.T) {
+	mux := http.NewServeMux()
+	if _, err := Mount(mux, oobPages{}, "/", "App"); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/todos", http.NoBody)
+	req.Header.Set("HX-Request", "true")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	want := `<li>new todo</li>` +
+		`<div id="todo-count" hx-swap-oob="innerHTML">4</div>` +
+		`<div id="flash" hx-swap-oob="true">Saved</div>`
+	if rec.Body.String() != want {
+		t.Errorf("body = %q, want %q", rec.Body.String(), want)
+	}
+}
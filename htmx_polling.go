@@ -0,0 +1,63 @@
+package structpages
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+// StatusStopPolling is the status code htmx treats as "stop polling": an
+// element polling with hx-trigger="every ..." cancels its timer when a
+// response carries it.
+const StatusStopPolling = 286
+
+var (
+	durationType   = reflect.TypeOf(time.Duration(0))
+	errStopPolling = errors.New("stop polling")
+)
+
+// HxPollingStop returns the error a Props method returns to tell a polling
+// htmx element to stop: the response is sent with status 286
+// (StatusStopPolling), an HX-Trigger of {"poll":{"stop":true}}, and no body.
+//
+//	func (p jobPage) Props(r *http.Request, jobs *Jobs) (Job, error) {
+//	    job := jobs.Get(r.PathValue("id"))
+//	    if job.Done {
+//	        return job, structpages.HxPollingStop()
+//	    }
+//	    return job, nil
+//	}
+func HxPollingStop() error { return errStopPolling }
+
+// setPollingHeader calls the page's HxPolling method, if any, and advertises
+// a positive interval in the HX-Trigger header as {"poll":{"interval":ms}}.
+// The event is merged into an HX-Trigger the page already set; a plain list
+// of event names becomes entries without detail.
+func (sp *StructPages) setPollingHeader(w http.ResponseWriter, r *http.Request, pn *PageNode) error {
+	if pn == nil || pn.hxPolling == nil {
+		return nil
+	}
+	res, err := sp.pc.callMethod(pn, pn.hxPolling, reflect.ValueOf(r), reflect.ValueOf(w))
+	if err != nil {
+		return fmt.Errorf("error calling HxPolling method on %s: %w", pn.Name, err)
+	}
+	interval := time.Duration(res[0].Int())
+	if interval <= 0 {
+		return nil
+	}
+	return setPollEvent(w, map[string]int64{"interval": interval.Milliseconds()})
+}
+
+// setPollEvent adds a poll event with detail to the HX-Trigger header,
+// merging it with whatever the page already set there.
+func setPollEvent(w http.ResponseWriter, detail any) error {
+	return setEvents(w.Header(), "HX-Trigger", []HXEvent{{Name: "poll", Payload: detail}})
+}
+
+// stopPolling answers a request whose Props returned HxPollingStop.
+func stopPolling(w http.ResponseWriter) {
+	_ = setPollEvent(w, map[string]bool{"stop": true})
+	w.WriteHeader(StatusStopPolling)
+}
@@ -0,0 +1,95 @@
+package structpages
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type pollingConfig struct{ interval time.Duration }
+
+type pollingPage struct{}
+
+func (pollingPage) Props(w http.ResponseWriter, r *http.Request) (string, error) {
+	if r.URL.Query().Has("done") {
+		return "", HxPollingStop()
+	}
+	switch r.URL.Query().Get("trigger") {
+	case "json":
+		w.Header().Set("HX-Trigger", `{"refreshed":true}`)
+	case "plain":
+		w.Header().Set("HX-Trigger", "refreshed, saved")
+	}
+	return "status", nil
+}
+
+func (pollingPage) Page(s string) component { return testComponent{s} }
+
+func (pollingPage) HxPolling(r *http.Request, cfg *pollingConfig) time.Duration {
+	if s := r.URL.Query().Get("every"); s != "" {
+		n, _ := strconv.Atoi(s)
+		return time.Duration(n) * time.Second
+	}
+	return cfg.interval
+}
+
+type pollingPages struct {
+	pollingPage `route:"/ Status"`
+}
+
+func TestHxPolling(t *testing.T) {
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &pollingPages{}, "/", "App", WithArgs(&pollingConfig{interval: 5 * time.Second}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	tests := []struct {
+		name, path  string
+		wantCode    int
+		wantTrigger string
+		wantBody    string
+	}{
+		{name: "interval", path: "/", wantCode: 200, wantTrigger: `{"poll":{"interval":5000}}`, wantBody: "status"},
+		{name: "zero duration", path: "/?every=0", wantCode: 200, wantBody: "status"},
+		{name: "dynamic", path: "/?every=30", wantCode: 200, wantTrigger: `{"poll":{"interval":30000}}`, wantBody: "status"},
+		{
+			name: "merged with page trigger", path: "/?trigger=json", wantCode: 200,
+			wantTrigger: `{"poll":{"interval":5000},"refreshed":true}`, wantBody: "status",
+		},
+		{
+			name: "merged with plain page trigger", path: "/?trigger=plain", wantCode: 200,
+			wantTrigger: `{"poll":{"interval":5000},"refreshed":null,"saved":null}`, wantBody: "status",
+		},
+		{name: "stop", path: "/?done", wantCode: StatusStopPolling, wantTrigger: `{"poll":{"stop":true}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+			if rec.Code != tt.wantCode || rec.Body.String() != tt.wantBody {
+				t.Errorf("got %d %q, want %d %q", rec.Code, rec.Body.String(), tt.wantCode, tt.wantBody)
+			}
+			if got := rec.Header().Get("HX-Trigger"); got != tt.wantTrigger {
+				t.Errorf("HX-Trigger = %q, want %q", got, tt.wantTrigger)
+			}
+		})
+	}
+}
+
+type badPollingPage struct{}
+
+func (badPollingPage) Page() component { return testComponent{"x"} }
+
+func (badPollingPage) HxPolling() int { return 5 }
+
+func TestHxPolling_BadSignature(t *testing.T) {
+	_, err := Parse(&struct {
+		Bad badPollingPage `route:"/bad Bad"`
+	}{}, "/", "App")
+	if err == nil || !strings.Contains(err.Error(), "HxPolling method on Bad must return a single time.Duration") {
+		t.Fatalf("err = %v, want HxPolling signature error", err)
+	}
+}
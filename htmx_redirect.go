@@ -0,0 +1,77 @@
+package structpages
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// hxRedirectError is returned by HXRedirect and HXLocation: header names the
+// HTMX response header carrying url.
+type hxRedirectError struct {
+	header string
+	url    string
+}
+
+func (e *hxRedirectError) Error() string {
+	return "redirect to " + e.url
+}
+
+// location returns the URL a non-HTMX client is redirected to: url itself,
+// or the path of an HX-Location JSON object.
+func (e *hxRedirectError) location() string {
+	if !strings.HasPrefix(e.url, "{") {
+		return e.url
+	}
+	var loc struct {
+		Path string `json:"path"`
+	}
+	if err := json.Unmarshal([]byte(e.url), &loc); err != nil || loc.Path == "" {
+		return e.url
+	}
+	return loc.Path
+}
+
+// HXRedirect returns the error a Props method returns to send the browser to
+// url. A plain 3xx is followed by the XHR htmx issues, so the target is
+// swapped into the element instead of replacing the page; for HTMX requests
+// the response is instead a 200 with an HX-Redirect header, which makes htmx
+// perform a full navigation. Other requests get a 302 Found.
+//
+//	func (p savePage) Props(r *http.Request, store *Store) (Item, error) {
+//	    item, err := store.Save(r)
+//	    if err != nil {
+//	        return Item{}, err
+//	    }
+//	    return item, structpages.HXRedirect("/items/" + item.ID)
+//	}
+func HXRedirect(url string) error {
+	return &hxRedirectError{header: "HX-Redirect", url: url}
+}
+
+// HXLocation is like HXRedirect but sets HX-Location, so htmx loads url with
+// an AJAX request and pushes it onto the history stack rather than reloading
+// the page. url may also be the JSON object form htmx accepts, e.g.
+// {"path":"/items","target":"#main"}. Non-HTMX requests get a 302 Found to
+// url, or to its path for the JSON form.
+func HXLocation(url string) error {
+	return &hxRedirectError{header: "HX-Location", url: url}
+}
+
+// handleRedirectError answers a request whose Props returned HXRedirect,
+// HXLocation or AbsoluteRedirect, possibly wrapped. It reports whether err
+// was such an error.
+func (sp *StructPages) handleRedirectError(w http.ResponseWriter, r *http.Request, err error) bool {
+	var redirect *hxRedirectError
+	if !errors.As(err, &redirect) {
+		return sp.handleAbsoluteRedirect(w, r, err)
+	}
+	if r.Header.Get(htmxConfig(sp.pc).RequestHeader) != "true" {
+		http.Redirect(w, r, redirect.location(), http.StatusFound)
+		return true
+	}
+	w.Header().Set(redirect.header, redirect.url)
+	w.WriteHeader(http.StatusOK)
+	return true
+}
@@ -0,0 +1,81 @@
+package structpages
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type redirectPage struct{}
+
+func (redirectPage) Props(r *http.Request, w http.ResponseWriter) (string, error) {
+	switch r.URL.Query().Get("to") {
+	case "redirect":
+		return "", HXRedirect("/done")
+	case "location":
+		return "", HXLocation(`{"path":"/done","target":"#main"}`)
+	case "wrapped":
+		return "", fmt.Errorf("saving item: %w", HXRedirect("/done"))
+	case "skip":
+		http.Redirect(w, r, "/legacy", http.StatusSeeOther)
+		return "", ErrSkipPageRender
+	}
+	return "page", nil
+}
+
+func (redirectPage) Page(s string) component { return testComponent{s} }
+
+type redirectHandlerPage struct{}
+
+func (redirectHandlerPage) ServeHTTP(w http.ResponseWriter, r *http.Request) error {
+	_, _ = w.Write([]byte("discarded"))
+	return HXLocation("/done")
+}
+
+type redirectPages struct {
+	redirectPage        `route:"/{$} Redirect"`
+	redirectHandlerPage `route:"/handler Handler"`
+}
+
+func TestHXRedirect(t *testing.T) {
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &redirectPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	tests := []struct {
+		name, path string
+		htmx       bool
+		wantCode   int
+		header     string
+		want       string
+	}{
+		{"HTMX redirect", "/?to=redirect", true, http.StatusOK, "HX-Redirect", "/done"},
+		{"non-HTMX redirect", "/?to=redirect", false, http.StatusFound, "Location", "/done"},
+		{"HTMX location", "/?to=location", true, http.StatusOK, "HX-Location", `{"path":"/done","target":"#main"}`},
+		{"non-HTMX location", "/?to=location", false, http.StatusFound, "Location", "/done"},
+		{"wrapped error", "/?to=wrapped", true, http.StatusOK, "HX-Redirect", "/done"},
+		{"ServeHTTP page", "/handler", true, http.StatusOK, "HX-Location", "/done"},
+		{"http.Redirect with ErrSkipPageRender", "/?to=skip", false, http.StatusSeeOther, "Location", "/legacy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+			if tt.htmx {
+				req.Header.Set("HX-Request", "true")
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantCode, rec.Body.String())
+			}
+			if got := rec.Header().Get(tt.header); got != tt.want {
+				t.Errorf("%s = %q, want %q", tt.header, got, tt.want)
+			}
+			if tt.htmx && rec.Body.Len() != 0 {
+				t.Errorf("body = %q, want empty", rec.Body.String())
+			}
+		})
+	}
+}
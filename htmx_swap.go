@@ -0,0 +1,36 @@
+package structpages
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// setSwapHeader calls the page's HxSwap method, if any, on HTMX requests
+// and sends a non-empty result as HX-Reswap, overriding the element's
+// hx-swap: "innerHTML", "outerHTML swap:500ms settle:100ms", and so on.
+func (sp *StructPages) setSwapHeader(w http.ResponseWriter, r *http.Request, pn *PageNode) error {
+	if pn == nil || pn.hxSwap == nil || r.Header.Get(htmxConfig(sp.pc).RequestHeader) != "true" {
+		return nil
+	}
+	res, err := sp.pc.callMethod(pn, pn.hxSwap, reflect.ValueOf(r), reflect.ValueOf(w))
+	if err != nil {
+		return fmt.Errorf("error calling HxSwap method on %s: %w", pn.Name, err)
+	}
+	if swap := res[0].String(); swap != "" {
+		w.Header().Set("HX-Reswap", swap)
+	}
+	return nil
+}
+
+// setHTMXHeaders sets the response headers derived from the page's
+// HxPolling, HXTrigger* and HxSwap methods once its component has rendered.
+func (sp *StructPages) setHTMXHeaders(w http.ResponseWriter, r *http.Request, pn *PageNode) error {
+	if err := sp.setPollingHeader(w, r, pn); err != nil {
+		return err
+	}
+	if err := sp.setTriggerHeaders(w, r, pn); err != nil {
+		return err
+	}
+	return sp.setSwapHeader(w, r, pn)
+}
@@ -0,0 +1,85 @@
+package structpages
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type swapConfig struct{ timing string }
+
+type swapPage struct{}
+
+func (swapPage) Page() component    { return testComponent{"page"} }
+func (swapPage) Content() component { return testComponent{"content"} }
+
+func (swapPage) HxSwap(r *http.Request, cfg *swapConfig) string {
+	switch r.URL.Query().Get("swap") {
+	case "timed":
+		return "outerHTML " + cfg.timing
+	case "none":
+		return ""
+	}
+	return "innerHTML"
+}
+
+type swapPages struct {
+	swapPage `route:"/ Swap"`
+}
+
+func TestHxSwap(t *testing.T) {
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &swapPages{}, "/", "App", WithArgs(&swapConfig{timing: "swap:500ms settle:100ms"}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	tests := []struct {
+		name, path string
+		htmx       bool
+		want       string
+	}{
+		{name: "innerHTML", path: "/", htmx: true, want: "innerHTML"},
+		{name: "timing from config", path: "/?swap=timed", htmx: true, want: "outerHTML swap:500ms settle:100ms"},
+		{name: "empty result", path: "/?swap=none", htmx: true},
+		{name: "non-HTMX request", path: "/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+			if tt.htmx {
+				req.Header.Set("HX-Request", "true")
+				req.Header.Set("HX-Target", "content")
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+			}
+			if got := rec.Header().Get("HX-Reswap"); got != tt.want {
+				t.Errorf("HX-Reswap = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+type badHxSwapPage struct{}
+
+func (badHxSwapPage) HxSwap() int     { return 0 }
+func (badHxSwapPage) Page() component { return testComponent{"x"} }
+
+func TestHxSwap_BadSignature(t *testing.T) {
+	_, err := Parse(&struct {
+		Bad badHxSwapPage `route:"/bad Bad"`
+	}{}, "/", "App")
+	if err == nil || !strings.Contains(err.Error(), "HxSwap method on Bad must return a single string") {
+		t.Fatalf("err = %v, want HxSwap signature error", err)
+	}
+}
+
+func TestHxSwap_ValidateArgs(t *testing.T) {
+	_, err := Validate(&swapPages{}, "/", "App")
+	if err == nil || !strings.Contains(err.Error(), "swapConfig") {
+		t.Fatalf("err = %v, want missing *swapConfig error", err)
+	}
+}
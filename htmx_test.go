@@ -18,6 +18,9 @@ func TestKebabToPascal(t *testing.T) {
 		{"todo-list", "TodoList"},
 		{"user-profile-settings", "UserProfileSettings"},
 		{"html-content", "HtmlContent"},
+		{"état-page", "ÉtatPage"},
+		{"änderungs-übersicht", "ÄnderungsÜbersicht"},
+		{"用户-list", "用户List"},
 	}
 
 	for _, tt := range tests {
@@ -311,7 +314,7 @@ type hxDeepRoot struct {
 
 // nodeByRoute walks the tree for the node at fullRoute.
 func nodeByRoute(pc *parseContext, fullRoute string) *PageNode {
-	for n := range pc.root.All() {
+	for n := range pc.root().All() {
 		if n.FullRoute() == fullRoute {
 			return n
 		}
@@ -687,3 +690,115 @@ func TestHTMXRenderTarget_Default(t *testing.T) {
 		t.Errorf("expected default selector to return Content, got %q", mrt.name)
 	}
 }
+
+type historyPage struct{}
+
+func (historyPage) Page() component    { return testComponent{"full page"} }
+func (historyPage) Content() component { return testComponent{"partial"} }
+
+type historyOverridePage struct{}
+
+func (historyOverridePage) Page() component    { return testComponent{"full page"} }
+func (historyOverridePage) Content() component { return testComponent{"partial"} }
+
+// HXHistoryRestore keeps rendering the partial for history restores that
+// carry the feed marker, and defers to the default (full page) otherwise.
+func (historyOverridePage) HXHistoryRestore(r *http.Request, pn *PageNode) RenderTarget {
+	if r.URL.Query().Get("keep") != "partial" {
+		return nil
+	}
+	method := pn.Components["Content"]
+	return newMethodRenderTarget("Content", &method)
+}
+
+type historyPages struct {
+	Plain    historyPage         `route:"/plain Plain"`
+	Override historyOverridePage `route:"/override Override"`
+}
+
+func TestHTMXRenderTarget_HistoryRestore(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		path    string
+		headers map[string]string
+		want    string
+	}{
+		{
+			name: "non-HTMX request renders full page",
+			path: "/plain",
+			want: "full page",
+		},
+		{
+			name:    "HTMX request renders partial",
+			path:    "/plain",
+			headers: map[string]string{"HX-Request": "true", "HX-Target": "content"},
+			want:    "partial",
+		},
+		{
+			name: "history restore renders full page regardless of target",
+			path: "/plain",
+			headers: map[string]string{
+				"HX-Request": "true", "HX-Target": "content", "HX-History-Restore-Request": "true",
+			},
+			want: "full page",
+		},
+		{
+			name: "history restore check disabled",
+			opts: []Option{WithHTMXHistoryEnabled(false)},
+			path: "/plain",
+			headers: map[string]string{
+				"HX-Request": "true", "HX-Target": "content", "HX-History-Restore-Request": "true",
+			},
+			want: "partial",
+		},
+		{
+			name: "override method chooses target",
+			path: "/override?keep=partial",
+			headers: map[string]string{
+				"HX-Request": "true", "HX-Target": "body", "HX-History-Restore-Request": "true",
+			},
+			want: "partial",
+		},
+		{
+			name: "override returning nil falls back to full page",
+			path: "/override",
+			headers: map[string]string{
+				"HX-Request": "true", "HX-Target": "content", "HX-History-Restore-Request": "true",
+			},
+			want: "full page",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			if _, err := Mount(mux, &historyPages{}, "/", "App", tt.opts...); err != nil {
+				t.Fatalf("Mount: %v", err)
+			}
+			req := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+			if got := rec.Body.String(); got != tt.want {
+				t.Errorf("body = %q (status %d), want %q", got, rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+type badHistoryPage struct{}
+
+func (badHistoryPage) Page() component          { return testComponent{"page"} }
+func (badHistoryPage) HXHistoryRestore() string { return "" }
+
+func TestHTMXRenderTarget_HistoryRestoreBadSignature(t *testing.T) {
+	type pages struct {
+		Bad badHistoryPage `route:"/bad Bad"`
+	}
+	_, err := Mount(http.NewServeMux(), &pages{}, "/", "App")
+	if err == nil || !strings.Contains(err.Error(), "HXHistoryRestore") {
+		t.Fatalf("Mount error = %v, want HXHistoryRestore signature error", err)
+	}
+}
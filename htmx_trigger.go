@@ -0,0 +1,104 @@
+package structpages
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// HXEvent is a client-side event a page triggers through its HXTrigger,
+// HXTriggerAfterSwap or HXTriggerAfterSettle method. A nil Payload triggers
+// the event by name; otherwise Payload is marshalled to JSON as the event's
+// detail.
+type HXEvent struct {
+	Name    string
+	Payload any
+}
+
+// HXEventSimple returns an event without a payload.
+func HXEventSimple(name string) HXEvent { return HXEvent{Name: name} }
+
+// HXEventWithPayload returns an event with payload as its detail.
+func HXEventWithPayload(name string, payload any) HXEvent {
+	return HXEvent{Name: name, Payload: payload}
+}
+
+// hxTriggerHeaders maps the trigger page methods to the response headers
+// they set, one per htmx trigger timing.
+var hxTriggerHeaders = map[string]string{
+	"HXTrigger":            "HX-Trigger",
+	"HXTriggerAfterSwap":   "HX-Trigger-After-Swap",
+	"HXTriggerAfterSettle": "HX-Trigger-After-Settle",
+}
+
+var (
+	hxEventsType = reflect.TypeFor[[]HXEvent]()
+	errorType    = reflect.TypeFor[error]()
+)
+
+// setTriggerHeaders calls the page's HXTrigger* methods and sends the events
+// they return. Events without a payload go out as a comma-separated list of
+// names; any payload switches the header to the JSON object form, merged into
+// a JSON object already set such as HxPolling's.
+func (sp *StructPages) setTriggerHeaders(w http.ResponseWriter, r *http.Request, pn *PageNode) error {
+	if pn == nil {
+		return nil
+	}
+	for name, method := range pn.hxTriggers {
+		res, err := sp.pc.callMethod(pn, method, reflect.ValueOf(r), reflect.ValueOf(w))
+		if err != nil {
+			return fmt.Errorf("error calling %s method on %s: %w", name, pn.Name, err)
+		}
+		res, err = extractError(res)
+		if err != nil {
+			return fmt.Errorf("error calling %s method on %s: %w", name, pn.Name, err)
+		}
+		if err := setEvents(w.Header(), hxTriggerHeaders[name], res[0].Interface().([]HXEvent)); err != nil {
+			return fmt.Errorf("%s on %s: %w", name, pn.Name, err)
+		}
+	}
+	return nil
+}
+
+// setEvents writes events to header key of h.
+func setEvents(h http.Header, key string, events []HXEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	existing := h.Get(key)
+	simple := !strings.HasPrefix(existing, "{")
+	for _, e := range events {
+		simple = simple && e.Payload == nil
+	}
+	if simple {
+		names := make([]string, 0, len(events)+1)
+		if existing != "" {
+			names = append(names, existing)
+		}
+		for _, e := range events {
+			names = append(names, e.Name)
+		}
+		h.Set(key, strings.Join(names, ", "))
+		return nil
+	}
+	obj := map[string]any{}
+	if existing != "" {
+		if err := json.Unmarshal([]byte(existing), &obj); err != nil {
+			// A plain list of names: keep them as events without detail.
+			for name := range strings.SplitSeq(existing, ",") {
+				obj[strings.TrimSpace(name)] = nil
+			}
+		}
+	}
+	for _, e := range events {
+		obj[e.Name] = e.Payload
+	}
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	h.Set(key, string(b))
+	return nil
+}
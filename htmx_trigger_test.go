@@ -0,0 +1,162 @@
+package structpages
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type triggerToast struct {
+	Level string `json:"level"`
+	Text  string `json:"text"`
+}
+
+type triggerPage struct{}
+
+func (triggerPage) Page() component { return testComponent{"page"} }
+
+func (triggerPage) HXTrigger(r *http.Request) ([]HXEvent, error) {
+	switch r.URL.Query().Get("events") {
+	case "simple":
+		return []HXEvent{HXEventSimple("saved")}, nil
+	case "payload":
+		return []HXEvent{HXEventWithPayload("toast", triggerToast{"info", "Saved"})}, nil
+	case "many":
+		return []HXEvent{HXEventSimple("saved"), HXEventSimple("refresh")}, nil
+	case "mixed":
+		return []HXEvent{HXEventSimple("saved"), HXEventWithPayload("count", 3)}, nil
+	case "unmarshalable":
+		return []HXEvent{HXEventWithPayload("bad", func() {})}, nil
+	}
+	return []HXEvent{}, nil
+}
+
+func (triggerPage) HXTriggerAfterSwap(r *http.Request) ([]HXEvent, error) {
+	if r.URL.Query().Get("events") == "timed" {
+		return []HXEvent{HXEventSimple("swapped")}, nil
+	}
+	return nil, nil
+}
+
+func (triggerPage) HXTriggerAfterSettle(r *http.Request) ([]HXEvent, error) {
+	if r.URL.Query().Get("events") == "timed" {
+		return []HXEvent{HXEventWithPayload("settled", map[string]int{"n": 1})}, nil
+	}
+	return nil, nil
+}
+
+type triggerPages struct {
+	triggerPage `route:"/ Trigger"`
+}
+
+func TestHXTrigger(t *testing.T) {
+	var gotErr error
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &triggerPages{}, "/", "App",
+		WithErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+			gotErr = err
+			http.Error(w, "error", http.StatusInternalServerError)
+		}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	tests := []struct {
+		events  string
+		headers map[string]string
+	}{
+		{"simple", map[string]string{"HX-Trigger": "saved"}},
+		{"payload", map[string]string{"HX-Trigger": `{"toast":{"level":"info","text":"Saved"}}`}},
+		{"many", map[string]string{"HX-Trigger": "saved, refresh"}},
+		{"mixed", map[string]string{"HX-Trigger": `{"count":3,"saved":null}`}},
+		{"timed", map[string]string{
+			"HX-Trigger-After-Swap":   "swapped",
+			"HX-Trigger-After-Settle": `{"settled":{"n":1}}`,
+		}},
+		{"empty", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.events, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?events="+tt.events, http.NoBody))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+			}
+			for _, key := range []string{"HX-Trigger", "HX-Trigger-After-Swap", "HX-Trigger-After-Settle"} {
+				if got := rec.Header().Get(key); got != tt.headers[key] {
+					t.Errorf("%s = %q, want %q", key, got, tt.headers[key])
+				}
+			}
+		})
+	}
+
+	t.Run("marshal error", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?events=unmarshalable", http.NoBody))
+		if rec.Code != http.StatusInternalServerError {
+			t.Errorf("status = %d, want 500", rec.Code)
+		}
+		if gotErr == nil || !strings.Contains(gotErr.Error(), "HXTrigger") {
+			t.Errorf("onError got %v, want the HXTrigger marshal error", gotErr)
+		}
+	})
+}
+
+type triggerPollPage struct{}
+
+func (triggerPollPage) Page() component { return testComponent{"page"} }
+
+func (triggerPollPage) HxPolling() time.Duration { return time.Second }
+
+func (triggerPollPage) HXTrigger() ([]HXEvent, error) {
+	return []HXEvent{HXEventSimple("saved")}, nil
+}
+
+func TestHXTrigger_MergesPolling(t *testing.T) {
+	mux := http.NewServeMux()
+	if _, err := Mount(mux, &struct {
+		triggerPollPage `route:"/ Poll"`
+	}{}, "/", "App"); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	if got, want := rec.Header().Get("HX-Trigger"), `{"poll":{"interval":1000},"saved":null}`; got != want {
+		t.Errorf("HX-Trigger = %q, want %q", got, want)
+	}
+}
+
+type triggerErrPage struct{}
+
+func (triggerErrPage) Page() component { return testComponent{"page"} }
+
+func (triggerErrPage) HXTrigger() ([]HXEvent, error) { return nil, errors.New("no events") }
+
+type badTriggerPage struct{}
+
+func (badTriggerPage) Page() component { return testComponent{"page"} }
+
+func (badTriggerPage) HXTrigger() string { return "saved" }
+
+func TestHXTrigger_Errors(t *testing.T) {
+	mux := http.NewServeMux()
+	if _, err := Mount(mux, &struct {
+		triggerErrPage `route:"/ Err"`
+	}{}, "/", "App"); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", rec.Code)
+	}
+
+	_, err := Mount(http.NewServeMux(), &struct {
+		badTriggerPage `route:"/ Bad"`
+	}{}, "/", "App")
+	if err == nil || !strings.Contains(err.Error(), "must return ([]structpages.HXEvent, error)") {
+		t.Errorf("Mount error = %v, want the signature error", err)
+	}
+}
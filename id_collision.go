@@ -0,0 +1,43 @@
+package structpages
+
+import "log"
+
+// IDCollisionPolicy decides what Mount, Parse, and Validate do when two
+// component methods would generate the same element id.
+type IDCollisionPolicy int
+
+const (
+	// IDCollisionWarn logs each collision and carries on. This is the
+	// default.
+	IDCollisionWarn IDCollisionPolicy = iota
+	// IDCollisionError makes Mount return an error describing every
+	// collision.
+	IDCollisionError
+	// IDCollisionIgnore skips the check.
+	IDCollisionIgnore
+)
+
+// WithIDCollisionPolicy sets how duplicate generated element ids are
+// reported. Colliding ids make HTMX targets ambiguous: a request for one
+// component may render the other. The default is IDCollisionWarn.
+func WithIDCollisionPolicy(policy IDCollisionPolicy) func(*StructPages) {
+	return func(r *StructPages) {
+		r.idCollisionPolicy = policy
+	}
+}
+
+// checkIDCollisions applies the configured IDCollisionPolicy to pc.
+func (sp *StructPages) checkIDCollisions(pc *parseContext) error {
+	if sp.idCollisionPolicy == IDCollisionIgnore {
+		return nil
+	}
+	err := pc.checkIDUniqueness()
+	if err == nil {
+		return nil
+	}
+	if sp.idCollisionPolicy == IDCollisionError {
+		return err
+	}
+	log.Printf("structpages: %v", err)
+	return nil
+}
@@ -0,0 +1,64 @@
+package structpages
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+type collisionListPage struct{}
+
+func (collisionListPage) Page() component { return testComponent{"list"} }
+
+type collisionUserPage struct{}
+
+func (collisionUserPage) ListPage() component { return testComponent{"user"} }
+
+// UserList.Page and User.ListPage both kebab-case to "user-list-page".
+type collisionPages struct {
+	UserList collisionListPage `route:"/list List"`
+	User     collisionUserPage `route:"/user User"`
+}
+
+func TestWithIDCollisionPolicy(t *testing.T) {
+	const wantMsg = `element id "user-list-page" is produced by both`
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	flags := log.Flags()
+	log.SetFlags(0)
+	t.Cleanup(func() {
+		log.SetOutput(os.Stderr)
+		log.SetFlags(flags)
+	})
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr bool
+		wantLog bool
+	}{
+		{name: "default warns", wantLog: true},
+		{name: "warn", opts: []Option{WithIDCollisionPolicy(IDCollisionWarn)}, wantLog: true},
+		{name: "error", opts: []Option{WithIDCollisionPolicy(IDCollisionError)}, wantErr: true},
+		{name: "ignore", opts: []Option{WithIDCollisionPolicy(IDCollisionIgnore)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			_, err := Parse(&collisionPages{}, "/", "App", tt.opts...)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), wantMsg) {
+					t.Errorf("err = %v, want %q", err, wantMsg)
+				}
+			} else if err != nil {
+				t.Errorf("err = %v, want nil", err)
+			}
+			if got := strings.Contains(buf.String(), wantMsg); got != tt.wantLog {
+				t.Errorf("logged %q, want warning: %v", buf.String(), tt.wantLog)
+			}
+		})
+	}
+}
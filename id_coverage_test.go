@@ -47,7 +47,7 @@ func TestID_BoundMethodValue(t *testing.T) {
 	// With the current page set to the Leaf mount, the current-page match
 	// path (pageNodeMatchesMethod, isBound branch) is taken.
 	var leaf *PageNode
-	for n := range pc.root.All() {
+	for n := range pc.root().All() {
 		if n.Name == "Leaf" {
 			leaf = n
 		}
@@ -132,7 +132,7 @@ func TestID_ChainSelfRenderOverride(t *testing.T) {
 	base := pcCtx.WithValue(context.Background(), pc)
 
 	var betaItem *PageNode
-	for n := range pc.root.All() {
+	for n := range pc.root().All() {
 		if n.Parent != nil && n.Parent.Name == "Beta" && n.Name == "Item" {
 			betaItem = n
 		}
@@ -29,7 +29,7 @@ func TestID_SelfRenderUsesCurrentPage(t *testing.T) {
 
 	// Find the two mount nodes.
 	var admin, user *PageNode
-	for n := range pc.root.All() {
+	for n := range pc.root().All() {
 		switch n.Name {
 		case "AdminDash":
 			admin = n
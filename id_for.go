@@ -20,6 +20,7 @@ import (
 //   - Method expression (p.UserList) - generates ID from page and method name
 //   - Ref type (structpages.Ref("PageName.MethodName")) - looks up page/method dynamically
 //   - Plain string ("my-custom-id") - returned as-is
+//   - IDParams - any of the above plus suffixes
 //
 // Example:
 //
@@ -65,6 +66,7 @@ func ID(ctx context.Context, v any) (string, error) {
 //   - Method expression (p.UserList) - generates selector from page and method name
 //   - Ref type (structpages.Ref("PageName.MethodName")) - looks up page/method dynamically
 //   - string ("body" or "#my-custom-id") - returned as-is
+//   - IDParams - any of the above plus suffixes
 //
 // Example:
 //
@@ -103,6 +105,10 @@ func IDTarget(ctx context.Context, v any) (string, error) {
 func idFor(pc *parseContext, currentPage *PageNode, v any, rawID bool) (string, error) {
 	methodExpr := v
 
+	if params, ok := methodExpr.(IDParams); ok {
+		return idForParams(pc, currentPage, params, rawID)
+	}
+
 	// Handle Ref type for dynamic method references
 	if ref, ok := methodExpr.(Ref); ok {
 		return idForRef(pc, string(ref), rawID)
@@ -146,6 +152,45 @@ func idFor(pc *parseContext, currentPage *PageNode, v any, rawID bool) (string,
 	return pc.componentID(pn, info.methodName, rawID), nil
 }
 
+// IDParams builds an id from a component reference plus suffixes, for
+// related elements such as a component's modal or container:
+//
+//	structpages.IDTarget(ctx, structpages.IDParams{Method: p.UserList, Suffixes: []string{"modal"}})
+//	// → "#user-list-page-user-list-modal"
+//
+// Method takes anything ID and IDTarget accept (method expression, Ref,
+// []any chain, string). Suffixes are appended in order, separated by
+// hyphens; a suffix containing uppercase letters is kebab-cased first.
+// RawID drops the "#" prefix even when passed to IDTarget.
+type IDParams struct {
+	Method   any
+	Suffixes []string
+	RawID    bool
+}
+
+func idForParams(pc *parseContext, currentPage *PageNode, params IDParams, rawID bool) (string, error) {
+	if _, nested := params.Method.(IDParams); nested {
+		return "", errors.New("IDParams.Method cannot be another IDParams")
+	}
+	id, err := idFor(pc, currentPage, params.Method, rawID || params.RawID)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	b.WriteString(id)
+	for _, suffix := range params.Suffixes {
+		if suffix == "" {
+			continue
+		}
+		if strings.ToLower(suffix) != suffix {
+			suffix = camelToKebab(suffix)
+		}
+		b.WriteByte('-')
+		b.WriteString(suffix)
+	}
+	return b.String(), nil
+}
+
 // idForChain resolves the []any composition form for ID/IDTarget.
 // The trailing element is the method spec:
 //
@@ -322,7 +367,7 @@ func resolvePageForMethod(pc *parseContext, currentPage *PageNode, info *methodI
 // method by writing the expression, so we trust it.
 func (p *parseContext) collectPageNodesForMethod(info *methodInfo) []*PageNode {
 	var out []*PageNode
-	for node := range p.root.All() {
+	for node := range p.root().All() {
 		nodeType := node.Value.Type()
 		if info.isBound {
 			nodeTypeName := nodeType.Name()
@@ -386,7 +431,7 @@ func (p *parseContext) findPageNodeForMethod(info *methodInfo) (*PageNode, error
 // findPageNodeByTypeName finds a PageNode by matching its type name.
 // Also verifies that the method exists on the page.
 func (p *parseContext) findPageNodeByTypeName(typeName, methodName string) (*PageNode, error) {
-	for node := range p.root.All() {
+	for node := range p.root().All() {
 		nodeType := node.Value.Type()
 		nodeTypeName := nodeType.Name()
 		if nodeType.Kind() == reflect.Pointer {
@@ -408,7 +453,7 @@ func (p *parseContext) findPageNodeByType(receiverType reflect.Type) (*PageNode,
 	// Normalize to pointer type for comparison
 	targetType := pointerType(receiverType)
 
-	for node := range p.root.All() {
+	for node := range p.root().All() {
 		nodeType := pointerType(node.Value.Type())
 		if targetType == nodeType {
 			return node, nil
@@ -450,7 +495,7 @@ func idForRef(pc *parseContext, ref string, rawID bool) (string, error) {
 	// parents), so collect every match and refuse to guess when more than
 	// one carries the method.
 	var named, withMethod []*PageNode
-	for node := range pc.root.All() {
+	for node := range pc.root().All() {
 		if node.Name != pageName {
 			continue
 		}
@@ -482,7 +527,7 @@ func idForRef(pc *parseContext, ref string, rawID bool) (string, error) {
 // findPagesWithMethod finds all pages that have a method with the given name.
 func findPagesWithMethod(pc *parseContext, methodName string) []*PageNode {
 	var matches []*PageNode
-	for node := range pc.root.All() {
+	for node := range pc.root().All() {
 		if _, found := node.Value.Type().MethodByName(methodName); found {
 			matches = append(matches, node)
 		}
@@ -496,18 +541,18 @@ func findPagesWithMethod(pc *parseContext, methodName string) []*PageNode {
 const defaultMaxIDLen = 40
 
 // assignIDPaths populates idPath and idCompactSuffix on every node in
-// the tree. idPath is the kebab-cased field-name path from the root
+// the tree below root. idPath is the kebab-cased field-name path from the root
 // (root excluded) down to the node; for the root itself it is the
 // root's own kebab name. A node whose leaf name is shared by another
 // node gets a stable "-<hash>" compact suffix so the leaf-only id form
 // stays unique.
-func (p *parseContext) assignIDPaths() {
+func assignIDPaths(root *PageNode) {
 	leafCount := make(map[string]int)
-	for node := range p.root.All() {
+	for node := range root.All() {
 		node.idPath = idPathFor(node)
 		leafCount[node.idPath[len(node.idPath)-1]]++
 	}
-	for node := range p.root.All() {
+	for node := range root.All() {
 		if leafCount[node.idPath[len(node.idPath)-1]] > 1 {
 			node.idCompactSuffix = "-" + shortHash(strings.Join(node.idPath, "/"))
 		} else {
@@ -546,20 +591,28 @@ func shortHash(s string) string {
 func (p *parseContext) checkIDUniqueness() error {
 	type owner struct{ route, method string }
 	seen := make(map[string]owner)
-	for node := range p.root.All() {
+	var errs []error
+	var methods []string // reused across nodes: Mount runs this every time
+	for node := range p.root().All() {
+		methods = methods[:0]
 		for method := range node.Components {
+			methods = append(methods, method)
+		}
+		slices.Sort(methods)
+		for _, method := range methods {
 			id := p.componentID(node, method, true)
 			cur := owner{route: node.FullRoute(), method: method}
 			if prev, ok := seen[id]; ok && prev != cur {
-				return fmt.Errorf(
+				errs = append(errs, fmt.Errorf(
 					"element id %q is produced by both %s.%s and %s.%s; "+
 						"rename a mount field to disambiguate",
-					id, prev.route, prev.method, cur.route, cur.method)
+					id, prev.route, prev.method, cur.route, cur.method))
+				continue
 			}
 			seen[id] = cur
 		}
 	}
-	return nil
+	return errors.Join(errs...)
 }
 
 // componentID constructs the HTML id string for a method on node.
@@ -80,6 +80,36 @@ func TestCamelToKebab(t *testing.T) {
 			input:    "GroupSearchInput",
 			expected: "group-search-input",
 		},
+		{
+			name:     "accented uppercase",
+			input:    "ÉtatPage",
+			expected: "état-page",
+		},
+		{
+			name:     "german umlauts",
+			input:    "ÄnderungsÜbersicht",
+			expected: "änderungs-übersicht",
+		},
+		{
+			name:     "uppercase after lowercase umlaut",
+			input:    "größeÄndern",
+			expected: "größe-ändern",
+		},
+		{
+			name:     "cjk not split",
+			input:    "用户列表",
+			expected: "用户列表",
+		},
+		{
+			name:     "mixed ascii and cjk",
+			input:    "用户List",
+			expected: "用户-list",
+		},
+		{
+			name:     "mixed ascii and accented acronym",
+			input:    "ÉTATParser",
+			expected: "état-parser",
+		},
 	}
 
 	for _, tt := range tests {
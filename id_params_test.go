@@ -0,0 +1,85 @@
+package structpages
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+type idParamsUserListPage struct{}
+
+func (idParamsUserListPage) Page() component { return testComponent{"users"} }
+
+func (idParamsUserListPage) UserList() component { return testComponent{"list"} }
+
+type idParamsPages struct {
+	UserListPage idParamsUserListPage `route:"/users Users"`
+}
+
+func TestIDParams(t *testing.T) {
+	sp, err := Parse(&idParamsPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	ctx := sp.PageContext(context.Background())
+	tests := []struct {
+		name   string
+		params IDParams
+		target string
+		raw    string
+	}{
+		{
+			name:   "no suffix",
+			params: IDParams{Method: idParamsUserListPage.UserList},
+			target: "#user-list-page-user-list",
+			raw:    "user-list-page-user-list",
+		},
+		{
+			name:   "single suffix",
+			params: IDParams{Method: idParamsUserListPage.UserList, Suffixes: []string{"modal"}},
+			target: "#user-list-page-user-list-modal",
+			raw:    "user-list-page-user-list-modal",
+		},
+		{
+			name:   "multiple suffixes",
+			params: IDParams{Method: idParamsUserListPage.UserList, Suffixes: []string{"container", "inner"}},
+			target: "#user-list-page-user-list-container-inner",
+			raw:    "user-list-page-user-list-container-inner",
+		},
+		{
+			name:   "RawID",
+			params: IDParams{Method: idParamsUserListPage.UserList, Suffixes: []string{"modal"}, RawID: true},
+			target: "user-list-page-user-list-modal",
+			raw:    "user-list-page-user-list-modal",
+		},
+		{
+			name:   "Ref with kebab-cased suffix",
+			params: IDParams{Method: Ref("UserListPage.UserList"), Suffixes: []string{"EditForm"}},
+			target: "#user-list-page-user-list-edit-form",
+			raw:    "user-list-page-user-list-edit-form",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := IDTarget(ctx, tt.params); err != nil || got != tt.target {
+				t.Errorf("IDTarget = %q, %v; want %q", got, err, tt.target)
+			}
+			if got, err := sp.IDTarget(tt.params); err != nil || got != tt.target {
+				t.Errorf("sp.IDTarget = %q, %v; want %q", got, err, tt.target)
+			}
+			if got, err := sp.IDFor(tt.params); err != nil || got != tt.target {
+				t.Errorf("sp.IDFor = %q, %v; want %q", got, err, tt.target)
+			}
+			if got, err := ID(ctx, tt.params); err != nil || got != tt.raw {
+				t.Errorf("ID = %q, %v; want %q", got, err, tt.raw)
+			}
+			if got, err := sp.ID(tt.params); err != nil || got != tt.raw {
+				t.Errorf("sp.ID = %q, %v; want %q", got, err, tt.raw)
+			}
+		})
+	}
+
+	if _, err := sp.ID(IDParams{Method: 42}); err == nil || !strings.Contains(err.Error(), "unsupported type") {
+		t.Errorf("sp.ID(IDParams{Method: 42}) err = %v, want unsupported type", err)
+	}
+}
@@ -51,7 +51,7 @@ func TestID_SectionRoots_MethodExprSelfRenders(t *testing.T) {
 	}
 
 	bySection := map[string]*PageNode{}
-	for n := range pc.root.All() {
+	for n := range pc.root().All() {
 		if n.Name != "EntryDetail" {
 			continue
 		}
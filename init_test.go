@@ -0,0 +1,118 @@
+package structpages
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type initDB struct{ name string }
+
+type initConfig struct{ debug bool }
+
+type initDIPage struct {
+	db    *initDB
+	debug bool
+}
+
+func (p *initDIPage) Init(db *initDB, cfg *initConfig) error {
+	p.db, p.debug = db, cfg.debug
+	return nil
+}
+
+func (p *initDIPage) Page() component { return testComponent{p.db.name} }
+
+type initCtxPage struct {
+	background bool
+	db         *initDB
+}
+
+func (p *initCtxPage) InitContext(ctx context.Context, db *initDB) error {
+	p.background, p.db = ctx == context.Background(), db
+	return nil
+}
+
+func (p *initCtxPage) Page() component { return testComponent{""} }
+
+type initFailPage struct{}
+
+func (initFailPage) Name() string { return "failing" }
+
+func (initFailPage) InitContext(context.Context) error { return errors.New("db unreachable") }
+
+func (initFailPage) Page() component { return testComponent{""} }
+
+type badInitCtxPage struct{}
+
+func (badInitCtxPage) InitContext(db *initDB) error { return nil }
+
+func (badInitCtxPage) Page() component { return testComponent{""} }
+
+func TestInit_DI(t *testing.T) {
+	pages := &struct {
+		DI  *initDIPage  `route:"/di DI"`
+		Ctx *initCtxPage `route:"/ctx Ctx"`
+	}{}
+	di, ctx := &initDIPage{}, &initCtxPage{}
+	db := &initDB{name: "main"}
+	_, err := Mount(http.NewServeMux(), pages, "/", "App",
+		WithArgs(db, &initConfig{debug: true}), WithSingleton(di), WithSingleton(ctx))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	if di.db != db || !di.debug {
+		t.Errorf("Init got db=%v debug=%v, want the registered args", di.db, di.debug)
+	}
+	if !ctx.background || ctx.db != db {
+		t.Errorf("InitContext got background=%v db=%v, want context.Background and the db",
+			ctx.background, ctx.db)
+	}
+}
+
+func TestInit_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		pages any
+		args  []Option
+		want  []string
+	}{
+		{
+			name: "missing arg",
+			pages: &struct {
+				DI *initDIPage `route:"/di DI"`
+			}{DI: &initDIPage{}},
+			args: []Option{WithArgs(&initDB{})},
+			want: []string{"Init method on DI", "requires argument of type *structpages.initConfig"},
+		},
+		{
+			name: "error names page and field",
+			pages: &struct {
+				Reports initFailPage `route:"/reports Reports"`
+			}{},
+			want: []string{"field Reports", "InitContext method on failing", "db unreachable"},
+		},
+		{
+			name: "no context parameter",
+			pages: &struct {
+				Bad badInitCtxPage `route:"/bad Bad"`
+			}{},
+			args: []Option{WithArgs(&initDB{})},
+			want: []string{"must take a context.Context"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Mount(http.NewServeMux(), tt.pages, "/", "App", tt.args...)
+			if err == nil {
+				t.Fatal("Mount succeeded, want an error")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Mount error = %q, want it to contain %q", err, want)
+				}
+			}
+		})
+	}
+}
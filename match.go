@@ -0,0 +1,99 @@
+package structpages
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/jackielii/ctxkey"
+)
+
+// ErrRouteNotFound is returned by StructPages.Match when no registered page
+// serves the given method and path.
+var ErrRouteNotFound = errors.New("structpages: route not found")
+
+var matchResultCtx = ctxkey.New[*matchResult]("structpages.matchResult", nil)
+
+// matchResult is filled in by the matcher's handlers during Match.
+type matchResult struct {
+	pn     *PageNode
+	params map[string]string
+}
+
+// Match reports which page a request with the given method and path would be
+// routed to, and the values of its path wildcards, without serving it. It
+// applies http.ServeMux matching rules to the page tree, so it works on a
+// StructPages from Parse or Validate as well as Mount. Paths that ServeMux
+// would answer with a redirect or 405, and pages disabled by Revert, return
+// ErrRouteNotFound. Page aliases match too, and so do pages added by
+// AddChildRoute and the tree Refresh swapped in.
+//
+// Example (contract test):
+//
+//	pn, params, err := sp.Match(http.MethodGet, "/users/42")
+//	// pn.Name == "User", params["id"] == "42"
+func (sp *StructPages) Match(method, path string) (*PageNode, map[string]string, error) {
+	sp = sp.current()
+	matcher, err := sp.currentMatcher()
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := http.NewRequest(method, path, http.NoBody)
+	if err != nil {
+		return nil, nil, fmt.Errorf("structpages: match %s %s: %w", method, path, err)
+	}
+	res := &matchResult{}
+	matcher.ServeHTTP(discardResponseWriter{}, req.WithContext(matchResultCtx.WithValue(req.Context(), res)))
+	if res.pn == nil || sp.pc.isReverted(res.pn) {
+		return nil, nil, ErrRouteNotFound
+	}
+	return res.pn, res.params, nil
+}
+
+// currentMatcher returns the matcher, building it if the tree changed since
+// it was last built.
+func (sp *StructPages) currentMatcher() (*http.ServeMux, error) {
+	sp.matchMu.Lock()
+	defer sp.matchMu.Unlock()
+	if sp.matcher == nil {
+		sp.buildMatcher()
+	}
+	return sp.matcher, sp.matchErr
+}
+
+// resetMatcher makes the next Match rebuild the matcher from the tree.
+func (sp *StructPages) resetMatcher() {
+	sp.matchMu.Lock()
+	defer sp.matchMu.Unlock()
+	sp.matcher, sp.matchErr = nil, nil
+}
+
+// buildMatcher registers every page on a private ServeMux whose handlers
+// record the matched page instead of serving it.
+func (sp *StructPages) buildMatcher() {
+	sp.matcher = http.NewServeMux()
+	sp.matchErr = sp.registerPatterns(sp.matcher, func(pn *PageNode, route string) http.Handler {
+		segments, _ := parseSegments(route)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			res := matchResultCtx.Value(r.Context())
+			if res == nil {
+				return
+			}
+			res.pn = pn
+			res.params = make(map[string]string)
+			for _, seg := range segments {
+				if seg.param {
+					res.params[seg.name] = r.PathValue(seg.name)
+				}
+			}
+		})
+	})
+}
+
+// discardResponseWriter swallows the redirects and errors ServeMux writes
+// for requests that match no page.
+type discardResponseWriter struct{}
+
+func (discardResponseWriter) Header() http.Header         { return http.Header{} }
+func (discardResponseWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (discardResponseWriter) WriteHeader(int)             {}
@@ -0,0 +1,79 @@
+package structpages
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type matchUserPage struct{}
+
+func (matchUserPage) Page() component { return testComponent{"user"} }
+
+type matchFilesPage struct{}
+
+func (matchFilesPage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	pn := CurrentPage(r.Context())
+	if pn == nil {
+		http.Error(w, "no page", http.StatusInternalServerError)
+		return
+	}
+	_, _ = w.Write([]byte(pn.Name))
+}
+
+type matchPages struct {
+	Home  serveHomePage  `route:"/{$} Home"`
+	User  matchUserPage  `route:"/users/{id} User"`
+	Files matchFilesPage `route:"/files/{path...} Files"`
+	Save  matchUserPage  `route:"POST /users/{id}/save Save"`
+}
+
+func TestStructPages_Match(t *testing.T) {
+	sp, err := Parse(&matchPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	tests := []struct {
+		name, method, path string
+		wantPage           string
+		wantParams         map[string]string
+	}{
+		{name: "exact", method: http.MethodGet, path: "/", wantPage: "Home", wantParams: map[string]string{}},
+		{name: "wildcard", method: http.MethodGet, path: "/users/42", wantPage: "User",
+			wantParams: map[string]string{"id": "42"}},
+		{name: "remainder wildcard", method: http.MethodGet, path: "/files/a/b.txt", wantPage: "Files",
+			wantParams: map[string]string{"path": "a/b.txt"}},
+		{name: "HEAD matches GET", method: http.MethodHead, path: "/users/7", wantPage: "User",
+			wantParams: map[string]string{"id": "7"}},
+		{name: "method", method: http.MethodPost, path: "/users/1/save", wantPage: "Save",
+			wantParams: map[string]string{"id": "1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pn, params, err := sp.Match(tt.method, tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if pn.Name != tt.wantPage {
+				t.Errorf("page = %s, want %s", pn.Name, tt.wantPage)
+			}
+			if diff := cmp.Diff(tt.wantParams, params); diff != "" {
+				t.Errorf("params mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	for name, req := range map[string][2]string{
+		"method mismatch": {http.MethodGet, "/users/1/save"},
+		"unknown path":    {http.MethodGet, "/nope"},
+		"redirect":        {http.MethodGet, "/files"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := sp.Match(req[0], req[1]); !errors.Is(err, ErrRouteNotFound) {
+				t.Errorf("err = %v, want ErrRouteNotFound", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package structpages
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type maxDepthLeaf struct{}
+
+func (maxDepthLeaf) Page() component { return testComponent{"leaf"} }
+
+type maxDepthMiddle struct {
+	Leaf maxDepthLeaf `route:"/leaf Leaf"`
+}
+
+type maxDepthTop struct {
+	Middle maxDepthMiddle `route:"/middle Middle"`
+}
+
+// maxDepthRoot nests Top (depth 1), Middle (2), and Leaf (3).
+type maxDepthRoot struct {
+	Top maxDepthTop `route:"/top Top"`
+}
+
+// recursivePage would recurse forever without cycle detection.
+type recursivePage struct {
+	Again *recursivePage `route:"/again Again"`
+}
+
+func (recursivePage) Page() component { return testComponent{"again"} }
+
+func TestWithMaxDepth(t *testing.T) {
+	tests := []struct {
+		name    string
+		page    any
+		opts    []Option
+		wantErr string
+	}{
+		{name: "limit equals depth", page: &maxDepthRoot{}, opts: []Option{WithMaxDepth(3)}},
+		{
+			name: "limit below depth", page: &maxDepthRoot{}, opts: []Option{WithMaxDepth(2)},
+			wantErr: "page Middle: field Leaf is at depth 3, deeper than the limit of 2",
+		},
+		{
+			name: "zero allows only the root", page: &maxDepthRoot{}, opts: []Option{WithMaxDepth(0)},
+			wantErr: "field Top is at depth 1, deeper than the limit of 0",
+		},
+		{name: "default", page: &maxDepthRoot{}},
+		{name: "recursive type", page: &recursivePage{}, wantErr: "cyclic page tree"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Mount(http.NewServeMux(), tt.page, "/", "App", tt.opts...)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Mount: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
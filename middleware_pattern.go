@@ -0,0 +1,39 @@
+package structpages
+
+import (
+	"net/http"
+)
+
+// WithMiddlewareByPattern adds a global middleware that only runs for
+// requests matching pattern, written as for http.ServeMux: "/admin/" covers
+// everything below /admin (and /admin itself), "POST /admin/" only POST
+// requests, "/users/{id}" exactly one segment after /users. Other requests
+// go straight to the next handler.
+//
+//	structpages.Mount(mux, pages{}, "/", "App",
+//	    structpages.WithMiddlewareByPattern("/admin/", requireAdmin),
+//	    structpages.WithMiddlewareByPattern("POST /", checkCSRF))
+//
+// Each call adds one middleware, in order with those from WithMiddlewares,
+// so they run before the pages' own Middlewares. mw is still built once per
+// page at Mount. The pattern is matched against the request path the pages
+// are served on, including any WithRoutePrefix. An invalid pattern panics,
+// as it does with http.ServeMux.Handle.
+func WithMiddlewareByPattern(pattern string, mw MiddlewareFunc) func(*StructPages) {
+	matcher := http.NewServeMux()
+	matcher.Handle(pattern, http.NotFoundHandler())
+	return func(r *StructPages) {
+		r.middlewares = append(r.middlewares, func(next http.Handler, pn *PageNode) http.Handler {
+			wrapped := mw(next, pn)
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// A pattern only comes back for a match, or a redirect to add
+				// the trailing slash of a subtree pattern.
+				if _, p := matcher.Handler(r); p != "" {
+					wrapped.ServeHTTP(w, r)
+					return
+				}
+				next.ServeHTTP(w, r)
+			})
+		})
+	}
+}
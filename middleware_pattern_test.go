@@ -0,0 +1,92 @@
+package structpages
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// markMiddleware records name in the X-Mw response header.
+func markMiddleware(name string) MiddlewareFunc {
+	return func(next http.Handler, pn *PageNode) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("X-Mw", name)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+type patternUsersPage struct{}
+
+func (patternUsersPage) Page() component { return testComponent{"users"} }
+
+func (patternUsersPage) Middlewares() []MiddlewareFunc {
+	return []MiddlewareFunc{markMiddleware("page")}
+}
+
+type patternAdminPages struct {
+	Users patternUsersPage `route:"/users Users"`
+}
+
+func (patternAdminPages) Page() component { return testComponent{"admin"} }
+
+type patternPublicPage struct{}
+
+func (patternPublicPage) Page() component { return testComponent{"public"} }
+
+type patternSubmitPage struct{}
+
+func (patternSubmitPage) Page() component { return testComponent{"submitted"} }
+
+type patternPages struct {
+	Admin  patternAdminPages `route:"/admin Admin"`
+	Public patternPublicPage `route:"/public Public"`
+	Submit patternSubmitPage `route:"POST /submit Submit"`
+}
+
+func TestWithMiddlewareByPattern(t *testing.T) {
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &patternPages{}, "/", "App",
+		WithMiddlewareByPattern("/admin/", markMiddleware("admin")),
+		WithMiddlewareByPattern("POST /", markMiddleware("post")),
+		WithMiddlewareByPattern("/admin/users", markMiddleware("users")))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	tests := []struct {
+		method, path string
+		want         string
+	}{
+		{http.MethodGet, "/admin/users", "admin,users,page"},
+		{http.MethodGet, "/admin", "admin"},
+		{http.MethodGet, "/public", ""},
+		{http.MethodPost, "/submit", "post"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, http.NoBody))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+			}
+			if got := strings.Join(rec.Header().Values("X-Mw"), ","); got != tt.want {
+				t.Errorf("middlewares = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithMiddlewareByPattern_RoutePrefix(t *testing.T) {
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &patternPages{}, "/", "App", WithRoutePrefix("/v2"),
+		WithMiddlewareByPattern("/v2/admin/", markMiddleware("admin")))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v2/admin/users", http.NoBody))
+	if got := strings.Join(rec.Header().Values("X-Mw"), ","); got != "admin,page" {
+		t.Errorf("middlewares = %q, want %q", got, "admin,page")
+	}
+}
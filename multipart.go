@@ -0,0 +1,69 @@
+package structpages
+
+import (
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+)
+
+// defaultMultipartMaxMemory matches the default http.Request.FormFile uses.
+const defaultMultipartMaxMemory = 32 << 20
+
+var (
+	multipartFormType  = reflect.TypeOf((*multipart.Form)(nil))
+	fileHeadersType    = reflect.TypeOf([]*multipart.FileHeader(nil))
+	requestPointerType = reflect.TypeOf((*http.Request)(nil))
+)
+
+// WithMultipartMaxMemory sets the maxMemory passed to
+// http.Request.ParseMultipartForm before a *multipart.Form or
+// []*multipart.FileHeader parameter is injected. Defaults to 32 MB.
+//
+// A Props method can then take the parsed upload directly:
+//
+//	func (p upload) Props(form *multipart.Form) (UploadProps, error)
+//
+// A []*multipart.FileHeader parameter receives the files of a single field,
+// named by a form struct tag on the page's field or by a FormField method:
+//
+//	Upload upload `route:"POST /upload Upload" form:"avatar"`
+//
+// A request that is not a valid multipart form is reported to the error
+// handler as an *HTTPError with code 400.
+func WithMultipartMaxMemory(n int64) func(*StructPages) {
+	return func(r *StructPages) {
+		r.multipartMaxMemory = n
+	}
+}
+
+// multipartArg fills *multipart.Form and []*multipart.FileHeader parameters
+// from the request among availableArgs, parsing the form on first use. ok is
+// false when argType is neither or no request is available.
+func (p *parseContext) multipartArg(
+	pn *PageNode, argType reflect.Type, availableArgs map[reflect.Type][]reflect.Value,
+) (reflect.Value, bool, error) {
+	if argType != multipartFormType && argType != fileHeadersType {
+		return reflect.Value{}, false, nil
+	}
+	reqs := availableArgs[requestPointerType]
+	if len(reqs) == 0 {
+		return reflect.Value{}, false, nil
+	}
+	r := reqs[0].Interface().(*http.Request)
+	if r.MultipartForm == nil {
+		if err := r.ParseMultipartForm(p.multipartMaxMemory); err != nil {
+			err = fmt.Errorf("parse multipart form: %w", err)
+			return reflect.Value{}, false, &HTTPError{Code: http.StatusBadRequest, Err: err}
+		}
+	}
+	if argType == multipartFormType {
+		return reflect.ValueOf(r.MultipartForm), true, nil
+	}
+	if pn == nil || pn.formField == "" {
+		return reflect.Value{}, false, errors.New(
+			"[]*multipart.FileHeader parameter needs a form field name: add a form struct tag or a FormField method")
+	}
+	return reflect.ValueOf(r.MultipartForm.File[pn.formField]), true, nil
+}
@@ -0,0 +1,172 @@
+package structpages
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type uploadFormPage struct{}
+
+func (uploadFormPage) Props(form *multipart.Form) (string, error) {
+	fh := form.File["doc"][0]
+	f, err := fh.Open()
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	body, err := io.ReadAll(f)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s=%s %s:%s", "title", form.Value["title"][0], fh.Filename, body), nil
+}
+
+func (uploadFormPage) Page(s string) component { return testComponent{s} }
+
+type uploadFilesPage struct{}
+
+func (uploadFilesPage) Props(files []*multipart.FileHeader, r *http.Request) (string, error) {
+	names := make([]string, len(files))
+	for i, fh := range files {
+		names[i] = fh.Filename
+	}
+	return strings.Join(names, ",") + " note=" + r.FormValue("note"), nil
+}
+
+func (uploadFilesPage) Page(s string) component { return testComponent{s} }
+
+type uploadMethodPage struct{}
+
+func (uploadMethodPage) FormField() string { return "photos" }
+
+func (uploadMethodPage) Props(files []*multipart.FileHeader, r *http.Request) (string, error) {
+	return uploadFilesPage{}.Props(files, r)
+}
+
+func (uploadMethodPage) Page(s string) component { return testComponent{s} }
+
+type uploadPages struct {
+	Form   uploadFormPage   `route:"POST /form Form"`
+	Tagged uploadFilesPage  `route:"POST /tagged Tagged" form:"photos"`
+	Method uploadMethodPage `route:"POST /method Method"`
+}
+
+func multipartRequest(t *testing.T, path string, fields map[string]string, files map[string][]string) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for k, v := range fields {
+		if err := mw.WriteField(k, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for field, names := range files {
+		for _, name := range names {
+			fw, err := mw.CreateFormFile(field, name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, _ = io.WriteString(fw, "content of "+name)
+		}
+	}
+	if err := mw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodPost, path, &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return req
+}
+
+func TestMultipartInjection(t *testing.T) {
+	mux := http.NewServeMux()
+	if _, err := Mount(mux, &uploadPages{}, "/", "App", WithMultipartMaxMemory(1<<20)); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		req  *http.Request
+		want string
+	}{
+		{
+			name: "multipart.Form",
+			req:  multipartRequest(t, "/form", map[string]string{"title": "report"}, map[string][]string{"doc": {"a.txt"}}),
+			want: "title=report a.txt:content of a.txt",
+		},
+		{
+			name: "file headers by struct tag",
+			req: multipartRequest(t, "/tagged", map[string]string{"note": "hi"},
+				map[string][]string{"photos": {"1.jpg", "2.jpg"}, "other": {"x"}}),
+			want: "1.jpg,2.jpg note=hi",
+		},
+		{
+			name: "file headers by FormField method",
+			req:  multipartRequest(t, "/method", nil, map[string][]string{"photos": {"3.jpg"}}),
+			want: "3.jpg note=",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, tt.req)
+			if rec.Code != http.StatusOK || rec.Body.String() != tt.want {
+				t.Errorf("got %d %q, want 200 %q", rec.Code, rec.Body.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestMultipartInjection_BadRequest(t *testing.T) {
+	var gotErr error
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &uploadPages{}, "/", "App",
+		WithErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+			gotErr = err
+			w.WriteHeader(http.StatusTeapot)
+		}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/form", strings.NewReader("title=x"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	mux.ServeHTTP(httptest.NewRecorder(), req)
+	var httpErr *HTTPError
+	if !errors.As(gotErr, &httpErr) || httpErr.Code != http.StatusBadRequest {
+		t.Fatalf("error = %v, want *HTTPError with code 400", gotErr)
+	}
+}
+
+func TestDefaultErrorHandler_HTTPError(t *testing.T) {
+	mux := http.NewServeMux()
+	if _, err := Mount(mux, &uploadPages{}, "/", "App"); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/form", strings.NewReader("not multipart"))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+type badFormFieldPage struct{}
+
+func (badFormFieldPage) FormField(r *http.Request) string { return "x" }
+
+func (badFormFieldPage) Page() component { return testComponent{"x"} }
+
+func TestFormField_BadSignature(t *testing.T) {
+	_, err := Parse(&struct {
+		Bad badFormFieldPage `route:"/bad Bad"`
+	}{}, "/", "App")
+	if err == nil || !strings.Contains(err.Error(), "FormField method on Bad must take no arguments") {
+		t.Fatalf("err = %v, want FormField signature error", err)
+	}
+}
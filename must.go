@@ -0,0 +1,69 @@
+package structpages
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// IDOrPanic is like ID but panics if the id can't be generated. It is meant
+// for startup code where a missing component is a programming error, such
+// as building a template FuncMap.
+func (sp *StructPages) IDOrPanic(method any) string {
+	id, err := sp.ID(method)
+	if err != nil {
+		panic(fmt.Sprintf("structpages: ID(%s): %v", describeRef(method), err))
+	}
+	return id
+}
+
+// IDTargetOrPanic is like IDTarget but panics if the selector can't be
+// generated.
+func (sp *StructPages) IDTargetOrPanic(method any) string {
+	id, err := sp.IDTarget(method)
+	if err != nil {
+		panic(fmt.Sprintf("structpages: IDTarget(%s): %v", describeRef(method), err))
+	}
+	return id
+}
+
+// URLForMust is like URLFor but panics if the URL can't be built. Use it
+// only where an error can't be returned, such as template FuncMap
+// registration or package-level constants; request code should call URLFor
+// and handle the error.
+func (sp *StructPages) URLForMust(page any, args ...any) string {
+	u, err := sp.URLFor(page, args...)
+	if err != nil {
+		panic(fmt.Sprintf("structpages: URLFor(%s): %v", describeRef(page), err))
+	}
+	return u
+}
+
+// MustURLFor is the context form of URLForMust.
+func MustURLFor(ctx context.Context, page any, args ...any) string {
+	u, err := URLFor(ctx, page, args...)
+	if err != nil {
+		panic(fmt.Sprintf("structpages: URLFor(%s): %v", describeRef(page), err))
+	}
+	return u
+}
+
+// IDMust is IDOrPanic, named to pair with URLForMust.
+func (sp *StructPages) IDMust(method any) string { return sp.IDOrPanic(method) }
+
+// IDTargetMust is IDTargetOrPanic, named to pair with URLForMust.
+func (sp *StructPages) IDTargetMust(method any) string { return sp.IDTargetOrPanic(method) }
+
+// describeRef names a page or component reference for a panic message: the
+// function name of a method expression, the type of a page value, or the
+// value of a Ref or string.
+func describeRef(v any) string {
+	switch v := v.(type) {
+	case string, Ref:
+		return fmt.Sprintf("%q", v)
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Func {
+		return formatCallable(rv)
+	}
+	return fmt.Sprintf("%T", v)
+}
@@ -0,0 +1,89 @@
+package structpages
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// mustPanic runs f and returns its panic message, failing t if it doesn't
+// panic.
+func mustPanic(t *testing.T, f func()) (msg string) {
+	t.Helper()
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("did not panic")
+		}
+		msg, _ = r.(string)
+	}()
+	f()
+	return ""
+}
+
+type strayWidgetPage struct{}
+
+func (strayWidgetPage) Page() component { return testComponent{""} }
+
+func (strayWidgetPage) Widget() component { return testComponent{""} }
+
+func TestIDOrPanic(t *testing.T) {
+	sp, err := Parse(&idParamsPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if got, want := sp.IDOrPanic(idParamsUserListPage.UserList), "user-list-page-user-list"; got != want {
+		t.Errorf("IDOrPanic = %q, want %q", got, want)
+	}
+	if got, want := sp.IDTargetOrPanic(idParamsUserListPage.UserList), "#user-list-page-user-list"; got != want {
+		t.Errorf("IDTargetOrPanic = %q, want %q", got, want)
+	}
+	for name, f := range map[string]func(){
+		"IDOrPanic":       func() { sp.IDOrPanic(strayWidgetPage.Widget) },
+		"IDTargetOrPanic": func() { sp.IDTargetOrPanic(strayWidgetPage.Widget) },
+	} {
+		msg := mustPanic(t, f)
+		if !strings.Contains(msg, "strayWidgetPage.Widget") || !strings.Contains(msg, "cannot find page") {
+			t.Errorf("%s panic = %q, want the method and the cause", name, msg)
+		}
+	}
+}
+
+func TestURLForMust(t *testing.T) {
+	sp, err := Parse(&idParamsPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	want, err := sp.URLFor(idParamsUserListPage{})
+	if err != nil {
+		t.Fatalf("URLFor: %v", err)
+	}
+	if got := sp.URLForMust(idParamsUserListPage{}); got != want {
+		t.Errorf("URLForMust = %q, want %q", got, want)
+	}
+	ctx := sp.PageContext(context.Background())
+	if got := MustURLFor(ctx, idParamsUserListPage{}); got != want {
+		t.Errorf("MustURLFor = %q, want %q", got, want)
+	}
+	if got, want := sp.IDMust(idParamsUserListPage.UserList), "user-list-page-user-list"; got != want {
+		t.Errorf("IDMust = %q, want %q", got, want)
+	}
+	if got, want := sp.IDTargetMust(idParamsUserListPage.UserList), "#user-list-page-user-list"; got != want {
+		t.Errorf("IDTargetMust = %q, want %q", got, want)
+	}
+
+	for name, tc := range map[string]struct {
+		f    func()
+		want string
+	}{
+		"URLForMust":   {func() { sp.URLForMust(strayWidgetPage{}) }, "strayWidgetPage"},
+		"MustURLFor":   {func() { MustURLFor(ctx, strayWidgetPage{}) }, "strayWidgetPage"},
+		"IDMust":       {func() { sp.IDMust(strayWidgetPage.Widget) }, "strayWidgetPage.Widget"},
+		"IDTargetMust": {func() { sp.IDTargetMust(strayWidgetPage.Widget) }, "strayWidgetPage.Widget"},
+	} {
+		msg := mustPanic(t, tc.f)
+		if !strings.Contains(msg, tc.want) || !strings.Contains(msg, "no page node found") {
+			t.Errorf("%s panic = %q, want the page type and the cause", name, msg)
+		}
+	}
+}
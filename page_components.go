@@ -0,0 +1,36 @@
+package structpages
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// ListComponents returns the sorted names of the component methods of the
+// page page identifies — a page value, Ref or func(*PageNode) bool
+// predicate, as in URLFor — e.g. ["Content", "Page"]. Like URLFor it fails
+// when the type is mounted more than once, and with an error wrapping
+// ErrPageNotFound when no page matches.
+func (sp *StructPages) ListComponents(page any) ([]string, error) {
+	sp = sp.current()
+	pn, err := sp.pc.findPageNode(page)
+	if err != nil {
+		return nil, fmt.Errorf("list components: %w", err)
+	}
+	return slices.Sorted(maps.Keys(pn.Components)), nil
+}
+
+// HasComponent reports whether the page page identifies has a component
+// method called name, so a template can choose between a partial and the
+// full page:
+//
+//	{{ if .SP.HasComponent .Page "Content" }}...{{ end }}
+func (sp *StructPages) HasComponent(page any, name string) (bool, error) {
+	sp = sp.current()
+	pn, err := sp.pc.findPageNode(page)
+	if err != nil {
+		return false, fmt.Errorf("has component: %w", err)
+	}
+	_, ok := pn.Components[name]
+	return ok, nil
+}
@@ -0,0 +1,64 @@
+package structpages
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+type listComponentsPage struct{}
+
+func (listComponentsPage) Page() component    { return testComponent{"page"} }
+func (listComponentsPage) Sidebar() component { return testComponent{"sidebar"} }
+func (listComponentsPage) Content() component { return testComponent{"content"} }
+
+type listComponentsPages struct {
+	listComponentsPage `route:"/ Home"`
+}
+
+type unmountedPage struct{}
+
+func TestListComponents(t *testing.T) {
+	sp, err := Parse(&listComponentsPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	got, err := sp.ListComponents(listComponentsPage{})
+	if err != nil {
+		t.Fatalf("ListComponents: %v", err)
+	}
+	if want := []string{"Content", "Page", "Sidebar"}; !slices.Equal(got, want) {
+		t.Errorf("ListComponents = %v, want %v", got, want)
+	}
+	if _, err := sp.ListComponents(unmountedPage{}); !errors.Is(err, ErrPageNotFound) {
+		t.Errorf("ListComponents(unmounted) error = %v, want ErrPageNotFound", err)
+	}
+}
+
+func TestHasComponent(t *testing.T) {
+	sp, err := Parse(&listComponentsPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"Content", true},
+		{"Page", true},
+		{"Footer", false},
+		{"Props", false},
+	}
+	for _, tt := range tests {
+		got, err := sp.HasComponent(listComponentsPage{}, tt.name)
+		if err != nil {
+			t.Fatalf("HasComponent(%q): %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("HasComponent(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if _, err := sp.HasComponent(unmountedPage{}, "Page"); !errors.Is(err, ErrPageNotFound) {
+		t.Errorf("HasComponent(unmounted) error = %v, want ErrPageNotFound", err)
+	}
+}
@@ -0,0 +1,152 @@
+package structpages
+
+import (
+	"cmp"
+	"html/template"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// PageInfo describes a page of the tree, as returned by
+// StructPages.ListPages and StructPages.FlatPages.
+type PageInfo struct {
+	// Name and Title are the PageNode's; Method is the route method, "ALL"
+	// when the route tag has none.
+	Name, Title, Method string
+	// Route is the full path pattern, including any WithRoutePrefix.
+	Route string
+	// HasHandler reports whether the page serves requests with its own
+	// ServeHTTP method.
+	HasHandler bool
+	HasProps   bool
+	// Components lists the page's component methods, sorted.
+	Components []string
+	// Children holds the pages below this one in ListPages; it is nil in
+	// FlatPages.
+	Children []PageInfo
+	Meta     map[string]string
+}
+
+// ListPages returns the page tree as PageInfo values nested by Children,
+// roots first: the mounted root, then the roots of registered plugins.
+// Pages excluded by WithEnv are left out.
+func (sp *StructPages) ListPages() []PageInfo {
+	sp = sp.current()
+	var pages []PageInfo
+	if sp.pc != nil {
+		if info, ok := sp.pageInfo(sp.pc.root()); ok {
+			pages = append(pages, info)
+		}
+	}
+	for _, ps := range sp.plugins {
+		pages = append(pages, ps.ListPages()...)
+	}
+	return pages
+}
+
+// FlatPages returns every page ListPages does in one slice, sorted by Route
+// and then Method.
+func (sp *StructPages) FlatPages() []PageInfo {
+	sp = sp.current()
+	var flat []PageInfo
+	var add func(pages []PageInfo)
+	add = func(pages []PageInfo) {
+		for _, p := range pages {
+			children := p.Children
+			p.Children = nil
+			flat = append(flat, p)
+			add(children)
+		}
+	}
+	add(sp.ListPages())
+	slices.SortStableFunc(flat, func(a, b PageInfo) int {
+		return cmp.Or(strings.Compare(a.Route, b.Route), strings.Compare(a.Method, b.Method))
+	})
+	return flat
+}
+
+func (sp *StructPages) pageInfo(pn *PageNode) (PageInfo, bool) {
+	if !sp.inEnv(pn) {
+		return PageInfo{}, false
+	}
+	info := PageInfo{
+		Name:       pn.Name,
+		Title:      pn.Title,
+		Method:     pn.Method,
+		Route:      sp.routePrefix + pn.FullRoute(),
+		HasHandler: pn.hasServeHTTP(),
+		HasProps:   len(pn.Props) > 0,
+		Meta:       maps.Clone(pn.Meta),
+	}
+	if len(pn.Components) > 0 {
+		info.Components = slices.Sorted(maps.Keys(pn.Components))
+	}
+	for _, child := range pn.Children {
+		if c, ok := sp.pageInfo(child); ok {
+			info.Children = append(info.Children, c)
+		}
+	}
+	return info, true
+}
+
+const adminPageHTML = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Pages</title></head>
+<body>
+<h1>Pages</h1>
+<table>
+<thead><tr>
+<th>Method</th><th>Route</th><th>Name</th><th>Title</th><th>Components</th><th>Props</th><th>Handler</th>
+</tr></thead>
+<tbody>
+{{- range .}}
+<tr>
+<td>{{.Method}}</td>
+<td>{{if .Link}}<a href="{{.Link}}">{{.Route}}</a>{{else}}{{.Route}}{{end}}</td>
+<td>{{.Name}}</td><td>{{.Title}}</td><td>{{join .Components ", "}}</td><td>{{.HasProps}}</td><td>{{.HasHandler}}</td>
+</tr>
+{{- end}}
+</tbody>
+</table>
+</body>
+</html>
+`
+
+var adminPageTemplate = template.Must(template.New("admin").
+	Funcs(template.FuncMap{"join": strings.Join}).
+	Parse(adminPageHTML))
+
+// ServeAdminHandler returns a handler serving an HTML table of FlatPages,
+// for a quick look at what is mounted; keep it behind authentication:
+//
+//	mux.Handle("GET /admin/pages", requireAdmin(sp.ServeAdminHandler("")))
+//
+// GET routes without path parameters link to the page, with prefix put in
+// front of the route — the path the app is reachable under when that is not
+// the route itself.
+func (sp *StructPages) ServeAdminHandler(prefix string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		type row struct {
+			PageInfo
+			Link string
+		}
+		var rows []row
+		for _, p := range sp.FlatPages() {
+			rw := row{PageInfo: p}
+			path := strings.TrimSuffix(p.Route, "{$}")
+			if (p.Method == http.MethodGet || p.Method == methodAll) && !strings.Contains(path, "{") {
+				rw.Link = prefix + path
+			}
+			rows = append(rows, rw)
+		}
+		var sb strings.Builder
+		if err := adminPageTemplate.Execute(&sb, rows); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(sb.String()))
+	})
+}
@@ -0,0 +1,109 @@
+package structpages
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type listUserPage struct{}
+
+func (listUserPage) Page() component { return testComponent{"user"} }
+
+func (listUserPage) Edit() component { return testComponent{"edit"} }
+
+type listUsersPage struct {
+	User listUserPage `route:"/{id} User"`
+}
+
+func (listUsersPage) Page() component { return testComponent{"users"} }
+
+type listAdminPages struct {
+	Users listUsersPage `route:"/users Users" meta:"nav:Users"`
+}
+
+type listPages struct {
+	Admin  listAdminPages `route:"/admin Admin"`
+	About  aboutPage      `route:"/about About"`
+	Submit listUserPage   `route:"POST /submit Submit"`
+}
+
+type aboutPage struct{}
+
+func (aboutPage) Page() component { return testComponent{"about"} }
+
+func TestListPages(t *testing.T) {
+	sp, err := Mount(http.NewServeMux(), &listPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	roots := sp.ListPages()
+	if len(roots) != 1 || roots[0].Title != "App" {
+		t.Fatalf("ListPages roots = %+v, want the App root", roots)
+	}
+	depths := map[string]int{}
+	var walk func(pages []PageInfo, depth int)
+	walk = func(pages []PageInfo, depth int) {
+		for _, p := range pages {
+			depths[p.Name] = depth
+			walk(p.Children, depth+1)
+		}
+	}
+	walk(roots, 0)
+	for name, want := range map[string]int{"Admin": 1, "Users": 2, "User": 3, "About": 1} {
+		if depths[name] != want {
+			t.Errorf("depth of %s = %d, want %d", name, depths[name], want)
+		}
+	}
+	users := roots[0].Children[0].Children[0]
+	if users.Route != "/admin/users" || users.Meta["nav"] != "Users" || !slices.Equal(users.Components, []string{"Page"}) {
+		t.Errorf("Users = %+v", users)
+	}
+}
+
+func TestFlatPages(t *testing.T) {
+	sp, err := Mount(http.NewServeMux(), &listPages{}, "/", "App", WithRoutePrefix("/v1"))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	var routes []string
+	for _, p := range sp.FlatPages() {
+		if p.Children != nil {
+			t.Errorf("%s has Children in FlatPages", p.Name)
+		}
+		routes = append(routes, p.Method+" "+p.Route)
+	}
+	want := []string{
+		"ALL /v1/", "ALL /v1/about", "ALL /v1/admin", "ALL /v1/admin/users",
+		"ALL /v1/admin/users/{id}", "POST /v1/submit",
+	}
+	if !slices.Equal(routes, want) {
+		t.Errorf("FlatPages routes = %q, want %q", routes, want)
+	}
+}
+
+func TestServeAdminHandler(t *testing.T) {
+	sp, err := Mount(http.NewServeMux(), &listPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	sp.ServeAdminHandler("/app").ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/pages", http.NoBody))
+	if ct := rec.Header().Get("Content-Type"); ct != "text/html; charset=utf-8" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	body := rec.Body.String()
+	if !strings.HasPrefix(body, "<!DOCTYPE html>") || !strings.HasSuffix(body, "</html>\n") {
+		t.Errorf("body is not an HTML document: %q", body)
+	}
+	for _, want := range []string{
+		"<td>Admin</td>", "<td>Users</td>", "<td>User</td>", "<td>About</td>", "<td>Submit</td>",
+		`<a href="/app/about">/about</a>`, "<td>/admin/users/{id}</td>", "<td>Edit, Page</td>",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("admin page is missing %q:\n%s", want, body)
+		}
+	}
+}
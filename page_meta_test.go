@@ -0,0 +1,144 @@
+package structpages
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseMetaTag(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  string
+		want map[string]string
+	}{
+		{name: "empty", tag: "", want: nil},
+		{name: "single", tag: "auth:required", want: map[string]string{"auth": "required"}},
+		{
+			name: "multiple",
+			tag:  "auth:required,cache:0,role:admin",
+			want: map[string]string{"auth": "required", "cache": "0", "role": "admin"},
+		},
+		{
+			name: "semicolon separator and spaces",
+			tag:  "auth: required; role :admin",
+			want: map[string]string{"auth": "required", "role": "admin"},
+		},
+		{
+			name: "value containing colon",
+			tag:  "origin:https://example.com",
+			want: map[string]string{"origin": "https://example.com"},
+		},
+		{name: "flag without value", tag: "public", want: map[string]string{"public": ""}},
+		{name: "empty entries skipped", tag: ",,a:1,", want: map[string]string{"a": "1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseMetaTag(tt.tag)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("parseMetaTag(%q) mismatch (-want +got):\n%s", tt.tag, diff)
+			}
+		})
+	}
+}
+
+type metaAdminPage struct{}
+
+func (metaAdminPage) Page() component { return testComponent{"admin"} }
+
+type metaPublicPage struct{}
+
+func (metaPublicPage) Page() component { return testComponent{"public"} }
+
+type metaPages struct {
+	Admin  metaAdminPage  `route:"/admin Admin" meta:"auth:required,cache:0,role:admin"`
+	Public metaPublicPage `route:"/public Public"`
+}
+
+func TestPageNode_Meta(t *testing.T) {
+	sp, err := Parse(&metaPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	admin, err := sp.pc.findPageNode(metaAdminPage{})
+	if err != nil {
+		t.Fatalf("findPageNode admin: %v", err)
+	}
+	public, err := sp.pc.findPageNode(metaPublicPage{})
+	if err != nil {
+		t.Fatalf("findPageNode public: %v", err)
+	}
+
+	if v, ok := MetaValue(admin, "role"); !ok || v != "admin" {
+		t.Errorf("MetaValue(admin, role) = %q, %v; want %q, true", v, ok, "admin")
+	}
+	if v, ok := MetaValue(admin, "cache"); !ok || v != "0" {
+		t.Errorf("MetaValue(admin, cache) = %q, %v; want %q, true", v, ok, "0")
+	}
+	if _, ok := MetaValue(admin, "missing"); ok {
+		t.Error("MetaValue(admin, missing) reported present")
+	}
+	if public.Meta != nil {
+		t.Errorf("public.Meta = %v, want nil", public.Meta)
+	}
+	if _, ok := MetaValue(public, "auth"); ok {
+		t.Error("MetaValue(public, auth) reported present")
+	}
+	if _, ok := MetaValue(nil, "auth"); ok {
+		t.Error("MetaValue(nil, auth) reported present")
+	}
+	if got := MetaValueOr(public, "cache", "3600"); got != "3600" {
+		t.Errorf("MetaValueOr(public, cache) = %q, want default %q", got, "3600")
+	}
+	if got := MetaValueOr(admin, "cache", "3600"); got != "0" {
+		t.Errorf("MetaValueOr(admin, cache) = %q, want %q", got, "0")
+	}
+	if sp.pc.root().Meta != nil {
+		t.Errorf("root.Meta = %v, want nil", sp.pc.root().Meta)
+	}
+}
+
+// TestPageNode_Meta_AuthMiddleware demonstrates declarative access control:
+// a global middleware gates only the pages tagged meta:"auth:required".
+func TestPageNode_Meta_AuthMiddleware(t *testing.T) {
+	requireAuth := func(next http.Handler, pn *PageNode) http.Handler {
+		if MetaValueOr(pn, "auth", "") != "required" {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	mux := http.NewServeMux()
+	if _, err := Mount(mux, &metaPages{}, "/", "App", WithMiddlewares(requireAuth)); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+
+	tests := []struct {
+		path string
+		auth string
+		want int
+	}{
+		{path: "/admin", want: http.StatusUnauthorized},
+		{path: "/admin", auth: "Bearer x", want: http.StatusOK},
+		{path: "/public", want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+		if tt.auth != "" {
+			req.Header.Set("Authorization", tt.auth)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("GET %s (auth=%q): status = %d, want %d", tt.path, tt.auth, rec.Code, tt.want)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package structpages
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type renamedTeamView struct{}
+
+func (renamedTeamView) Name() string { return "TeamManagementView" }
+
+func (renamedTeamView) Page() component { return testComponent{"team"} }
+
+func (renamedTeamView) UserList() component { return testComponent{"users"} }
+
+type renamedPointerPage struct{}
+
+func (*renamedPointerPage) Name() string { return "Settings" }
+
+func (*renamedPointerPage) Page() component { return testComponent{"settings"} }
+
+type pageNamePages struct {
+	Team     renamedTeamView    `route:"/team Team"`
+	Settings renamedPointerPage `route:"/settings Settings"`
+}
+
+func TestPageNameMethod(t *testing.T) {
+	sp, err := Parse(&pageNamePages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	t.Run("used in PageNode.Name and ids", func(t *testing.T) {
+		var names []string
+		for _, c := range sp.pc.root().Children {
+			names = append(names, c.Name)
+		}
+		if got := strings.Join(names, ","); got != "TeamManagementView,Settings" {
+			t.Errorf("child names = %s, want TeamManagementView,Settings", got)
+		}
+		id, err := sp.ID(renamedTeamView.UserList)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id != "team-management-view-user-list" {
+			t.Errorf("ID = %q, want team-management-view-user-list", id)
+		}
+	})
+
+	t.Run("type-based URLFor unaffected", func(t *testing.T) {
+		for page, want := range map[any]string{renamedTeamView{}: "/team", &renamedPointerPage{}: "/settings"} {
+			got, err := sp.URLFor(page)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Errorf("URLFor(%T) = %q, want %q", page, got, want)
+			}
+		}
+	})
+}
+
+type duplicateNamePage struct{}
+
+func (duplicateNamePage) Name() string { return "TeamManagementView" }
+
+func (duplicateNamePage) Page() component { return testComponent{"dup"} }
+
+type badNamePage struct{}
+
+func (badNamePage) Name(r *http.Request) string { return "x" }
+
+func (badNamePage) Page() component { return testComponent{"x"} }
+
+func TestPageNameMethod_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		page any
+		want string
+	}{
+		{
+			name: "duplicate name",
+			page: &struct {
+				Team  renamedTeamView   `route:"/team Team"`
+				Other duplicateNamePage `route:"/other Other"`
+			}{},
+			want: `page name "TeamManagementView" returned by Name method on structpages.duplicateNamePage ` +
+				`is already used by *structpages.renamedTeamView`,
+		},
+		{
+			name: "bad signature",
+			page: &struct {
+				Bad badNamePage `route:"/bad Bad"`
+			}{},
+			want: "Name method on Bad must take no arguments and return a string",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Mount(http.NewServeMux(), tt.page, "/", "App")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
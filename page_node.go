@@ -7,22 +7,22 @@ import (
 	"net/http"
 	"path"
 	"reflect"
+	"slices"
 	"strings"
+	"time"
 )
 
 // CurrentPage returns the PageNode of the route currently being served, or
 // nil when ctx did not originate from a structpages request — for example a
 // bare context, or one wrapped only by [StructPages.PageContext] in a test.
 //
-// structpages sets this on the request context before rendering a matched
-// Props/Component page, so a handler, a Props method, or any templ component
-// they render can ask "which page am I?" without threading the node through
-// every call. The node is the matched leaf; walk [PageNode.Parent] to reach
-// its mount ancestors (e.g. shared layout chrome computing active-nav state
-// by testing whether a nav target is an ancestor of the current page).
-//
-// Pages served by their own ServeHTTP do not set this — the value is for the
-// component/Props render path.
+// structpages sets this on the request context before any middleware runs,
+// so a middleware, a ServeHTTP or Props method, or any templ component they
+// render can ask "which page am I?" without threading the node through
+// every call — e.g. to read a page's Meta for access control. The node is
+// the matched leaf; walk [PageNode.Parent] to reach its mount ancestors
+// (e.g. shared layout chrome computing active-nav state by testing whether
+// a nav target is an ancestor of the current page).
 func CurrentPage(ctx context.Context) *PageNode {
 	return currentPageCtx.Value(ctx)
 }
@@ -30,6 +30,14 @@ func CurrentPage(ctx context.Context) *PageNode {
 // PageNode represents a page in the routing tree.
 // It contains metadata about the page including its route, title, and registered methods.
 // PageNodes form a tree structure with parent-child relationships representing nested routes.
+//
+// The tree, including the Props and Components maps, is built while parsing
+// and read by request handlers concurrently without locking, so a node is
+// never changed once Mount has returned. AddChildRoute copies the tree,
+// adds the child to the copy and swaps it in; a node taken from the old
+// tree keeps showing the old children. Revert records disabled pages
+// outside the nodes, and Refresh swaps in a new tree the same way. Treat
+// it as read-only.
 type PageNode struct {
 	Name          string
 	Title         string
@@ -42,25 +50,212 @@ type PageNode struct {
 	Middlewares   *reflect.Method
 	Parent        *PageNode
 	Children      []*PageNode
+	// Meta holds the key/value pairs declared by the `meta` struct tag on
+	// the field that mounts this page, e.g. `meta:"auth:required,role:admin"`.
+	// It is nil for the root page and for fields without a meta tag.
+	Meta map[string]string
+	// Aliases are the extra routes from the page's Aliases method, relative
+	// to the parent's route like Route. The page's handler is registered at
+	// each of them too; URLFor returns the primary route and AliasURLFor an
+	// alias.
+	Aliases []string
 
 	// idPath is the kebab-cased field-name path from the root (root
 	// excluded) down to this node — the stable identity used to build
-	// element ids. Populated by parseContext.assignIDPaths.
+	// element ids. Populated by assignIDPaths.
 	idPath []string
 	// idCompactSuffix is "" when this node's leaf name is unique across
 	// the tree, otherwise "-<hash>" derived from idPath. It disambiguates
 	// the compact (leaf-only) id form used when the full path is too long.
 	idCompactSuffix string
+	// hxHistoryRestore is the page's optional HXHistoryRestore method,
+	// consulted by HTMXRenderTarget for history-restore requests.
+	hxHistoryRestore *reflect.Method
+	// formField names the multipart file field injected into
+	// []*multipart.FileHeader parameters, from the form struct tag or a
+	// FormField method.
+	formField string
+	// hxPolling is the page's optional HxPolling method, called after each
+	// render to advertise a poll interval in HX-Trigger.
+	hxPolling *reflect.Method
+	// hxSwap is the page's optional HxSwap method, called after rendering
+	// an HTMX request to set HX-Reswap.
+	hxSwap *reflect.Method
+	// hxTriggers holds the page's HXTrigger, HXTriggerAfterSwap and
+	// HXTriggerAfterSettle methods by name, called after rendering to set
+	// the HX-Trigger headers.
+	hxTriggers map[string]*reflect.Method
+	// trailers is the page's optional Trailers method, called after the
+	// body is written to send HTTP trailers.
+	trailers *reflect.Method
+	// validateQuery is the page's optional ValidateQuery method, called
+	// before Props in place of WithQueryValidator.
+	validateQuery *reflect.Method
+	// propsTimeout is the page's Props timeout from a PropsTimeout method;
+	// zero defers to WithPropsTimeout.
+	propsTimeout time.Duration
+	// maxRequestBodyBytes is the page's body size limit for
+	// RequestBodyLimitMiddleware, from a MaxRequestBodyBytes method; zero
+	// defers to the middleware's default.
+	maxRequestBodyBytes int64
+	// rateLimit is the page's rate from a RateLimit method, nil without
+	// one; see WithRateLimiting.
+	rateLimit *Rate
+	// componentChain is the page's own fallback chain from a
+	// ComponentChain method; nil defers to WithComponentFallbackChain.
+	componentChain []string
+	// environments lists the environments the page is registered in, from
+	// an Environments method; nil means all of them. See WithEnv.
+	environments []string
+	// origin is the node this one was copied from when the tree was
+	// copied, nil for an original; see key.
+	origin *PageNode
+}
+
+// key returns the node pn stands for in every copy of the tree: the
+// original it was copied from, or pn itself. State kept outside the nodes,
+// such as reverted pages, is keyed by it.
+func (pn *PageNode) key() *PageNode {
+	if pn.origin != nil {
+		return pn.origin
+	}
+	return pn
+}
+
+// MetaValue returns the value stored under key in pn.Meta and whether it
+// was present. It is safe to call with a nil pn or a page without metadata.
+//
+// Example (declarative access control in middleware):
+//
+//	func requireAuth(next http.Handler, pn *PageNode) http.Handler {
+//	    if v, _ := structpages.MetaValue(pn, "auth"); v != "required" {
+//	        return next
+//	    }
+//	    return authenticate(next)
+//	}
+func MetaValue(pn *PageNode, key string) (string, bool) {
+	if pn == nil {
+		return "", false
+	}
+	v, ok := pn.Meta[key]
+	return v, ok
+}
+
+// MetaValueOr returns the value stored under key in pn.Meta, or def when
+// the key is absent.
+func MetaValueOr(pn *PageNode, key, def string) string {
+	if v, ok := MetaValue(pn, key); ok {
+		return v
+	}
+	return def
 }
 
 // FullRoute returns the complete route path for this page node,
 // including all parent routes. For example, if a parent has route "/admin"
 // and this node has route "/users", FullRoute returns "/admin/users".
+// A trailing slash on the node's own route is kept, so "/static/" stays a
+// ServeMux subtree pattern.
 func (pn *PageNode) FullRoute() string {
 	if pn.Parent == nil {
 		return pn.Route
 	}
-	return path.Join(pn.Parent.FullRoute(), pn.Route)
+	full := path.Join(pn.Parent.FullRoute(), pn.Route)
+	if len(pn.Route) > 1 && strings.HasSuffix(pn.Route, "/") && full != "/" {
+		full += "/"
+	}
+	return full
+}
+
+// aliasRoute returns the full route of pn's alias i, joined with the
+// parent routes as FullRoute joins Route.
+func (pn *PageNode) aliasRoute(i int) string {
+	alias := pn.Aliases[i]
+	if pn.Parent == nil {
+		return alias
+	}
+	full := path.Join(pn.Parent.FullRoute(), alias)
+	if len(alias) > 1 && strings.HasSuffix(alias, "/") && full != "/" {
+		full += "/"
+	}
+	return full
+}
+
+// Depth returns the nesting level of pn in the page tree: 0 for the root,
+// 1 for its children, and so on.
+func (pn *PageNode) Depth() int {
+	d := 0
+	for p := pn.Parent; p != nil; p = p.Parent {
+		d++
+	}
+	return d
+}
+
+// Ancestors returns the nodes from the root down to pn's parent, root
+// first. It returns an empty slice for the root.
+//
+// Example (breadcrumbs):
+//
+//	for _, a := range pn.Ancestors() {
+//	    crumbs = append(crumbs, a.Title)
+//	}
+func (pn *PageNode) Ancestors() []*PageNode {
+	ancestors := make([]*PageNode, pn.Depth())
+	i := len(ancestors)
+	for p := pn.Parent; p != nil; p = p.Parent {
+		i--
+		ancestors[i] = p
+	}
+	return ancestors
+}
+
+// IsLeaf reports whether pn has no child pages.
+func (pn *PageNode) IsLeaf() bool { return len(pn.Children) == 0 }
+
+// IsRoot reports whether pn is the root of the page tree.
+func (pn *PageNode) IsRoot() bool { return pn.Parent == nil }
+
+// SiblingPages returns the pages sharing pn's parent, pn included, in the
+// order their fields are declared. The root is its own only sibling.
+func (pn *PageNode) SiblingPages() []*PageNode {
+	if pn.Parent == nil {
+		return []*PageNode{pn}
+	}
+	return slices.Clone(pn.Parent.Children)
+}
+
+// Index returns the position of pn among its siblings, 0 for the first.
+func (pn *PageNode) Index() int {
+	if pn.Parent == nil {
+		return 0
+	}
+	return slices.Index(pn.Parent.Children, pn)
+}
+
+// PrevSibling returns the sibling declared before pn, or nil if pn is the
+// first.
+//
+// Example (pagination):
+//
+//	if prev := pn.PrevSibling(); prev != nil {
+//	    // link to prev
+//	}
+func (pn *PageNode) PrevSibling() *PageNode {
+	if i := pn.Index(); i > 0 {
+		return pn.Parent.Children[i-1]
+	}
+	return nil
+}
+
+// NextSibling returns the sibling declared after pn, or nil if pn is the
+// last.
+func (pn *PageNode) NextSibling() *PageNode {
+	if pn.Parent == nil {
+		return nil
+	}
+	if i := pn.Index(); i >= 0 && i+1 < len(pn.Parent.Children) {
+		return pn.Parent.Children[i+1]
+	}
+	return nil
 }
 
 // urlTarget returns the node whose route should represent this node in a
@@ -145,3 +145,120 @@ func TestPageNode_String_edgeCases(t *testing.T) {
 		t.Error("Expected string to contain child information")
 	}
 }
+
+type depthLeafPage struct{}
+
+func (depthLeafPage) Page() component { return testComponent{"leaf"} }
+
+type depthSection struct {
+	Leaf depthLeafPage `route:"/leaf Leaf"`
+}
+
+type depthPages struct {
+	Section depthSection `route:"/section Section"`
+}
+
+func TestPageNode_Depth_Ancestors(t *testing.T) {
+	sp, err := Parse(&depthPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	root := sp.pc.root()
+	section := root.Children[0]
+	leaf := section.Children[0]
+
+	names := func(nodes []*PageNode) []string {
+		out := make([]string, len(nodes))
+		for i, n := range nodes {
+			out[i] = n.Name
+		}
+		return out
+	}
+	tests := []struct {
+		node      *PageNode
+		depth     int
+		ancestors []string
+		leaf      bool
+		root      bool
+	}{
+		{node: root, depth: 0, ancestors: []string{}, root: true},
+		{node: section, depth: 1, ancestors: []string{root.Name}},
+		{node: leaf, depth: 2, ancestors: []string{root.Name, "Section"}, leaf: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.node.Name, func(t *testing.T) {
+			if got := tt.node.Depth(); got != tt.depth {
+				t.Errorf("Depth() = %d, want %d", got, tt.depth)
+			}
+			if got := names(tt.node.Ancestors()); !reflect.DeepEqual(got, tt.ancestors) {
+				t.Errorf("Ancestors() = %v, want %v", got, tt.ancestors)
+			}
+			if got := tt.node.IsLeaf(); got != tt.leaf {
+				t.Errorf("IsLeaf() = %v, want %v", got, tt.leaf)
+			}
+			if got := tt.node.IsRoot(); got != tt.root {
+				t.Errorf("IsRoot() = %v, want %v", got, tt.root)
+			}
+		})
+	}
+}
+
+type siblingPages struct {
+	Intro    testPage `route:"/intro Intro"`
+	Setup    testPage `route:"/setup Setup"`
+	Usage    testPage `route:"/usage Usage"`
+	Appendix testPage `route:"/appendix Appendix"`
+}
+
+func TestPageNode_Siblings(t *testing.T) {
+	sp, err := Parse(&siblingPages{}, "/", "Docs")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	root := sp.pc.root()
+	order := []string{"Intro", "Setup", "Usage", "Appendix"}
+	name := func(pn *PageNode) string {
+		if pn == nil {
+			return "<nil>"
+		}
+		return pn.Name
+	}
+	for i, want := range order {
+		pn := root.Children[i]
+		t.Run(want, func(t *testing.T) {
+			if pn.Name != want {
+				t.Fatalf("Children[%d] = %s, want %s", i, pn.Name, want)
+			}
+			if got := pn.Index(); got != i {
+				t.Errorf("Index() = %d, want %d", got, i)
+			}
+			var siblings []string
+			for _, s := range pn.SiblingPages() {
+				siblings = append(siblings, s.Name)
+			}
+			if !reflect.DeepEqual(siblings, order) {
+				t.Errorf("SiblingPages() = %v, want %v", siblings, order)
+			}
+			wantPrev, wantNext := "<nil>", "<nil>"
+			if i > 0 {
+				wantPrev = order[i-1]
+			}
+			if i < len(order)-1 {
+				wantNext = order[i+1]
+			}
+			if got := name(pn.PrevSibling()); got != wantPrev {
+				t.Errorf("PrevSibling() = %s, want %s", got, wantPrev)
+			}
+			if got := name(pn.NextSibling()); got != wantNext {
+				t.Errorf("NextSibling() = %s, want %s", got, wantNext)
+			}
+		})
+	}
+
+	if root.Index() != 0 || root.PrevSibling() != nil || root.NextSibling() != nil {
+		t.Error("root should be its own only sibling")
+	}
+	if got := root.SiblingPages(); len(got) != 1 || got[0] != root {
+		t.Errorf("root.SiblingPages() = %v, want [root]", got)
+	}
+}
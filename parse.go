@@ -2,17 +2,34 @@ package structpages
 
 import (
 	"cmp"
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
 	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 type parseContext struct {
-	root           *PageNode
-	args           argRegistry
+	// tree holds the page tree. AddChildRoute replaces it with a changed
+	// copy instead of changing nodes that requests may be reading; treeMu
+	// serializes those changes.
+	tree   atomic.Pointer[pageTree]
+	treeMu sync.Mutex
+	args   argRegistry
+	// argsMu guards args (and StructPages.args) against UpdateArg and
+	// AddArg running concurrently with requests.
+	argsMu sync.RWMutex
+	// aliases redirects registry lookups for a requested type to another
+	// registered type. Set by WithDIAlias.
+	aliases map[reflect.Type]reflect.Type
+	// pageNames records names supplied by pages' Name() methods, which must
+	// be unique across the tree.
+	pageNames      map[string]*PageNode
 	segmentCache   map[string][]segment
 	segmentCacheMu sync.RWMutex
 	// urlPrefix, if non-empty, is prepended to every URL produced by URLFor.
@@ -21,34 +38,150 @@ type parseContext struct {
 	// does NOT affect route registration — that is controlled by Mount's
 	// route argument.
 	urlPrefix string
+	// routePrefix is prepended to every registered route and, below
+	// urlPrefix, to every URL. Set by WithRoutePrefix; stored normalized
+	// (leading slash, no trailing slash) or empty.
+	routePrefix string
 	// maxIDLen is the character budget for a generated element id before
 	// it degrades from the readable full-path form to the compact
 	// leaf-only form. Defaults to defaultMaxIDLen; overridable via
 	// WithMaxIDLength.
 	maxIDLen int
+	// multipartMaxMemory is passed to http.Request.ParseMultipartForm when a
+	// method asks for *multipart.Form or []*multipart.FileHeader. Set by
+	// WithMultipartMaxMemory; defaults to defaultMultipartMaxMemory.
+	multipartMaxMemory int64
+	// cookieDecoder fills CookieJar parameters and writes CookieWriter
+	// results. Set by WithCookieDecoder.
+	cookieDecoder CookieDecoder
+	// htmxHistoryDisabled turns off the HX-History-Restore-Request check in
+	// HTMXRenderTarget. Set by WithHTMXHistoryEnabled(false).
+	htmxHistoryDisabled bool
+	// componentChain is the default fallback chain for partial requests,
+	// set by WithComponentFallbackChain.
+	componentChain []string
+	// maxDepth is the deepest a page may be nested below the root, which
+	// is at depth 0. Set by WithMaxDepth; defaults to defaultMaxDepth.
+	// depth tracks the nesting level while parsing.
+	maxDepth int
+	depth    int
+	// typePath holds the struct types from the root down to the page being
+	// parsed, to detect a type that contains itself.
+	typePath []reflect.Type
+	// reverted holds the pages disabled by StructPages.Revert, guarded by
+	// revertedMu since it changes while requests are served.
+	reverted   map[*PageNode]struct{}
+	revertedMu sync.RWMutex
+	// routes maps the patterns added by StructPages.RegisterRoute to their
+	// methods, guarded by routesMu since routes are added after Mount.
+	routes   map[string]string
+	routesMu sync.RWMutex
+	// htmx holds the header names read by the HTMX target selectors, with
+	// defaults filled in. Set by WithHTMXConfig.
+	htmx HTMXConfig
+	// singletons maps page struct types to the instances given to
+	// WithSingleton.
+	singletons map[reflect.Type]reflect.Value
+	// collectErrors is set by WithCollectErrors; errs holds the errors
+	// collected while parsing.
+	collectErrors bool
+	errs          []error
+}
+
+// pageTree is one version of the page tree. Its nodes are not changed
+// once it is stored in parseContext.tree.
+type pageTree struct {
+	root *PageNode
+	// nodes maps the node each copied node was taken from (its key) to the
+	// copy in this tree, so handlers registered with an older tree's nodes
+	// can find the current one. It is nil for a tree that was never copied.
+	nodes map[*PageNode]*PageNode
+}
+
+// root returns the root of the current page tree, or nil before parsing.
+func (p *parseContext) root() *PageNode {
+	if t := p.tree.Load(); t != nil {
+		return t.root
+	}
+	return nil
+}
+
+// setRoot makes root the current page tree.
+func (p *parseContext) setRoot(root *PageNode) {
+	p.tree.Store(&pageTree{root: root})
+}
+
+// currentNode returns the node standing for pn in the current page tree:
+// pn itself, or its copy if AddChildRoute has copied the tree since pn's
+// handler was registered.
+func (p *parseContext) currentNode(pn *PageNode) *PageNode {
+	if t := p.tree.Load(); t != nil && t.nodes != nil {
+		if c, ok := t.nodes[pn.key()]; ok {
+			return c
+		}
+	}
+	return pn
 }
 
 func parsePageTree(route string, page any, args ...any) (*parseContext, error) {
+	pc, err := newParseContext(args...)
+	if err != nil {
+		return nil, err
+	}
+	if err := pc.parseRoot(route, page); err != nil {
+		return nil, err
+	}
+	return pc, nil
+}
+
+// newParseContext returns an empty parse context with the given DI args
+// registered, ready for parseRoot.
+func newParseContext(args ...any) (*parseContext, error) {
 	pc := &parseContext{
 		args:         make(map[reflect.Type]reflect.Value),
 		segmentCache: make(map[string][]segment),
 		maxIDLen:     defaultMaxIDLen,
+		maxDepth:     defaultMaxDepth,
+		htmx:         HTMXConfig{}.withDefaults(),
+
+		multipartMaxMemory: defaultMultipartMaxMemory,
 	}
 	for _, v := range args {
+		if a, ok := v.(diAlias); ok {
+			if err := pc.addAlias(a); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if c, ok := v.(configFile); ok {
+			if err := pc.addConfigFile(c); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if _, ok := v.(perRequestArg); ok {
+			continue
+		}
 		if err := pc.args.addArg(v); err != nil {
 			return nil, fmt.Errorf("error adding argument to registry: %w", err)
 		}
 	}
-	topNode, err := pc.parsePageTree(route, "", page)
-	if err != nil {
-		return nil, err
+	return pc, nil
+}
+
+// parseRoot parses the page tree rooted at page into p.
+func (p *parseContext) parseRoot(route string, page any) error {
+	topNode, err := p.parsePageTree(route, "", page)
+	if p.collectErrors && (err != nil || len(p.errs) > 0) {
+		_ = p.fail(err)
+		return &MultiError{Errors: p.errs}
 	}
-	pc.root = topNode
-	pc.assignIDPaths()
-	if err := pc.checkIDUniqueness(); err != nil {
-		return nil, err
+	if err != nil {
+		return err
 	}
-	return pc, nil
+	assignIDPaths(topNode)
+	p.setRoot(topNode)
+	return nil
 }
 
 func (p *parseContext) parsePageTree(route, fieldName string, page any) (*PageNode, error) {
@@ -61,8 +194,14 @@ func (p *parseContext) parsePageTree(route, fieldName string, page any) (*PageNo
 		return nil, err
 	}
 
+	p.typePath = append(p.typePath, st)
+	defer func() { p.typePath = p.typePath[:len(p.typePath)-1] }()
+
 	item := &PageNode{Value: reflect.ValueOf(page), Name: cmp.Or(fieldName, st.Name())}
 	item.Method, item.Route, item.Title = parseTag(route)
+	if err := p.applyPageName(st, pt, item); err != nil {
+		return nil, err
+	}
 
 	// Parse child fields
 	if err := p.parseChildFields(st, item); err != nil {
@@ -71,12 +210,49 @@ func (p *parseContext) parsePageTree(route, fieldName string, page any) (*PageNo
 
 	// Process methods
 	if err := p.processMethods(st, pt, item); err != nil {
+		if fieldName != "" {
+			err = mapErrors(err, func(err error) error { return fmt.Errorf("field %s: %w", fieldName, err) })
+		}
 		return nil, err
 	}
 
 	return item, nil
 }
 
+// applyPageName replaces item.Name with the result of the page's own
+// Name() string method, if it has one, so ids survive a struct or field
+// rename. The method is a pure name provider: it gets no injected arguments.
+func (p *parseContext) applyPageName(st, pt reflect.Type, item *PageNode) error {
+	for _, t := range []reflect.Type{st, pt} {
+		method, ok := t.MethodByName("Name")
+		if !ok || isPromotedMethod(&method) {
+			continue
+		}
+		if method.Type.NumIn() != 1 || method.Type.NumOut() != 1 || method.Type.Out(0).Kind() != reflect.String {
+			return fmt.Errorf("Name method on %s must take no arguments and return a string", item.Name)
+		}
+		v, err := p.prepareReceiver(item.Value, &method)
+		if err != nil {
+			return err
+		}
+		name := method.Func.Call([]reflect.Value{v})[0].String()
+		if name == "" {
+			return fmt.Errorf("Name method on %s returned an empty name", item.Name)
+		}
+		if other, ok := p.pageNames[name]; ok {
+			return fmt.Errorf("page name %q returned by Name method on %s is already used by %s",
+				name, st.String(), other.Value.Type().String())
+		}
+		if p.pageNames == nil {
+			p.pageNames = make(map[string]*PageNode)
+		}
+		p.pageNames[name] = item
+		item.Name = name
+		return nil
+	}
+	return nil
+}
+
 // getStructAndPointerTypes extracts struct and pointer types from a page
 func getStructAndPointerTypes(page any) (structType, pointerType reflect.Type, err error) {
 	st := reflect.TypeOf(page) // struct type
@@ -97,41 +273,98 @@ func getStructAndPointerTypes(page any) (structType, pointerType reflect.Type, e
 
 // parseChildFields parses child fields with route tags
 func (p *parseContext) parseChildFields(st reflect.Type, item *PageNode) error {
+	p.depth++
+	defer func() { p.depth-- }()
 	for i := range st.NumField() {
 		field := st.Field(i)
 		route, ok := field.Tag.Lookup("route")
 		if !ok {
 			continue
 		}
-		typ := field.Type
-		if typ.Kind() == reflect.Pointer {
-			typ = typ.Elem()
+		if err := p.checkCycle(field.Type); err != nil {
+			if err := p.fail(fmt.Errorf("page %s: field %s: %w", item.Name, field.Name, err)); err != nil {
+				return err
+			}
+			continue
+		}
+		if p.depth > p.maxDepth {
+			err := fmt.Errorf("page %s: field %s is at depth %d, deeper than the limit of %d (see WithMaxDepth)",
+				item.Name, field.Name, p.depth, p.maxDepth)
+			if err := p.fail(err); err != nil {
+				return err
+			}
+			continue
+		}
+		childPage, ok := p.singletonFor(field.Type)
+		if !ok {
+			childPage = newChildPage(item.Value, i, field)
 		}
-		childPage := reflect.New(typ)
 		childItem, err := p.parsePageTree(route, field.Name, childPage.Interface())
 		if err != nil {
-			return err
+			if err := p.fail(err); err != nil {
+				return err
+			}
+			continue
 		}
 		childItem.Parent = item
+		childItem.Meta = parseMetaTag(field.Tag.Get("meta"))
+		if form := field.Tag.Get("form"); form != "" {
+			childItem.formField = form
+		}
 		item.Children = append(item.Children, childItem)
 	}
 	return nil
 }
 
-// processMethods processes all methods of the page
+// newChildPage returns a pointer to a new, zeroed page for field i of the
+// parent value. The one exception is a FileServerPage field, which keeps
+// the value set in the page literal passed to Mount so the page gets its
+// FS.
+func newChildPage(parent reflect.Value, i int, field reflect.StructField) reflect.Value {
+	typ := field.Type
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	child := reflect.New(typ)
+	if typ != fileServerPageType || !field.IsExported() {
+		return child
+	}
+	if parent.Kind() == reflect.Pointer {
+		if parent.IsNil() {
+			return child
+		}
+		parent = parent.Elem()
+	}
+	fv := parent.Field(i)
+	if fv.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			return child
+		}
+		fv = fv.Elem()
+	}
+	child.Elem().Set(fv)
+	return child
+}
+
+// processMethods processes all methods of the page. With WithCollectErrors
+// it carries on past a failing method and returns the errors joined.
 func (p *parseContext) processMethods(st, pt reflect.Type, item *PageNode) error {
+	var errs []error
 	for _, t := range []reflect.Type{st, pt} {
 		for i := range t.NumMethod() {
 			method := t.Method(i)
-			if isPromotedMethod(&method) {
+			if isPromotedMethod(&method) || isEmbeddedMethod(st, &method) {
 				continue // skip promoted methods
 			}
 			if err := p.processMethod(item, &method); err != nil {
-				return err
+				if !p.collectErrors {
+					return err
+				}
+				errs = append(errs, err)
 			}
 		}
 	}
-	return nil
+	return errors.Join(errs...)
 }
 
 // processMethod processes a single method
@@ -155,21 +388,137 @@ func (p *parseContext) processMethod(item *PageNode, method *reflect.Method) err
 	switch method.Name {
 	case "Middlewares":
 		item.Middlewares = method
+	case "HXHistoryRestore":
+		if method.Type.NumOut() != 1 || method.Type.Out(0) != renderTargetType {
+			return fmt.Errorf("HXHistoryRestore method on %s must return a single structpages.RenderTarget", item.Name)
+		}
+		item.hxHistoryRestore = method
+	case "HxPolling":
+		if method.Type.NumOut() != 1 || method.Type.Out(0) != durationType {
+			return fmt.Errorf("HxPolling method on %s must return a single time.Duration", item.Name)
+		}
+		item.hxPolling = method
+	case "HxSwap":
+		if method.Type.NumOut() != 1 || method.Type.Out(0).Kind() != reflect.String {
+			return fmt.Errorf("HxSwap method on %s must return a single string", item.Name)
+		}
+		item.hxSwap = method
+	case "HXTrigger", "HXTriggerAfterSwap", "HXTriggerAfterSettle":
+		if method.Type.NumOut() != 2 || method.Type.Out(0) != hxEventsType || method.Type.Out(1) != errorType {
+			return fmt.Errorf("%s method on %s must return ([]structpages.HXEvent, error)", method.Name, item.Name)
+		}
+		if item.hxTriggers == nil {
+			item.hxTriggers = make(map[string]*reflect.Method)
+		}
+		item.hxTriggers[method.Name] = method
+	case "Trailers":
+		if method.Type.NumOut() != 2 || method.Type.Out(0) != httpHeaderType || method.Type.Out(1) != errorType {
+			return fmt.Errorf("Trailers method on %s must return (http.Header, error)", item.Name)
+		}
+		item.trailers = method
+	case "ValidateQuery":
+		if method.Type.NumOut() != 1 || method.Type.Out(0) != errorType {
+			return fmt.Errorf("ValidateQuery method on %s must return a single error", item.Name)
+		}
+		item.validateQuery = method
+	case "FormField":
+		if method.Type.NumIn() != 1 || method.Type.NumOut() != 1 || method.Type.Out(0).Kind() != reflect.String {
+			return fmt.Errorf("FormField method on %s must take no arguments and return a string", item.Name)
+		}
+		res, err := p.callMethod(item, method)
+		if err != nil {
+			return fmt.Errorf("error calling FormField method on %s: %w", item.Name, err)
+		}
+		item.formField = res[0].String()
+	case "ComponentChain":
+		if method.Type.NumIn() != 1 || method.Type.NumOut() != 1 ||
+			method.Type.Out(0) != reflect.TypeFor[[]string]() {
+			return fmt.Errorf("ComponentChain method on %s must take no arguments and return []string", item.Name)
+		}
+		res, err := p.callMethod(item, method)
+		if err != nil {
+			return fmt.Errorf("error calling ComponentChain method on %s: %w", item.Name, err)
+		}
+		item.componentChain = res[0].Interface().([]string)
+	case "Environments":
+		if method.Type.NumIn() != 1 || method.Type.NumOut() != 1 ||
+			method.Type.Out(0) != reflect.TypeFor[[]string]() {
+			return fmt.Errorf("Environments method on %s must take no arguments and return []string", item.Name)
+		}
+		res, err := p.callMethod(item, method)
+		if err != nil {
+			return fmt.Errorf("error calling Environments method on %s: %w", item.Name, err)
+		}
+		item.environments = res[0].Interface().([]string)
+		if item.environments == nil {
+			item.environments = []string{}
+		}
+	case "Aliases":
+		if method.Type.NumIn() != 1 || method.Type.NumOut() != 1 ||
+			method.Type.Out(0) != reflect.TypeFor[[]string]() {
+			return fmt.Errorf("Aliases method on %s must take no arguments and return []string", item.Name)
+		}
+		res, err := p.callMethod(item, method)
+		if err != nil {
+			return fmt.Errorf("error calling Aliases method on %s: %w", item.Name, err)
+		}
+		item.Aliases = res[0].Interface().([]string)
+		for _, alias := range item.Aliases {
+			if !strings.HasPrefix(alias, "/") {
+				return fmt.Errorf("alias %q of %s must start with /", alias, item.Name)
+			}
+		}
+	case "PropsTimeout":
+		if method.Type.NumIn() != 1 || method.Type.NumOut() != 1 || method.Type.Out(0) != durationType {
+			return fmt.Errorf("PropsTimeout method on %s must take no arguments and return a time.Duration", item.Name)
+		}
+		res, err := p.callMethod(item, method)
+		if err != nil {
+			return fmt.Errorf("error calling PropsTimeout method on %s: %w", item.Name, err)
+		}
+		item.propsTimeout = time.Duration(res[0].Int())
+	case "MaxRequestBodyBytes":
+		if method.Type.NumIn() != 1 || method.Type.NumOut() != 1 || method.Type.Out(0).Kind() != reflect.Int64 {
+			return fmt.Errorf("MaxRequestBodyBytes method on %s must take no arguments and return an int64", item.Name)
+		}
+		res, err := p.callMethod(item, method)
+		if err != nil {
+			return fmt.Errorf("error calling MaxRequestBodyBytes method on %s: %w", item.Name, err)
+		}
+		item.maxRequestBodyBytes = res[0].Int()
+	case "RateLimit":
+		if method.Type.NumIn() != 1 || method.Type.NumOut() != 1 || method.Type.Out(0) != rateType {
+			return fmt.Errorf("RateLimit method on %s must take no arguments and return a structpages.Rate", item.Name)
+		}
+		res, err := p.callMethod(item, method)
+		if err != nil {
+			return fmt.Errorf("error calling RateLimit method on %s: %w", item.Name, err)
+		}
+		rate := res[0].Interface().(Rate)
+		item.rateLimit = &rate
 	case "Init":
 		return p.callInitMethod(item, method)
+	case "InitContext":
+		if method.Type.NumIn() < 2 || method.Type.In(1) != contextType {
+			return fmt.Errorf("InitContext method on %s must take a context.Context as its first argument", item.Name)
+		}
+		return p.callInitMethod(item, method, reflect.ValueOf(context.Background()))
 	}
 	return nil
 }
 
-// callInitMethod calls the Init method and handles errors
-func (p *parseContext) callInitMethod(item *PageNode, method *reflect.Method) error {
-	res, err := p.callMethod(item, method)
+var contextType = reflect.TypeFor[context.Context]()
+
+// callInitMethod calls the Init or InitContext method, injecting args and
+// the registered DI args, and handles errors
+func (p *parseContext) callInitMethod(item *PageNode, method *reflect.Method, args ...reflect.Value) error {
+	res, err := p.callMethod(item, method, args...)
 	if err != nil {
-		return fmt.Errorf("error calling Init method on %s: %w", item.Name, err)
+		return fmt.Errorf("error calling %s method on %s: %w", method.Name, item.Name, err)
 	}
 	res, err = extractError(res)
 	if err != nil {
-		return fmt.Errorf("error calling Init method on %s: %w", item.Name, err)
+		return fmt.Errorf("error calling %s method on %s: %w", method.Name, item.Name, err)
 	}
 	_ = res
 	return nil
@@ -194,7 +543,7 @@ func (p *parseContext) callMethod(
 	in[0] = v // first argument is the receiver
 
 	// Fill remaining arguments
-	if err := p.fillMethodArgs(in, method, availableArgs); err != nil {
+	if err := p.fillMethodArgs(pn, in, method, availableArgs); err != nil {
 		return nil, err
 	}
 
@@ -244,6 +593,7 @@ func (p *parseContext) buildAvailableArgs(pn *PageNode, args []reflect.Value) ma
 
 // fillMethodArgs fills the method arguments using type matching
 func (p *parseContext) fillMethodArgs(
+	pn *PageNode,
 	in []reflect.Value,
 	method *reflect.Method,
 	availableArgs map[reflect.Type][]reflect.Value,
@@ -253,16 +603,53 @@ func (p *parseContext) fillMethodArgs(
 	for i := 1; i < method.Type.NumIn(); i++ {
 		argType := method.Type.In(i)
 
+		if arg, ok := pathParamsArg(pn, argType, availableArgs); ok {
+			in[i] = arg
+			continue
+		}
+		if arg, ok := requestPartArg(argType, availableArgs); ok {
+			in[i] = arg
+			continue
+		}
+		if arg, ok, err := p.multipartArg(pn, argType, availableArgs); err != nil {
+			return fmt.Errorf("method %s: %w", formatMethod(method), err)
+		} else if ok {
+			in[i] = arg
+			continue
+		}
+		if arg, ok, err := p.cookieJarArg(argType, availableArgs); err != nil {
+			return fmt.Errorf("method %s: %w", formatMethod(method), err)
+		} else if ok {
+			in[i] = arg
+			continue
+		}
+
 		// Try to find a matching argument
-		arg, found := p.findMatchingArg(argType, availableArgs, usedArgs)
+		arg, found, err := p.findMatchingArg(argType, availableArgs, usedArgs)
+		if err != nil {
+			return fmt.Errorf("method %s: %w", formatMethod(method), err)
+		}
 		if found {
 			in[i] = arg
 			continue
 		}
 
+		// Request-scoped args (WithScopedArg) win over the global registry
+		val, err := p.resolveScoped(argType, availableArgs)
+		if err != nil {
+			return fmt.Errorf("method %s: %w", formatMethod(method), err)
+		}
+		if val.IsValid() {
+			in[i] = val
+			continue
+		}
+
 		// If not found in available args, try the registry
-		val, ok := p.args.getArg(argType)
-		if !ok {
+		val, err = p.resolveRegistered(argType)
+		if err != nil {
+			return fmt.Errorf("method %s: %w", formatMethod(method), err)
+		}
+		if !val.IsValid() {
 			return fmt.Errorf("method %s requires argument of type %s, but not found",
 				formatMethod(method), argType.String())
 		}
@@ -271,35 +658,68 @@ func (p *parseContext) fillMethodArgs(
 	return nil
 }
 
-// findMatchingArg tries to find a matching argument from available args
+// findMatchingArg tries to find a matching argument from available args.
+// An exact type match wins; otherwise interface parameters are filled by
+// the unique type implementing them that still has an unused value, then
+// by any assignable type.
+// Candidates are visited in a stable order so resolution is deterministic.
 func (p *parseContext) findMatchingArg(
 	argType reflect.Type,
 	availableArgs map[reflect.Type][]reflect.Value,
 	usedArgs map[reflect.Value]bool,
-) (reflect.Value, bool) {
-	// First try exact type match
-	if candidates, ok := availableArgs[argType]; ok {
-		for _, candidate := range candidates {
+) (reflect.Value, bool, error) {
+	take := func(t reflect.Type) (reflect.Value, bool) {
+		for _, candidate := range availableArgs[t] {
 			if !usedArgs[candidate] {
 				usedArgs[candidate] = true
 				return candidate, true
 			}
 		}
+		return reflect.Value{}, false
 	}
 
-	// Try assignable types
-	for availType, candidates := range availableArgs {
-		if availType.AssignableTo(argType) {
-			for _, candidate := range candidates {
-				if !usedArgs[candidate] {
-					usedArgs[candidate] = true
-					return candidate, true
+	// First try exact type match
+	if v, ok := take(argType); ok {
+		return v, true, nil
+	}
+
+	// unusedWhere returns the types keep accepts that still have an unused
+	// value, sorted; only the matches are, as this runs per request.
+	unusedWhere := func(keep func(reflect.Type) bool) []reflect.Type {
+		var types []reflect.Type
+		for t, candidates := range availableArgs {
+			if !keep(t) {
+				continue
+			}
+			for _, c := range candidates {
+				if !usedArgs[c] {
+					types = append(types, t)
+					break
 				}
 			}
 		}
+		sortTypes(types)
+		return types
 	}
 
-	return reflect.Value{}, false
+	if argType.Kind() == reflect.Interface {
+		candidates := implementingTypes(unusedWhere(func(t reflect.Type) bool { return t.Implements(argType) }), argType)
+		if len(candidates) > 1 {
+			return reflect.Value{}, false, ambiguousArgError(argType, candidates)
+		}
+		if len(candidates) == 1 {
+			v, _ := take(candidates[0])
+			return v, true, nil
+		}
+	}
+
+	// Try assignable types
+	if types := unusedWhere(func(t reflect.Type) bool { return t.AssignableTo(argType) }); len(types) > 0 {
+		v, _ := take(types[0])
+		return v, true, nil
+	}
+
+	return reflect.Value{}, false, nil
 }
 
 func (p *parseContext) callComponentMethod(pn *PageNode, method *reflect.Method,
@@ -328,37 +748,47 @@ func (p *parseContext) urlFor(v any) (string, error) {
 }
 
 func (p *parseContext) findPageNode(v any) (*PageNode, error) {
+	return p.lookupPageNode(v, true)
+}
+
+// lookupPageNode implements findPageNode. With liveOnly set, pages disabled
+// by StructPages.Revert are not found.
+func (p *parseContext) lookupPageNode(v any, liveOnly bool) (*PageNode, error) {
 	if v == nil {
 		return nil, fmt.Errorf("URLFor: page argument is nil")
 	}
 
 	// Handle Ref type for dynamic page references
 	if ref, ok := v.(Ref); ok {
-		return p.findPageNodeByRef(string(ref))
+		node, err := p.findPageNodeByRef(string(ref))
+		if err == nil && liveOnly && p.isReverted(node) {
+			return nil, fmt.Errorf("page %s is reverted: %w", node.Name, ErrPageNotFound)
+		}
+		return node, err
 	}
 
 	// Handle predicate function for custom matching
 	if f, ok := v.(func(*PageNode) bool); ok {
-		for node := range p.root.All() {
-			if f(node) {
+		for node := range p.root().All() {
+			if f(node) && !(liveOnly && p.isReverted(node)) {
 				return node, nil
 			}
 		}
-		return nil, fmt.Errorf("no page matched the provided predicate function")
+		return nil, fmt.Errorf("no page matched the provided predicate function: %w", ErrPageNotFound)
 	}
 
 	// Handle static type reference
 	ptv := pointerType(reflect.TypeOf(v))
 	var matches []*PageNode
-	for node := range p.root.All() {
+	for node := range p.root().All() {
 		pt := pointerType(node.Value.Type())
-		if ptv == pt {
+		if ptv == pt && !(liveOnly && p.isReverted(node)) {
 			matches = append(matches, node)
 		}
 	}
 	switch len(matches) {
 	case 0:
-		return nil, fmt.Errorf("no page node found for type %s", ptv.String())
+		return nil, fmt.Errorf("no page node found for type %s: %w", ptv.String(), ErrPageNotFound)
 	case 1:
 		return matches[0], nil
 	default:
@@ -412,11 +842,20 @@ func (p *parseContext) resolveParts(parts []any) (string, error) {
 	fragments := parts[chainEnd:]
 
 	var pattern string
+	// A Ref naming a RegisterRoute pattern resolves to the pattern itself.
+	if len(chain) == 1 {
+		if ref, ok := chain[0].(Ref); ok && p.hasRoute(string(ref)) {
+			pattern, chain = string(ref), nil
+		}
+	}
 	if len(chain) > 0 {
 		node, err := p.resolveChain(chain)
 		if err != nil {
 			return "", err
 		}
+		if p.isReverted(node) {
+			return "", fmt.Errorf("page %s is reverted: %w", node.Name, ErrPageNotFound)
+		}
 		// When the chain is the whole specification, resolve a subtree
 		// container to its index child so the URL carries the canonical
 		// trailing slash. If string fragments follow, the caller is
@@ -527,7 +966,7 @@ func (p *parseContext) descendByType(parent *PageNode, step any) (*PageNode, err
 func (p *parseContext) findPageNodeByRef(ref string) (*PageNode, error) {
 	if strings.HasPrefix(ref, "/") {
 		// Match by route
-		for node := range p.root.All() {
+		for node := range p.root().All() {
 			if node.FullRoute() == ref {
 				return node, nil
 			}
@@ -540,7 +979,7 @@ func (p *parseContext) findPageNodeByRef(ref string) (*PageNode, error) {
 	}
 
 	// Match by page name
-	for node := range p.root.All() {
+	for node := range p.root().All() {
 		if node.Name == ref {
 			return node, nil
 		}
@@ -565,11 +1004,12 @@ func (p *parseContext) findPageNodeByQualifiedRef(ref string) (*PageNode, error)
 	// Anchor: match the first segment by Name. Prefer a top-level match
 	// (the root or its direct children) so existing "Parent.Field" refs
 	// resolve exactly as before and "Root.Foo" works explicitly.
+	root := p.root()
 	var current *PageNode
-	if p.root.Name == segments[0] {
-		current = p.root
+	if root.Name == segments[0] {
+		current = root
 	} else {
-		for _, c := range p.root.Children {
+		for _, c := range root.Children {
 			if c.Name == segments[0] {
 				current = c
 				break
@@ -582,7 +1022,7 @@ func (p *parseContext) findPageNodeByQualifiedRef(ref string) (*PageNode, error)
 	// More than one match is ambiguous — error rather than silently pick.
 	if current == nil {
 		var matches []*PageNode
-		for node := range p.root.All() {
+		for node := range root.All() {
 			if node.Name == segments[0] {
 				matches = append(matches, node)
 			}
@@ -681,6 +1121,31 @@ func parseTag(route string) (method, path, title string) {
 	return
 }
 
+// parseMetaTag parses a `meta` struct tag of the form "key:value,key2:value2"
+// into a map. Entries may also be separated by ";". An entry without a ":"
+// is stored with an empty value, so `meta:"public"` yields {"public": ""}.
+// Returns nil for an empty tag.
+func parseMetaTag(tag string) map[string]string {
+	if strings.TrimSpace(tag) == "" {
+		return nil
+	}
+	meta := make(map[string]string)
+	entries := strings.FieldsFunc(tag, func(r rune) bool { return r == ',' || r == ';' })
+	for _, entry := range entries {
+		kv := strings.SplitN(entry, ":", 2)
+		key := strings.TrimSpace(kv[0])
+		if key == "" {
+			continue
+		}
+		var value string
+		if len(kv) == 2 {
+			value = strings.TrimSpace(kv[1])
+		}
+		meta[key] = value
+	}
+	return meta
+}
+
 const methodAll = "ALL"
 
 var validMethod = []string{
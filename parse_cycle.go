@@ -0,0 +1,36 @@
+package structpages
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// CyclicRouteError reports a page type that contains itself through its
+// route fields, directly or through other pages, which would make the page
+// tree infinite. Cycle lists the types from the repeated one back to it.
+type CyclicRouteError struct {
+	Cycle []reflect.Type
+}
+
+func (e *CyclicRouteError) Error() string {
+	names := make([]string, len(e.Cycle))
+	for i, t := range e.Cycle {
+		names[i] = t.String()
+	}
+	return "cyclic page tree: " + strings.Join(names, " → ")
+}
+
+// checkCycle returns a *CyclicRouteError if a route field of type t would
+// mount a page type that is already being parsed above it. The same type
+// mounted in two sibling subtrees is not a cycle.
+func (p *parseContext) checkCycle(t reflect.Type) error {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	i := slices.Index(p.typePath, t)
+	if i < 0 {
+		return nil
+	}
+	return &CyclicRouteError{Cycle: append(slices.Clone(p.typePath[i:]), t)}
+}
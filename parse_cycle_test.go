@@ -0,0 +1,70 @@
+package structpages
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type cycleA struct {
+	B cycleB `route:"/b B"`
+}
+
+type cycleB struct {
+	cycleC `route:"/c C"`
+}
+
+type cycleC struct {
+	A *cycleA `route:"/a A"`
+}
+
+type selfEmbedPage struct {
+	*selfEmbedPage `route:"/self Self"`
+}
+
+type diamondShared struct{}
+
+func (diamondShared) Page() component { return testComponent{"shared"} }
+
+type diamondLeft struct {
+	Shared diamondShared `route:"/shared Shared"`
+}
+
+type diamondRight struct {
+	Shared diamondShared `route:"/shared Shared"`
+}
+
+type diamondPages struct {
+	Left  diamondLeft  `route:"/left Left"`
+	Right diamondRight `route:"/right Right"`
+}
+
+func TestCyclicRouteError(t *testing.T) {
+	tests := []struct {
+		name string
+		page any
+		want string
+	}{
+		{"three types", &cycleA{}, "structpages.cycleA → structpages.cycleB → structpages.cycleC → structpages.cycleA"},
+		{"self embedding", &selfEmbedPage{}, "structpages.selfEmbedPage → structpages.selfEmbedPage"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Mount(http.NewServeMux(), tt.page, "/", "App")
+			var cycleErr *CyclicRouteError
+			if !errors.As(err, &cycleErr) {
+				t.Fatalf("Mount error = %v, want a *CyclicRouteError", err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Mount error = %q, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+
+	t.Run("diamond", func(t *testing.T) {
+		if _, err := Mount(http.NewServeMux(), &diamondPages{}, "/", "App"); err != nil {
+			t.Errorf("Mount: %v", err)
+		}
+	})
+}
@@ -2,11 +2,17 @@ package structpages
 
 import (
 	"context"
+	"errors"
 )
 
+// ErrNotMounted is returned, or given to the error handler by ServeHTTP,
+// when a StructPages from Parse or Validate is used to serve requests
+// before its Mount method has registered the pages.
+var ErrNotMounted = errors.New("structpages: not mounted")
+
 // Parse builds a page tree from page without registering any HTTP
 // routes. The returned *StructPages exposes URLFor, ID, IDTarget,
-// and PageContext, but never touches a mux.
+// Export, ListPages and PageContext, but never touches a mux.
 //
 // Use Parse for tests and tooling that need URL/ID resolution
 // against the real page tree but don't want to spin up a server.
@@ -15,8 +21,10 @@ import (
 //
 // Options behave identically to Mount: WithArgs, WithURLPrefix,
 // WithErrorHandler, etc. all apply. WithMiddlewares and other
-// mux-affecting options are accepted but never observed (no
-// handlers are wired).
+// mux-affecting options are accepted but not observed until the
+// tree is registered with the Mount method, which can be called
+// later on the returned *StructPages. Until then ServeHTTP and
+// Handle fail with ErrNotMounted.
 //
 // Example (test):
 //
@@ -25,20 +33,7 @@ import (
 //	ctx := sp.PageContext(context.Background())
 //	body := renderTo(ctx, List{}.Page(props))
 func Parse(page any, route, title string, options ...Option) (*StructPages, error) {
-	sp := &StructPages{
-		targetSelector: HTMXRenderTarget,
-	}
-	for _, opt := range options {
-		opt(sp)
-	}
-	pc, err := parsePageTree(route, page, sp.args...)
-	if err != nil {
-		return nil, err
-	}
-	pc.root.Title = title
-	pc.urlPrefix = sp.urlPrefix
-	sp.pc = pc
-	return sp, nil
+	return newStructPages(page, route, title, options)
 }
 
 // PageContext returns a context derived from ctx with sp's page
@@ -54,5 +49,6 @@ func Parse(page any, route, title string, options ...Option) (*StructPages, erro
 //	ctx := sp.PageContext(context.Background())
 //	html := mustRender(ctx, MyPage{}.Page(props))
 func (sp *StructPages) PageContext(ctx context.Context) context.Context {
+	sp = sp.current()
 	return pcCtx.WithValue(ctx, sp.pc)
 }
@@ -2,6 +2,8 @@ package structpages_test
 
 import (
 	"context"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -138,3 +140,69 @@ func TestPageContext_EnablesURLForOnBareContext(t *testing.T) {
 		t.Errorf("URLFor = %q, want %q", got, "/items/7")
 	}
 }
+
+// textComponent renders a fixed string.
+type textComponent string
+
+func (c textComponent) Render(_ context.Context, w io.Writer) error {
+	_, err := io.WriteString(w, string(c))
+	return err
+}
+
+type parsePubWidget struct{}
+
+func (parsePubWidget) Page() structpages.Component { return textComponent("widget") }
+
+func (parsePubWidget) Panel() structpages.Component { return textComponent("panel") }
+
+type parsePubWidgets struct {
+	Widget parsePubWidget `route:"/widget Widget"`
+}
+
+// TestParse_MountLater covers the Parse-then-Mount lifecycle: the parsed
+// instance resolves URLs and ids and lists its pages, refuses to serve
+// with ErrNotMounted, and serves normally once Mount registers it.
+func TestParse_MountLater(t *testing.T) {
+	var served error
+	sp, err := structpages.Parse(parsePubWidgets{}, "/", "Test",
+		structpages.WithErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+			served = err
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		}))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if got, err := sp.URLFor(parsePubWidget{}); err != nil || got != "/widget" {
+		t.Errorf("URLFor = %q, %v; want /widget", got, err)
+	}
+	if got, err := sp.IDFor(parsePubWidget.Panel); err != nil || got != "#widget-panel" {
+		t.Errorf("IDFor = %q, %v; want #widget-panel", got, err)
+	}
+	if pages := sp.FlatPages(); len(pages) != 2 || pages[1].Name != "Widget" {
+		t.Errorf("FlatPages = %+v, want the root and Widget", pages)
+	}
+
+	rec := httptest.NewRecorder()
+	sp.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/widget", http.NoBody))
+	if !errors.Is(served, structpages.ErrNotMounted) || rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("ServeHTTP before Mount = %d, error %v; want ErrNotMounted", rec.Code, served)
+	}
+	health := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { _, _ = io.WriteString(w, "ok") })
+	if err := sp.Handle("/healthz", health); !errors.Is(err, structpages.ErrNotMounted) {
+		t.Errorf("Handle before Mount = %v, want ErrNotMounted", err)
+	}
+
+	if err := sp.Mount(http.NewServeMux()); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	if err := sp.Handle("/healthz", health); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	for path, want := range map[string]string{"/widget": "widget", "/healthz": "ok"} {
+		rec = httptest.NewRecorder()
+		sp.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+		if rec.Code != http.StatusOK || rec.Body.String() != want {
+			t.Errorf("GET %s after Mount = %d %q, want %q", path, rec.Code, rec.Body.String(), want)
+		}
+	}
+}
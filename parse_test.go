@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -150,10 +151,10 @@ func TestParseSimple(t *testing.T) {
 	if err != nil {
 		t.Fatalf("parsePageTree failed: %v", err)
 	}
-	if pc.root == nil {
+	if pc.root() == nil {
 		t.Fatal("parsePageTree returned nil")
 	}
-	s := pc.root.String()
+	s := pc.root().String()
 	if s == "" {
 		t.Fatal("Page tree string representation is empty")
 	}
@@ -168,7 +169,7 @@ func Test_pc_UrlFor(t *testing.T) {
 	if err != nil {
 		t.Fatalf("parsePageTree failed: %v", err)
 	}
-	if pc.root == nil {
+	if pc.root() == nil {
 		t.Fatal("parsePageTree returned nil")
 	}
 	// Two fields of the same type — always strict, so URLFor errors.
@@ -278,8 +279,8 @@ func TestParsePageTree_skipFields(t *testing.T) {
 	}
 
 	// Should have one child for the exported field with route tag
-	if len(pc.root.Children) != 1 {
-		t.Errorf("Expected 1 child, got %d", len(pc.root.Children))
+	if len(pc.root().Children) != 1 {
+		t.Errorf("Expected 1 child, got %d", len(pc.root().Children))
 	}
 }
 
@@ -683,3 +684,40 @@ func TestProcessMethod_initWithMissingArg(t *testing.T) {
 		t.Errorf("Expected error about missing string argument, got: %v", err)
 	}
 }
+
+type literalConfigPage struct {
+	Label string
+	Hits  *int
+}
+
+func (literalConfigPage) Page() component { return testComponent{"x"} }
+
+func TestParsePageTree_pageLiteralFields(t *testing.T) {
+	t.Run("pages start zeroed", func(t *testing.T) {
+		hits := 0
+		pc, err := parsePageTree("/", &struct {
+			Docs *literalConfigPage `route:"/docs Docs"`
+		}{Docs: &literalConfigPage{Label: "docs", Hits: &hits}})
+		if err != nil {
+			t.Fatalf("parsePageTree: %v", err)
+		}
+		if got := pc.root().Children[0].Value.Interface().(*literalConfigPage); got.Label != "" || got.Hits != nil {
+			t.Errorf("child = %+v, want the zero value", got)
+		}
+	})
+
+	t.Run("FileServerPage keeps its FS", func(t *testing.T) {
+		fsys := fstest.MapFS{}
+		literal := &FileServerPage{FS: fsys}
+		pc, err := parsePageTree("/", &struct {
+			Assets *FileServerPage `route:"/static/ Assets"`
+		}{Assets: literal})
+		if err != nil {
+			t.Fatalf("parsePageTree: %v", err)
+		}
+		got := pc.root().Children[0].Value.Interface().(*FileServerPage)
+		if got == literal || got.FS == nil {
+			t.Errorf("child = %p %+v, want a copy of %p with its FS", got, got, literal)
+		}
+	})
+}
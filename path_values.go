@@ -0,0 +1,144 @@
+package structpages
+
+import (
+	"encoding"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// PathValue returns the path wildcard name of r converted to T. Strings,
+// booleans, integer and floating-point kinds (including named types built
+// on them), and types implementing encoding.TextUnmarshaler are supported.
+//
+//	id, err := structpages.PathValue[int64](r, "id")
+func PathValue[T any](r *http.Request, name string) (T, error) {
+	var zero T
+	raw := r.PathValue(name)
+	if raw == "" {
+		return zero, fmt.Errorf("path value %s: empty", name)
+	}
+	v, err := convertPathValue[T](raw)
+	if err != nil {
+		return zero, fmt.Errorf("path value %s: %w", name, err)
+	}
+	return v, nil
+}
+
+// PathValues splits the path wildcard name of r on sep and converts each
+// element to T as PathValue does. It is meant for routes such as
+// `/compare/{ids}` requested as /compare/1,2,3:
+//
+//	ids, err := structpages.PathValues[int](r, "ids", ",")
+//
+// An empty value is an error. URLFor produces such URLs from a slice
+// argument, joining the escaped elements with commas.
+func PathValues[T any](r *http.Request, name, sep string) ([]T, error) {
+	raw := r.PathValue(name)
+	if raw == "" {
+		return nil, fmt.Errorf("path value %s: empty list", name)
+	}
+	parts := strings.Split(raw, sep)
+	values := make([]T, len(parts))
+	for i, part := range parts {
+		v, err := convertPathValue[T](part)
+		if err != nil {
+			return nil, fmt.Errorf("path value %s[%d]: %w", name, i, err)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+func convertPathValue[T any](s string) (T, error) {
+	var v T
+	rv := reflect.ValueOf(&v).Elem()
+	if reflect.PointerTo(rv.Type()).Implements(textUnmarshalerType) {
+		err := rv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+		return v, err
+	}
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return v, err
+		}
+		rv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, rv.Type().Bits())
+		if err != nil {
+			return v, err
+		}
+		rv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, rv.Type().Bits())
+		if err != nil {
+			return v, err
+		}
+		rv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, rv.Type().Bits())
+		if err != nil {
+			return v, err
+		}
+		rv.SetFloat(f)
+	default:
+		return v, fmt.Errorf("unsupported type %s", rv.Type())
+	}
+	return v, nil
+}
+
+// PathParams holds the path wildcard values of the current request by name,
+// for every wildcard in the page's full route, ancestors' included. A Props
+// (or other injected) method receives it by declaring a PathParams
+// parameter:
+//
+//	// route:"/orgs/{org}/users/{id}"
+//	func (p userPage) Props(params structpages.PathParams, db *DB) (User, error) {
+//	    return db.User(params.MustGet("org"), params.MustGet("id"))
+//	}
+type PathParams map[string]string
+
+var pathParamsType = reflect.TypeOf(PathParams(nil))
+
+// Get returns the value of the wildcard key and whether the route has it.
+func (p PathParams) Get(key string) (string, bool) {
+	v, ok := p[key]
+	return v, ok
+}
+
+// MustGet returns the value of the wildcard key, panicking when the route
+// has no such wildcard.
+func (p PathParams) MustGet(key string) string {
+	v, ok := p[key]
+	if !ok {
+		panic(fmt.Sprintf("structpages: route has no path parameter %q", key))
+	}
+	return v
+}
+
+// pathParamsArg fills a PathParams parameter from the request among
+// availableArgs. ok is false when argType is not PathParams or no request is
+// available.
+func pathParamsArg(
+	pn *PageNode, argType reflect.Type, availableArgs map[reflect.Type][]reflect.Value,
+) (reflect.Value, bool) {
+	reqs := availableArgs[requestPointerType]
+	if argType != pathParamsType || len(reqs) == 0 || pn == nil {
+		return reflect.Value{}, false
+	}
+	r := reqs[0].Interface().(*http.Request)
+	params := PathParams{}
+	for _, seg := range pn.getRouteSegments() {
+		if seg.param {
+			params[seg.name] = r.PathValue(seg.name)
+		}
+	}
+	return reflect.ValueOf(params), true
+}
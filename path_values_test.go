@@ -0,0 +1,216 @@
+package structpages
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// requestWithPathValue returns a request as routed by ServeMux for pattern.
+func requestWithPathValue(t *testing.T, pattern, path string) *http.Request {
+	t.Helper()
+	var got *http.Request
+	mux := http.NewServeMux()
+	mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) { got = r })
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, http.NoBody))
+	if got == nil {
+		t.Fatalf("%s did not match %s", path, pattern)
+	}
+	return got
+}
+
+func TestPathValues(t *testing.T) {
+	t.Run("int slice", func(t *testing.T) {
+		r := requestWithPathValue(t, "/compare/{ids}", "/compare/1,2,30")
+		got, err := PathValues[int](r, "ids", ",")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]int{1, 2, 30}, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("string slice", func(t *testing.T) {
+		r := requestWithPathValue(t, "/compare/{ids...}", "/compare/a,b%2Fc,d")
+		got, err := PathValues[string](r, "ids", ",")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{"a", "b/c", "d"}, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("single element", func(t *testing.T) {
+		r := requestWithPathValue(t, "/compare/{ids}", "/compare/7")
+		got, err := PathValues[uint8](r, "ids", ",")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]uint8{7}, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("empty list", func(t *testing.T) {
+		r := requestWithPathValue(t, "/compare/{ids...}", "/compare/")
+		if _, err := PathValues[int](r, "ids", ","); err == nil || !strings.Contains(err.Error(), "empty list") {
+			t.Errorf("err = %v, want empty list error", err)
+		}
+	})
+
+	t.Run("conversion error names the element", func(t *testing.T) {
+		r := requestWithPathValue(t, "/compare/{ids}", "/compare/1,x")
+		if _, err := PathValues[int](r, "ids", ","); err == nil || !strings.Contains(err.Error(), "ids[1]") {
+			t.Errorf("err = %v, want error for ids[1]", err)
+		}
+	})
+
+	t.Run("custom separator and TextUnmarshaler", func(t *testing.T) {
+		r := requestWithPathValue(t, "/hosts/{addrs}", "/hosts/10.0.0.1;10.0.0.2")
+		got, err := PathValues[netip.Addr](r, "addrs", ";")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []netip.Addr{netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("10.0.0.2")}
+		if len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+}
+
+func TestPathValue(t *testing.T) {
+	r := requestWithPathValue(t, "/items/{id}/{flag}", "/items/42/true")
+	if id, err := PathValue[int64](r, "id"); err != nil || id != 42 {
+		t.Errorf("PathValue[int64](id) = %v, %v; want 42", id, err)
+	}
+	if flag, err := PathValue[bool](r, "flag"); err != nil || !flag {
+		t.Errorf("PathValue[bool](flag) = %v, %v; want true", flag, err)
+	}
+	if _, err := PathValue[int](r, "missing"); err == nil {
+		t.Error("PathValue(missing) succeeded, want error")
+	}
+	if _, err := PathValue[struct{}](r, "id"); err == nil || !strings.Contains(err.Error(), "unsupported type") {
+		t.Errorf("PathValue[struct{}] err = %v, want unsupported type", err)
+	}
+}
+
+type compareItemsPage struct{}
+
+func (compareItemsPage) Page() component { return testComponent{"compare"} }
+
+type comparePages struct {
+	Compare compareItemsPage `route:"/compare/{ids} Compare"`
+}
+
+func TestURLFor_SliceArgJoinsWithCommas(t *testing.T) {
+	sp, err := Parse(&comparePages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	tests := []struct {
+		name string
+		args []any
+		want string
+	}{
+		{name: "positional strings", args: []any{[]string{"a", "b", "c"}}, want: "/compare/a,b,c"},
+		{name: "map ints", args: []any{map[string]any{"ids": []int{1, 2}}}, want: "/compare/1,2"},
+		{name: "elements escaped", args: []any{[]string{"x y", "a,b"}}, want: "/compare/x%20y,a%2Cb"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sp.URLFor(compareItemsPage{}, tt.args...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("URLFor = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// Round trip through PathValues.
+	r := requestWithPathValue(t, "/compare/{ids}", "/compare/1,2")
+	ids, err := PathValues[int](r, "ids", ",")
+	if err != nil || len(ids) != 2 {
+		t.Errorf("PathValues round trip = %v, %v", ids, err)
+	}
+}
+
+type pathParamsDB struct{ prefix string }
+
+type pathParamsUser struct{}
+
+func (pathParamsUser) Props(db *pathParamsDB, params PathParams, r *http.Request) (string, error) {
+	return db.prefix + " " + params.MustGet("org") + "/" + params.MustGet("id") + " " + r.Method, nil
+}
+
+func (pathParamsUser) Page(s string) component { return testComponent{s} }
+
+type pathParamsOrg struct {
+	User pathParamsUser `route:"/users/{id} User"`
+}
+
+type pathParamsFile struct{}
+
+func (pathParamsFile) Props(params PathParams) (PathParams, error) { return params, nil }
+
+func (pathParamsFile) Page(p PathParams) component {
+	path, ok := p.Get("path")
+	_, missing := p.Get("id")
+	return testComponent{fmt.Sprintf("%q %v %v", path, ok, missing)}
+}
+
+type pathParamsItem struct{}
+
+func (pathParamsItem) Props(params PathParams) (string, error) { return params.MustGet("id"), nil }
+func (pathParamsItem) Page(s string) component                 { return testComponent{s} }
+
+type pathParamsPages struct {
+	Item  pathParamsItem `route:"/items/{id} Item"`
+	Org   pathParamsOrg  `route:"/orgs/{org} Org"`
+	Files pathParamsFile `route:"/files/{path...} Files"`
+}
+
+func TestPathParams(t *testing.T) {
+	mux := http.NewServeMux()
+	sp, err := Validate(&pathParamsPages{}, "/", "App", WithArgs(&pathParamsDB{prefix: "user"}))
+	if err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if err := sp.Mount(mux); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	tests := []struct {
+		name, path, want string
+	}{
+		{"one param", "/items/42", "42"},
+		{"ancestor and own params, any argument order", "/orgs/acme/users/7", "user acme/7 GET"},
+		{"wildcard", "/files/css/app.css", `"css/app.css" true false`},
+		{"empty wildcard", "/files/", `"" true false`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+			if rec.Code != http.StatusOK || rec.Body.String() != tt.want {
+				t.Errorf("GET %s = %d %q, want %q", tt.path, rec.Code, rec.Body.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestPathParams_MustGetMissing(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("MustGet of a missing key did not panic")
+		}
+	}()
+	PathParams{"id": "1"}.MustGet("org")
+}
@@ -0,0 +1,88 @@
+package structpages
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"reflect"
+)
+
+// perRequestArg is a PerRequest factory carried in StructPages.args.
+type perRequestArg struct {
+	typ reflect.Type
+	new func(*http.Request) (any, error)
+}
+
+// PerRequest returns a dependency injection argument for WithArgs whose
+// value is created anew for every request, for request-scoped resources
+// such as a database transaction:
+//
+//	structpages.WithArgs(structpages.PerRequest(func(r *http.Request) (*sql.Tx, error) {
+//	    return db.BeginTx(r.Context(), nil)
+//	}))
+//
+// fn is called before the page's middlewares run and its value is injected
+// like one set with WithScopedArg. If the value implements io.Closer it is
+// closed after the handler returns. An error from fn is passed to the error
+// handler and the page is not served. PerRequest values are not available
+// to Init methods, which run before any request.
+func PerRequest[T any](fn func(r *http.Request) (T, error)) any {
+	return perRequestArg{
+		typ: reflect.TypeFor[T](),
+		new: func(r *http.Request) (any, error) { return fn(r) },
+	}
+}
+
+// perRequestArgs returns the PerRequest factories given to WithArgs.
+func (sp *StructPages) perRequestArgs() []perRequestArg {
+	var factories []perRequestArg
+	for _, arg := range sp.args {
+		if f, ok := arg.(perRequestArg); ok {
+			factories = append(factories, f)
+		}
+	}
+	return factories
+}
+
+// perRequestTypes returns the types the PerRequest factories create, for
+// Validate.
+func (sp *StructPages) perRequestTypes() []reflect.Type {
+	var types []reflect.Type
+	for _, f := range sp.perRequestArgs() {
+		types = append(types, f.typ)
+	}
+	return types
+}
+
+// perRequestMiddleware creates the PerRequest values of sp for each request,
+// or returns nil if there are none.
+func (sp *StructPages) perRequestMiddleware() MiddlewareFunc {
+	factories := sp.perRequestArgs()
+	if len(factories) == 0 {
+		return nil
+	}
+	return func(next http.Handler, pn *PageNode) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			values := make([]any, 0, len(factories))
+			defer func() {
+				for i := len(values) - 1; i >= 0; i-- {
+					if c, ok := values[i].(io.Closer); ok {
+						if err := c.Close(); err != nil {
+							log.Printf("structpages: closing per-request %T for %s: %v", values[i], pn.Name, err)
+						}
+					}
+				}
+			}()
+			for _, f := range factories {
+				v, err := f.new(r)
+				if err != nil {
+					sp.onError(w, r, fmt.Errorf("per-request %s for %s: %w", f.typ, pn.Name, err))
+					return
+				}
+				values = append(values, v)
+			}
+			next.ServeHTTP(w, r.WithContext(WithScopedArg(r.Context(), values...)))
+		})
+	}
+}
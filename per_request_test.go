@@ -0,0 +1,115 @@
+package structpages
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type reqTx struct {
+	id     int64
+	closed atomic.Bool
+}
+
+func (tx *reqTx) Close() error {
+	tx.closed.Store(true)
+	return nil
+}
+
+type reqTxPage struct{}
+
+func (reqTxPage) Props(tx *reqTx) (string, error) { return fmt.Sprint(tx.id), nil }
+
+func (reqTxPage) Page(s string) component { return testComponent{s} }
+
+type reqTxHandlerPage struct{}
+
+func (reqTxHandlerPage) ServeHTTP(w http.ResponseWriter, _ *http.Request, tx *reqTx) {
+	_, _ = fmt.Fprint(w, tx.id)
+}
+
+type reqTxPages struct {
+	Home reqTxPage        `route:"/{$} Home"`
+	Raw  reqTxHandlerPage `route:"/raw Raw"`
+}
+
+func TestPerRequest(t *testing.T) {
+	var (
+		mu  sync.Mutex
+		txs []*reqTx
+		ids atomic.Int64
+	)
+	newTx := func(r *http.Request) (*reqTx, error) {
+		if r.URL.Query().Has("fail") {
+			return nil, errors.New("begin failed")
+		}
+		tx := &reqTx{id: ids.Add(1)}
+		mu.Lock()
+		txs = append(txs, tx)
+		mu.Unlock()
+		return tx, nil
+	}
+	var handled error
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &reqTxPages{}, "/", "App",
+		WithArgs(PerRequest(newTx)),
+		WithErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+			handled = err
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	if _, err := Validate(&reqTxPages{}, "/", "App", WithArgs(PerRequest(newTx))); err != nil {
+		t.Errorf("Validate with a PerRequest-only type: %v", err)
+	}
+
+	const n = 20
+	bodies := make([]string, n)
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			path := "/"
+			if i%2 == 1 {
+				path = "/raw"
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+			bodies[i] = rec.Body.String()
+		}()
+	}
+	wg.Wait()
+
+	seen := map[string]bool{}
+	for _, body := range bodies {
+		if body == "" || seen[body] {
+			t.Errorf("request bodies %v: want a distinct instance per request", bodies)
+			break
+		}
+		seen[body] = true
+	}
+	if len(txs) != n {
+		t.Errorf("factory ran %d times, want %d", len(txs), n)
+	}
+	for _, tx := range txs {
+		if !tx.closed.Load() {
+			t.Errorf("tx %d was not closed after its request", tx.id)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?fail", http.NoBody))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("failing factory: status = %d, want the error handler's 503", rec.Code)
+	}
+	if handled == nil || !strings.Contains(handled.Error(), "begin failed") {
+		t.Errorf("error handler got %v, want the factory error", handled)
+	}
+}
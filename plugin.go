@@ -0,0 +1,107 @@
+package structpages
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+// Plugin is a page tree packaged for mounting into an application it does
+// not import. Pages returns the root page, mounted at Route with Title and
+// configured by Options on top of the host's options.
+type Plugin interface {
+	Pages() any
+	Route() string
+	Title() string
+	Options() []Option
+}
+
+// RegisterPlugin mounts p's page tree onto mux next to sp's own pages. The
+// plugin gets sp's options and DI args, as with Group, followed by
+// p.Options(). Its pages are found by sp's URLFor, and listed by Export,
+// alongside sp's:
+//
+//	if err := sp.RegisterPlugin(mux, billing.Plugin{}); err != nil {
+//	    return err
+//	}
+//	sp.URLFor(billing.InvoicesPage{}) // "/billing/invoices"
+//
+// A route conflicting with one of sp's or an earlier plugin's is an error,
+// and nothing is registered. Pages of the plugin resolve URLFor and IDs
+// against the plugin's own tree at request time. Register plugins before
+// serving requests.
+func (sp *StructPages) RegisterPlugin(mux Mux, p Plugin) error {
+	sp.checkWritable()
+	sp = sp.current()
+	if sp.pc == nil {
+		return errors.New("structpages: RegisterPlugin called on a StructPages without a page tree")
+	}
+	ps := sp.Group("", p.Options()...)
+	if err := ps.parse(p.Pages(), p.Route(), p.Title()); err != nil {
+		return fmt.Errorf("plugin %s: %w", p.Title(), err)
+	}
+	if err := routeConflicts(append(sp.trees(), ps)...); err != nil {
+		return fmt.Errorf("plugin %s: %w", p.Title(), err)
+	}
+	if mux == nil {
+		mux = sp.mux
+	}
+	if err := ps.Mount(mux); err != nil {
+		return fmt.Errorf("plugin %s: %w", p.Title(), err)
+	}
+	sp.plugins = append(sp.plugins, ps)
+	return nil
+}
+
+// LoadPlugins registers plugins in order with RegisterPlugin, stopping at
+// the first error.
+func (sp *StructPages) LoadPlugins(mux Mux, plugins []Plugin) error {
+	for _, p := range plugins {
+		if err := sp.RegisterPlugin(mux, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// trees returns sp followed by its plugins.
+func (sp *StructPages) trees() []*StructPages {
+	return append([]*StructPages{sp}, sp.plugins...)
+}
+
+// routeConflicts registers the pages of every tree on one scratch
+// http.ServeMux and returns the conflicts.
+func routeConflicts(trees ...*StructPages) error {
+	mux := http.NewServeMux()
+	var errs []error
+	for _, t := range trees {
+		errs = append(errs, t.registerPatterns(mux, func(*PageNode, string) http.Handler {
+			return http.NotFoundHandler()
+		}))
+	}
+	return errors.Join(errs...)
+}
+
+// pluginURLFor resolves page against sp's plugins, returning err, the
+// failure from sp's own tree, when none of them has it.
+func (sp *StructPages) pluginURLFor(page any, args []any, err error) (string, error) {
+	if !errors.Is(err, ErrPageNotFound) {
+		return "", err
+	}
+	for _, ps := range sp.plugins {
+		if url, perr := ps.URLFor(page, args...); perr == nil {
+			return url, nil
+		}
+	}
+	return "", err
+}
+
+// pluginExports returns Export of each of sp's plugins, in order.
+func (sp *StructPages) pluginExports() []RouteExport {
+	var routes []RouteExport
+	for _, ps := range sp.plugins {
+		routes = slices.Concat(routes, ps.Export())
+	}
+	return routes
+}
@@ -0,0 +1,107 @@
+package structpages
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type pluginGreeting string
+
+type pluginHostPage struct{}
+
+func (pluginHostPage) Page() component { return testComponent{"host"} }
+
+type pluginHostPages struct {
+	Home pluginHostPage `route:"/{$} Home"`
+}
+
+type billingPage struct{}
+
+func (billingPage) Props(g pluginGreeting) (string, error) { return string(g) + " billing", nil }
+
+func (billingPage) Page(s string) component { return testComponent{s} }
+
+type billingPages struct {
+	Invoices billingPage `route:"/invoices Invoices"`
+}
+
+type blogPage struct{}
+
+func (blogPage) Page() component { return testComponent{"blog"} }
+
+type blogPages struct {
+	Posts blogPage `route:"/posts/{slug} Post"`
+}
+
+// testPlugin is a Plugin built from its fields.
+type testPlugin struct {
+	pages any
+	route string
+	opts  []Option
+}
+
+func (p testPlugin) Pages() any        { return p.pages }
+func (p testPlugin) Route() string     { return p.route }
+func (p testPlugin) Title() string     { return strings.Trim(p.route, "/") }
+func (p testPlugin) Options() []Option { return p.opts }
+
+func TestLoadPlugins(t *testing.T) {
+	mux := http.NewServeMux()
+	sp, err := Mount(mux, pluginHostPages{}, "/", "App", WithArgs(pluginGreeting("hello")))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	err = sp.LoadPlugins(mux, []Plugin{
+		testPlugin{pages: billingPages{}, route: "/billing"},
+		testPlugin{pages: blogPages{}, route: "/blog"},
+	})
+	if err != nil {
+		t.Fatalf("LoadPlugins: %v", err)
+	}
+
+	for path, want := range map[string]string{"/": "host", "/billing/invoices": "hello billing", "/blog/posts/a": "blog"} {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+		if rec.Body.String() != want {
+			t.Errorf("GET %s = %q, want %q", path, rec.Body.String(), want)
+		}
+	}
+
+	if url, err := sp.URLFor(billingPage{}); err != nil || url != "/billing/invoices" {
+		t.Errorf("URLFor(billingPage) = %q, %v; want /billing/invoices", url, err)
+	}
+	if url, err := sp.URLFor(blogPage{}, "hi"); err != nil || url != "/blog/posts/hi" {
+		t.Errorf("URLFor(blogPage) = %q, %v; want /blog/posts/hi", url, err)
+	}
+	if url, err := sp.URLFor(pluginHostPage{}); err != nil || url != "/" {
+		t.Errorf("URLFor(pluginHostPage) = %q, %v; want /", url, err)
+	}
+
+	var patterns []string
+	for _, r := range sp.Export() {
+		patterns = append(patterns, r.Pattern)
+	}
+	if got, want := strings.Join(patterns, " "), "/{$} /billing/invoices /blog/posts/{slug}"; got != want {
+		t.Errorf("Export patterns = %s, want %s", got, want)
+	}
+}
+
+func TestRegisterPlugin_Conflict(t *testing.T) {
+	mux := http.NewServeMux()
+	sp, err := Mount(mux, pluginHostPages{}, "/", "App", WithArgs(pluginGreeting("hello")))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	if err := sp.RegisterPlugin(mux, testPlugin{pages: billingPages{}, route: "/billing"}); err != nil {
+		t.Fatalf("RegisterPlugin: %v", err)
+	}
+	err = sp.RegisterPlugin(mux, testPlugin{pages: billingPages{}, route: "/billing"})
+	if err == nil || !strings.Contains(err.Error(), "/billing/invoices") {
+		t.Fatalf("RegisterPlugin with a taken route = %v, want a conflict error", err)
+	}
+	if n := len(sp.Export()); n != 2 {
+		t.Errorf("Export has %d routes after the failed registration, want 2", n)
+	}
+}
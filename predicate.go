@@ -0,0 +1,49 @@
+package structpages
+
+import "strings"
+
+// PredicateByRoute matches the page whose FullRoute equals pattern, e.g.
+// "/products/{id}". Use it with URLFor when the same page type is mounted in
+// several places.
+func PredicateByRoute(pattern string) func(*PageNode) bool {
+	return func(pn *PageNode) bool { return pn.FullRoute() == pattern }
+}
+
+// PredicateByTitle matches pages whose Title contains the given substring.
+func PredicateByTitle(contains string) func(*PageNode) bool {
+	return func(pn *PageNode) bool { return strings.Contains(pn.Title, contains) }
+}
+
+// PredicateByMeta matches pages whose `meta` tag sets key to value.
+func PredicateByMeta(key, value string) func(*PageNode) bool {
+	return func(pn *PageNode) bool {
+		v, ok := MetaValue(pn, key)
+		return ok && v == value
+	}
+}
+
+// PredicateAnd matches pages satisfying every predicate. With no predicates
+// it matches every page.
+func PredicateAnd(preds ...func(*PageNode) bool) func(*PageNode) bool {
+	return func(pn *PageNode) bool {
+		for _, pred := range preds {
+			if !pred(pn) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// PredicateOr matches pages satisfying at least one predicate. With no
+// predicates it matches nothing.
+func PredicateOr(preds ...func(*PageNode) bool) func(*PageNode) bool {
+	return func(pn *PageNode) bool {
+		for _, pred := range preds {
+			if pred(pn) {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,65 @@
+package structpages
+
+import (
+	"strings"
+	"testing"
+)
+
+type predicateProductPage struct{}
+
+func (predicateProductPage) Page() component { return testComponent{"product"} }
+
+type predicatePages struct {
+	ProductV1 predicateProductPage `route:"/v1/products/{id} Product (legacy)" meta:"version:v1"`
+	ProductV2 predicateProductPage `route:"/products/{id} Product" meta:"version:v2"`
+	Beta      predicateProductPage `route:"/beta/products/{id} Product beta" meta:"version:v2,beta:true"`
+}
+
+func TestPredicates(t *testing.T) {
+	sp, err := Parse(&predicatePages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	tests := []struct {
+		name string
+		pred func(*PageNode) bool
+		want string
+	}{
+		{name: "by route", pred: PredicateByRoute("/products/{id}"), want: "/products/7"},
+		{name: "by title", pred: PredicateByTitle("legacy"), want: "/v1/products/7"},
+		{name: "by meta", pred: PredicateByMeta("beta", "true"), want: "/beta/products/7"},
+		{
+			name: "and",
+			pred: PredicateAnd(PredicateByTitle("Product"), PredicateByMeta("version", "v2")),
+			want: "/products/7",
+		},
+		{
+			name: "or",
+			pred: PredicateOr(PredicateByRoute("/nope"), PredicateByMeta("version", "v1")),
+			want: "/v1/products/7",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sp.URLFor(tt.pred, "7")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("URLFor = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	for name, pred := range map[string]func(*PageNode) bool{
+		"no match":     PredicateAnd(PredicateByRoute("/products/{id}"), PredicateByMeta("version", "v1")),
+		"missing meta": PredicateByMeta("owner", ""),
+		"empty or":     PredicateOr(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := sp.URLFor(pred, "7"); err == nil || !strings.Contains(err.Error(), "no page matched") {
+				t.Errorf("err = %v, want no page matched error", err)
+			}
+		})
+	}
+}
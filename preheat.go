@@ -0,0 +1,118 @@
+package structpages
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jackielii/ctxkey"
+)
+
+var preheatCtx = ctxkey.New[bool]("structpages.preheat", false)
+
+// WithPreheat makes Mount serve one synthetic GET request to every page
+// whose route has no path parameters, once all routes are registered, so
+// lazy initialization, template compilation and buffer pools are warm
+// before real traffic arrives. The requests go through the mux, with all
+// middlewares, using ctx as their base context; IsPreheat reports true
+// for them. Failures (a panic or a 5xx status) are logged and do not fail
+// Mount. Bound the total time with WithPreheatTimeout.
+func WithPreheat(ctx context.Context) func(*StructPages) {
+	return func(r *StructPages) {
+		r.preheatCtx = ctx
+	}
+}
+
+// WithPreheatTimeout limits the total time WithPreheat spends serving its
+// requests; pages not reached in time are skipped.
+func WithPreheatTimeout(d time.Duration) func(*StructPages) {
+	return func(r *StructPages) {
+		r.preheatTimeout = d
+	}
+}
+
+// IsPreheat reports whether ctx belongs to a request made by WithPreheat,
+// so a page can skip side effects such as analytics or writes.
+func IsPreheat(ctx context.Context) bool {
+	return preheatCtx.Value(ctx)
+}
+
+// preheat serves the WithPreheat requests on mux.
+func (sp *StructPages) preheat(mux Mux) {
+	if sp.preheatCtx == nil {
+		return
+	}
+	h, ok := mux.(http.Handler)
+	if !ok {
+		log.Printf("structpages: preheat skipped: mux %T does not implement http.Handler", mux)
+		return
+	}
+	ctx := preheatCtx.WithValue(sp.preheatCtx, true)
+	if sp.preheatTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, sp.preheatTimeout)
+		defer cancel()
+	}
+	for pn := range sp.pc.root().All() {
+		if ctx.Err() != nil {
+			log.Printf("structpages: preheat stopped: %v", ctx.Err())
+			return
+		}
+		if pn.Route == "" || !pn.routable() || !sp.inEnv(pn) ||
+			(pn.Method != http.MethodGet && pn.Method != methodAll) {
+			continue
+		}
+		path := sp.routePrefix + strings.Replace(pn.FullRoute(), "{$}", "", 1)
+		if strings.Contains(path, "{") {
+			continue
+		}
+		preheatOne(ctx, h, pn, path)
+	}
+}
+
+func preheatOne(ctx context.Context, h http.Handler, pn *PageNode, path string) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("structpages: preheat %s (%s): panic: %v", path, pn.Name, r)
+		}
+	}()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, path, http.NoBody)
+	if err != nil {
+		log.Printf("structpages: preheat %s (%s): %v", path, pn.Name, err)
+		return
+	}
+	w := &preheatWriter{}
+	h.ServeHTTP(w, req)
+	if w.status >= http.StatusInternalServerError {
+		log.Printf("structpages: preheat %s (%s): status %d", path, pn.Name, w.status)
+	}
+}
+
+// preheatWriter discards a preheat response, keeping only its status.
+type preheatWriter struct {
+	header http.Header
+	status int
+}
+
+func (w *preheatWriter) Header() http.Header {
+	if w.header == nil {
+		w.header = http.Header{}
+	}
+	return w.header
+}
+
+func (w *preheatWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+}
+
+func (w *preheatWriter) Write(b []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	return len(b), nil
+}
+
+// Flush lets streaming pages preheat as they would serve.
+func (w *preheatWriter) Flush() {}
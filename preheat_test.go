@@ -0,0 +1,115 @@
+package structpages
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type preheatCounters struct {
+	once      sync.Once
+	inits     atomic.Int32
+	preheated atomic.Int32
+}
+
+type preheatHomePage struct{}
+
+func (preheatHomePage) Props(r *http.Request, c *preheatCounters) (string, error) {
+	c.once.Do(func() { c.inits.Add(1) })
+	if IsPreheat(r.Context()) {
+		c.preheated.Add(1)
+	}
+	return "home", nil
+}
+
+func (preheatHomePage) Page(s string) component { return testComponent{s} }
+
+type preheatItemPage struct{}
+
+func (preheatItemPage) Props(c *preheatCounters) (string, error) {
+	c.preheated.Add(100)
+	return "item", nil
+}
+
+func (preheatItemPage) Page(s string) component { return testComponent{s} }
+
+type preheatFailPage struct{}
+
+func (preheatFailPage) Props() (string, error) { panic("boom") }
+
+func (preheatFailPage) Page(s string) component { return testComponent{s} }
+
+type preheatPages struct {
+	preheatHomePage `route:"/{$} Home"`
+	preheatItemPage `route:"/item/{id} Item"`
+	Post            preheatItemPage `route:"POST /post Post"`
+}
+
+func TestWithPreheat(t *testing.T) {
+	c := &preheatCounters{}
+	_, err := Mount(http.NewServeMux(), &preheatPages{}, "/", "App",
+		WithArgs(c), WithPreheat(context.Background()))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	if got := c.inits.Load(); got != 1 {
+		t.Errorf("Once ran %d times before the first request, want 1", got)
+	}
+	// Only the home page: /item/{id} has a parameter and /post is POST.
+	if got := c.preheated.Load(); got != 1 {
+		t.Errorf("preheated = %d, want 1", got)
+	}
+}
+
+func TestWithPreheat_Disabled(t *testing.T) {
+	c := &preheatCounters{}
+	if _, err := Mount(http.NewServeMux(), &preheatPages{}, "/", "App", WithArgs(c)); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	if got := c.inits.Load(); got != 0 {
+		t.Errorf("Once ran %d times without WithPreheat, want 0", got)
+	}
+}
+
+func TestWithPreheat_ErrorsAreLogged(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	_, err := Mount(http.NewServeMux(), &struct {
+		preheatFailPage `route:"/fail Fail"`
+	}{}, "/", "App", WithPreheat(context.Background()), WithPreheatTimeout(time.Second))
+	if err != nil {
+		t.Fatalf("Mount failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "structpages: preheat /fail") {
+		t.Errorf("log = %q, want preheat failure", buf.String())
+	}
+}
+
+func TestWithPreheat_Timeout(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	c := &preheatCounters{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Mount(http.NewServeMux(), &preheatPages{}, "/", "App",
+		WithArgs(c), WithPreheat(ctx), WithPreheatTimeout(time.Second)); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	if got := c.preheated.Load(); got != 0 {
+		t.Errorf("preheated = %d after the context ended, want 0", got)
+	}
+	if !strings.Contains(buf.String(), "preheat stopped") {
+		t.Errorf("log = %q, want preheat stopped", buf.String())
+	}
+}
@@ -0,0 +1,53 @@
+package structpages
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/jackielii/ctxkey"
+)
+
+// propsChainCtx holds the Props results of the ancestors of the page being
+// served, root first, when WithPropsChain is enabled.
+var propsChainCtx = ctxkey.New[[]reflect.Value]("structpages.propsChain", nil)
+
+// WithPropsChain makes the results of ancestor pages' Props methods
+// available to their descendants. Before a page's Props runs, the Props of
+// every ancestor are called root first, and their return values can be
+// injected into later Props by type:
+//
+//	type admin struct {
+//	    Users users `route:"/users Users"`
+//	}
+//	func (admin) Props(r *http.Request) (*Session, error) { ... }
+//	func (users) Props(s *Session) ([]User, error) { ... } // s from admin.Props
+//
+// An ancestor's Props receives the descendant's RenderTarget. An error from
+// any of them aborts the request like an error from the page's own Props.
+// Disabled by default.
+func WithPropsChain(enabled bool) func(*StructPages) {
+	return func(r *StructPages) {
+		r.propsChain = enabled
+	}
+}
+
+// runPropsChain calls the Props method of each ancestor of page and returns
+// r with their results stored for execProps.
+func (sp *StructPages) runPropsChain(
+	w http.ResponseWriter, r *http.Request, page *PageNode, target RenderTarget,
+) (*http.Request, error) {
+	var chain []reflect.Value
+	for _, a := range page.Ancestors() {
+		if len(sp.propsMethods(a)) == 0 {
+			continue
+		}
+		props, err := sp.execProps(a, r, w, target)
+		if err != nil {
+			return r, fmt.Errorf("props chain: %w", err)
+		}
+		chain = append(chain, props...)
+		r = r.WithContext(propsChainCtx.WithValue(r.Context(), chain))
+	}
+	return r, nil
+}
@@ -0,0 +1,96 @@
+package structpages
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type chainSession struct{ user string }
+
+type chainUserList []string
+
+type chainAdminPage struct {
+	Users chainUsersPage `route:"/users Users"`
+}
+
+func (chainAdminPage) Props(r *http.Request) (*chainSession, error) {
+	return &chainSession{user: r.URL.Query().Get("as")}, nil
+}
+
+func (chainAdminPage) Page(s *chainSession) component { return testComponent{"admin " + s.user} }
+
+type chainUsersPage struct {
+	User chainUserPage `route:"/{id} User"`
+}
+
+func (chainUsersPage) Props(s *chainSession) (chainUserList, error) {
+	return chainUserList{"ann", "bob"}, nil
+}
+
+func (chainUsersPage) Page(users chainUserList) component {
+	return testComponent{strings.Join(users, ",")}
+}
+
+type chainUserPage struct{}
+
+func (chainUserPage) Props(r *http.Request, s *chainSession, users chainUserList) (string, error) {
+	return s.user + " viewing " + r.PathValue("id") + " of " + strings.Join(users, ","), nil
+}
+
+func (chainUserPage) Page(s string) component { return testComponent{s} }
+
+type chainPages struct {
+	Admin chainAdminPage `route:"/admin Admin"`
+}
+
+func TestWithPropsChain(t *testing.T) {
+	mux := http.NewServeMux()
+	if _, err := Mount(mux, &chainPages{}, "/", "App", WithPropsChain(true)); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	tests := []struct{ path, want string }{
+		{"/admin?as=root", "admin root"},
+		{"/admin/users?as=root", "ann,bob"},
+		{"/admin/users/7?as=root", "root viewing 7 of ann,bob"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+		if rec.Code != http.StatusOK || rec.Body.String() != tt.want {
+			t.Errorf("GET %s = %d %q, want 200 %q", tt.path, rec.Code, rec.Body.String(), tt.want)
+		}
+	}
+}
+
+func TestWithPropsChain_Disabled(t *testing.T) {
+	var gotErr error
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &chainPages{}, "/", "App",
+		WithErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+			gotErr = err
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin?as=root", http.NoBody))
+	if rec.Body.String() != "admin root" {
+		t.Errorf("GET /admin = %q, want %q", rec.Body.String(), "admin root")
+	}
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/admin/users", http.NoBody))
+	if gotErr == nil || !strings.Contains(gotErr.Error(), "requires argument of type *structpages.chainSession") {
+		t.Errorf("err = %v, want missing *chainSession", gotErr)
+	}
+}
+
+func TestValidate_PropsChain(t *testing.T) {
+	if _, err := Validate(&chainPages{}, "/", "App"); err == nil {
+		t.Error("Validate without WithPropsChain succeeded, want missing *chainSession")
+	}
+	if _, err := Validate(&chainPages{}, "/", "App", WithPropsChain(true)); err != nil {
+		t.Errorf("Validate with WithPropsChain: %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package structpages
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type propsComponentDB struct{ name string }
+
+// propsComponentPage's Props picks its result from the "case" query value.
+type propsComponentPage struct{}
+
+func (propsComponentPage) Props(r *http.Request, db *propsComponentDB) (component, error) {
+	switch r.URL.Query().Get("case") {
+	case "component":
+		return testComponent{"from props: " + db.name}, nil
+	case "error":
+		return nil, errors.New("props failed")
+	case "both":
+		return testComponent{"ignored"}, errors.New("props failed")
+	}
+	return nil, nil
+}
+
+func (propsComponentPage) Page() component { return testComponent{"page"} }
+
+type propsComponentPages struct {
+	Home propsComponentPage `route:"/ Home"`
+}
+
+func TestPropsReturnsComponent(t *testing.T) {
+	var gotErr error
+	mux := http.NewServeMux()
+	_, err := Mount(mux, propsComponentPages{}, "/", "App",
+		WithArgs(&propsComponentDB{name: "db"}),
+		WithErrorHandler(func(w http.ResponseWriter, _ *http.Request, err error) {
+			gotErr = err
+			http.Error(w, "error", http.StatusInternalServerError)
+		}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	tests := []struct {
+		name     string
+		query    string
+		wantCode int
+		wantBody string
+	}{
+		{"nil component uses the target", "", http.StatusOK, "page"},
+		{"component is rendered", "?case=component", http.StatusOK, "from props: db"},
+		{"error goes to the error handler", "?case=error", http.StatusInternalServerError, "error\n"},
+		{"error wins over the component", "?case=both", http.StatusInternalServerError, "error\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotErr = nil
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/"+tt.query, http.NoBody))
+			if rec.Code != tt.wantCode || rec.Body.String() != tt.wantBody {
+				t.Errorf("got %d %q, want %d %q", rec.Code, rec.Body.String(), tt.wantCode, tt.wantBody)
+			}
+			if wantErr := tt.wantCode != http.StatusOK; (gotErr != nil) != wantErr {
+				t.Errorf("error handler got %v, want an error: %v", gotErr, wantErr)
+			}
+		})
+	}
+}
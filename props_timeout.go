@@ -0,0 +1,44 @@
+package structpages
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// PropsTimeoutError reports that a page's Props methods took longer than
+// the timeout set with WithPropsTimeout or the page's PropsTimeout method.
+// It unwraps to context.DeadlineExceeded.
+type PropsTimeoutError struct {
+	Page    string // name of the page whose Props timed out
+	Timeout time.Duration
+}
+
+func (e *PropsTimeoutError) Error() string {
+	return fmt.Sprintf("props for %s: exceeded props timeout of %s", e.Page, e.Timeout)
+}
+
+func (e *PropsTimeoutError) Unwrap() error { return context.DeadlineExceeded }
+
+// WithPropsTimeout bounds how long a page's Props methods may take, 0
+// meaning no limit. The deadline is set on the request context the Props
+// methods receive, so they must pass r.Context() on to be cancelled; if it
+// is exceeded, the error handler receives a *PropsTimeoutError. A page
+// overrides the timeout with a PropsTimeout method, called once at parse
+// time, whose positive result replaces it:
+//
+//	func (reportPage) PropsTimeout() time.Duration { return 30 * time.Second }
+func WithPropsTimeout(d time.Duration) func(*StructPages) {
+	return func(r *StructPages) {
+		r.propsTimeout = d
+	}
+}
+
+// propsTimeoutFor returns the Props timeout for pn: its PropsTimeout
+// method's, or the WithPropsTimeout one.
+func (sp *StructPages) propsTimeoutFor(pn *PageNode) time.Duration {
+	if pn.propsTimeout > 0 {
+		return pn.propsTimeout
+	}
+	return sp.propsTimeout
+}
@@ -0,0 +1,106 @@
+package structpages
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// slowProps waits d unless the request context ends first.
+func slowProps(r *http.Request, d time.Duration) (string, error) {
+	select {
+	case <-time.After(d):
+		return "done", nil
+	case <-r.Context().Done():
+		return "", r.Context().Err()
+	}
+}
+
+type slowPage struct{}
+
+func (slowPage) Props(r *http.Request) (string, error) { return slowProps(r, 100*time.Millisecond) }
+
+func (slowPage) Page(s string) component { return testComponent{s} }
+
+// patientPage allows more than the global timeout.
+type patientPage struct{}
+
+func (patientPage) Props(r *http.Request) (string, error) { return slowProps(r, 100*time.Millisecond) }
+
+func (patientPage) Page(s string) component { return testComponent{s} }
+
+func (patientPage) PropsTimeout() time.Duration { return time.Second }
+
+// defaultTimeoutPage returns 0, deferring to the global timeout.
+type defaultTimeoutPage struct{}
+
+func (defaultTimeoutPage) Props(r *http.Request) (string, error) {
+	return slowProps(r, 100*time.Millisecond)
+}
+
+func (defaultTimeoutPage) Page(s string) component { return testComponent{s} }
+
+func (defaultTimeoutPage) PropsTimeout() time.Duration { return 0 }
+
+// strictPage allows less than its Props take, with no global timeout.
+type strictPage struct{}
+
+func (strictPage) Props(r *http.Request) (string, error) { return slowProps(r, 100*time.Millisecond) }
+
+func (strictPage) Page(s string) component { return testComponent{s} }
+
+func (strictPage) PropsTimeout() time.Duration { return 10 * time.Millisecond }
+
+type propsTimeoutPages struct {
+	Slow    slowPage           `route:"/slow Slow"`
+	Patient patientPage        `route:"/patient Patient"`
+	Default defaultTimeoutPage `route:"/default Default"`
+	Strict  strictPage         `route:"/strict Strict"`
+}
+
+func TestPropsTimeout(t *testing.T) {
+	global := []Option{WithPropsTimeout(10 * time.Millisecond)}
+	tests := []struct {
+		name    string
+		opts    []Option
+		path    string
+		timeout time.Duration // 0: the request succeeds
+	}{
+		{"global timeout cancels Props", global, "/slow", 10 * time.Millisecond},
+		{"page timeout overrides global", global, "/patient", 0},
+		{"zero page timeout uses global", global, "/default", 10 * time.Millisecond},
+		{"page timeout without global", nil, "/strict", 10 * time.Millisecond},
+		{"no timeout", nil, "/slow", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotErr error
+			opts := append(tt.opts, WithErrorHandler(func(w http.ResponseWriter, _ *http.Request, err error) {
+				gotErr = err
+				http.Error(w, "timeout", http.StatusGatewayTimeout)
+			}))
+			mux := http.NewServeMux()
+			if _, err := Mount(mux, propsTimeoutPages{}, "/", "App", opts...); err != nil {
+				t.Fatalf("Mount: %v", err)
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+			if tt.timeout == 0 {
+				if rec.Body.String() != "done" || gotErr != nil {
+					t.Errorf("body = %q, err = %v; want done", rec.Body.String(), gotErr)
+				}
+				return
+			}
+			var timeoutErr *PropsTimeoutError
+			if !errors.As(gotErr, &timeoutErr) || timeoutErr.Timeout != tt.timeout {
+				t.Fatalf("err = %v, want a PropsTimeoutError of %s", gotErr, tt.timeout)
+			}
+			if !errors.Is(gotErr, context.DeadlineExceeded) {
+				t.Errorf("err = %v, want it to unwrap to context.DeadlineExceeded", gotErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package structpages
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// WithPropsWaterfall makes a page with several Props methods — Props and
+// any other method named *Props — call all of them, one after the other,
+// instead of only Props. The results of each are available for injection
+// by type into the ones that run after it, and the results of all of them
+// into the component:
+//
+//	func (p orgPage) UserProps(r *http.Request, s *Sessions) (*User, error) { ... }
+//	func (p orgPage) PermissionsProps(u *User) ([]Permission, error) { ... }
+//	func (p orgPage) Props(u *User, perms []Permission) (*Org, error) { ... }
+//	func (p orgPage) Page(u *User, org *Org) component { ... }
+//
+// A method runs after every method returning a type it takes, otherwise in
+// name order, so the above runs UserProps, PermissionsProps, Props. An
+// error from any of them stops the waterfall and aborts the request like an
+// error from Props.
+func WithPropsWaterfall() func(*StructPages) {
+	return func(r *StructPages) {
+		r.propsWaterfall = true
+	}
+}
+
+// propsMethods returns the Props methods execProps calls for pn, in order:
+// just Props, or with WithPropsWaterfall every *Props method.
+func (sp *StructPages) propsMethods(pn *PageNode) []reflect.Method {
+	if !sp.propsWaterfall {
+		if m, ok := pn.Props["Props"]; ok {
+			return []reflect.Method{m}
+		}
+		return nil
+	}
+	return waterfallOrder(pn.Props)
+}
+
+// waterfallOrder sorts methods so that each comes after the methods
+// returning the types it takes, breaking ties by name. Methods in a
+// dependency cycle are left in name order.
+func waterfallOrder(methods map[string]reflect.Method) []reflect.Method {
+	pending := make([]reflect.Method, 0, len(methods))
+	for _, m := range methods {
+		pending = append(pending, m)
+	}
+	slices.SortFunc(pending, func(a, b reflect.Method) int { return strings.Compare(a.Name, b.Name) })
+
+	ordered := make([]reflect.Method, 0, len(pending))
+	for len(pending) > 0 {
+		next := slices.IndexFunc(pending, func(m reflect.Method) bool {
+			return !slices.ContainsFunc(pending, func(dep reflect.Method) bool {
+				return dep.Name != m.Name && consumes(m, dep)
+			})
+		})
+		if next < 0 {
+			return append(ordered, pending...)
+		}
+		ordered = append(ordered, pending[next])
+		pending = slices.Delete(pending, next, next+1)
+	}
+	return ordered
+}
+
+// consumes reports whether m takes a parameter of a type dep returns.
+func consumes(m, dep reflect.Method) bool {
+	errorType := reflect.TypeOf((*error)(nil)).Elem()
+	for i := 1; i < m.Type.NumIn(); i++ {
+		for j := range dep.Type.NumOut() {
+			if out := dep.Type.Out(j); out != errorType && m.Type.In(i) == out {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package structpages
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type waterfallUser struct{ ID int }
+
+type waterfallPermission string
+
+type waterfallOrg struct{ Name string }
+
+// waterfallPage's Props methods sort by name as Org, Permissions, User, the
+// reverse of the order their dependencies need.
+type waterfallPage struct{ calls *[]string }
+
+func (p waterfallPage) UserProps(r *http.Request) (*waterfallUser, error) {
+	*p.calls = append(*p.calls, "user")
+	return &waterfallUser{ID: 7}, nil
+}
+
+func (p waterfallPage) PermissionsProps(u *waterfallUser) ([]waterfallPermission, error) {
+	*p.calls = append(*p.calls, "permissions")
+	return []waterfallPermission{waterfallPermission(fmt.Sprintf("edit:%d", u.ID))}, nil
+}
+
+func (p waterfallPage) OrgProps(u *waterfallUser, perms []waterfallPermission) (waterfallOrg, error) {
+	*p.calls = append(*p.calls, "org")
+	return waterfallOrg{Name: fmt.Sprintf("org-%d-%s", u.ID, perms[0])}, nil
+}
+
+func (p waterfallPage) Page(u *waterfallUser, org waterfallOrg) component {
+	return testComponent{fmt.Sprintf("user %d in %s", u.ID, org.Name)}
+}
+
+type waterfallPages struct {
+	Home waterfallPage `route:"/ Home"`
+}
+
+func TestPropsWaterfall(t *testing.T) {
+	var calls []string
+	mux := http.NewServeMux()
+	sp, err := Validate(&waterfallPages{}, "/", "App",
+		WithPropsWaterfall(), WithSingleton(&waterfallPage{calls: &calls}))
+	if err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if err := sp.Mount(mux); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	if got, want := strings.Join(calls, ","), "user,permissions,org"; got != want {
+		t.Errorf("calls = %s, want %s", got, want)
+	}
+	if got, want := rec.Body.String(), "user 7 in org-7-edit:7"; got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+}
+
+type waterfallFailPage struct{ calls *[]string }
+
+func (p waterfallFailPage) AProps() (*waterfallUser, error) {
+	*p.calls = append(*p.calls, "a")
+	return nil, fmt.Errorf("no user")
+}
+
+func (p waterfallFailPage) BProps() (waterfallOrg, error) {
+	*p.calls = append(*p.calls, "b")
+	return waterfallOrg{}, nil
+}
+
+func (waterfallFailPage) Page() component { return testComponent{"page"} }
+
+func TestPropsWaterfall_ErrorStops(t *testing.T) {
+	var calls []string
+	var gotErr error
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &struct {
+		Home waterfallFailPage `route:"/ Home"`
+	}{}, "/", "App", WithPropsWaterfall(), WithSingleton(&waterfallFailPage{calls: &calls}),
+		WithErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+			gotErr = err
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	if got := strings.Join(calls, ","); got != "a" {
+		t.Errorf("calls = %s, want a", got)
+	}
+	if gotErr == nil || !strings.Contains(gotErr.Error(), "no user") {
+		t.Errorf("error = %v, want it to contain %q", gotErr, "no user")
+	}
+}
+
+func TestPropsWaterfall_Disabled(t *testing.T) {
+	var calls []string
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &struct {
+		Home waterfallFailPage `route:"/ Home"`
+	}{}, "/", "App", WithSingleton(&waterfallFailPage{calls: &calls}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	if rec.Body.String() != "page" || len(calls) != 0 {
+		t.Errorf("body = %q, calls = %v; want page and no *Props calls", rec.Body.String(), calls)
+	}
+}
@@ -0,0 +1,93 @@
+package structpages
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// pushRecorder is a ResponseWriter that supports server push, recording
+// pushes and body writes in the order they reach the connection.
+type pushRecorder struct {
+	*httptest.ResponseRecorder
+	events []string
+}
+
+func (w *pushRecorder) Push(target string, _ *http.PushOptions) error {
+	w.events = append(w.events, "push "+target)
+	return nil
+}
+
+func (w *pushRecorder) Write(b []byte) (int, error) {
+	w.events = append(w.events, "body")
+	return w.ResponseRecorder.Write(b)
+}
+
+type pushHandlerPage struct{}
+
+func (pushHandlerPage) ServeHTTP(w http.ResponseWriter, r *http.Request) error {
+	_, _ = w.Write([]byte("<html>"))
+	pusher, ok := w.(http.Pusher)
+	if !ok {
+		return errors.New("writer is not an http.Pusher")
+	}
+	if err := pusher.Push("/static/main.css", nil); err != nil {
+		return err
+	}
+	_, _ = w.Write([]byte("</html>"))
+	return nil
+}
+
+type pushPropsPage struct{}
+
+func (pushPropsPage) Props(w http.ResponseWriter) (string, error) {
+	pusher, ok := w.(http.Pusher)
+	if !ok {
+		return "", errors.New("writer is not an http.Pusher")
+	}
+	return "props", pusher.Push("/static/app.js", nil)
+}
+
+func (pushPropsPage) Page(s string) component { return testComponent{s} }
+
+type pushPages struct {
+	Handler pushHandlerPage `route:"/handler Handler"`
+	Props   pushPropsPage   `route:"/props Props"`
+}
+
+func TestServerPush(t *testing.T) {
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &pushPages{}, "/", "App",
+		WithResponseHeaders(map[string]string{"X-Frame-Options": "DENY"}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	tests := []struct {
+		path, want string
+	}{
+		{"/handler", "push /static/main.css,body"},
+		{"/props", "push /static/app.js,body"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := &pushRecorder{ResponseRecorder: httptest.NewRecorder()}
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+			}
+			if got := strings.Join(w.events, ","); got != tt.want {
+				t.Errorf("events = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServerPush_NotSupported(t *testing.T) {
+	bw := newBuffered(httptest.NewRecorder())
+	defer func() { _ = bw.close() }()
+	if err := bw.Push("/static/main.css", nil); !errors.Is(err, http.ErrNotSupported) {
+		t.Errorf("Push = %v, want http.ErrNotSupported", err)
+	}
+}
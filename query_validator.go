@@ -0,0 +1,49 @@
+package structpages
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// WithQueryValidator checks the query parameters of every Props/component
+// page before its Props run, so a missing or malformed parameter is answered
+// with 400 rather than failing somewhere inside Props:
+//
+//	structpages.WithQueryValidator(func(r *http.Request, pn *structpages.PageNode) error {
+//	    if page := r.URL.Query().Get("page"); page != "" {
+//	        if _, err := strconv.Atoi(page); err != nil {
+//	            return fmt.Errorf("page: %w", err)
+//	        }
+//	    }
+//	    return nil
+//	})
+//
+// A page with a ValidateQuery method is checked by that method instead. An
+// error reaches the error handler as an *HTTPError with code 400 wrapping it.
+func WithQueryValidator(fn func(*http.Request, *PageNode) error) func(*StructPages) {
+	return func(sp *StructPages) {
+		sp.queryValidator = fn
+	}
+}
+
+// validateQuery runs pn's ValidateQuery method, or else the WithQueryValidator
+// function, and returns its error as a 400 HTTPError.
+func (sp *StructPages) validateQuery(r *http.Request, pn *PageNode) error {
+	var err error
+	switch {
+	case pn.validateQuery != nil:
+		var res []reflect.Value
+		res, err = sp.pc.callMethod(pn, pn.validateQuery, reflect.ValueOf(r))
+		if err != nil {
+			return fmt.Errorf("error calling ValidateQuery method on %s: %w", pn.Name, err)
+		}
+		_, err = extractError(res)
+	case sp.queryValidator != nil:
+		err = sp.queryValidator(r, pn)
+	}
+	if err != nil {
+		return &HTTPError{Code: http.StatusBadRequest, Err: fmt.Errorf("invalid query for %s: %w", pn.Name, err)}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package structpages
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+type queryListPage struct{}
+
+func (queryListPage) Props(r *http.Request) (string, error) {
+	return "page " + r.URL.Query().Get("page"), nil
+}
+
+func (queryListPage) Page(s string) component { return testComponent{s} }
+
+type querySearchPage struct{}
+
+func (querySearchPage) ValidateQuery(r *http.Request) error {
+	if r.URL.Query().Get("q") == "" {
+		return errors.New("q is required")
+	}
+	return nil
+}
+
+func (querySearchPage) Props(r *http.Request) (string, error) {
+	return "search " + r.URL.Query().Get("q"), nil
+}
+
+func (querySearchPage) Page(s string) component { return testComponent{s} }
+
+type queryPages struct {
+	List   queryListPage   `route:"/list List"`
+	Search querySearchPage `route:"/search Search"`
+}
+
+func requirePageNumber(r *http.Request, _ *PageNode) error {
+	page := r.URL.Query().Get("page")
+	if page == "" {
+		return errors.New("page is required")
+	}
+	if _, err := strconv.Atoi(page); err != nil {
+		return err
+	}
+	return nil
+}
+
+func TestWithQueryValidator(t *testing.T) {
+	var handled error
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &queryPages{}, "/", "App",
+		WithQueryValidator(requirePageNumber),
+		WithErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+			handled = err
+			var httpErr *HTTPError
+			if errors.As(err, &httpErr) {
+				http.Error(w, err.Error(), httpErr.Code)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	tests := []struct {
+		name, path string
+		code       int
+		body       string
+	}{
+		{"missing param", "/list", http.StatusBadRequest, ""},
+		{"invalid value", "/list?page=two", http.StatusBadRequest, ""},
+		{"valid params", "/list?page=2", http.StatusOK, "page 2"},
+		{"page validator overrides global", "/search?q=go", http.StatusOK, "search go"},
+		{"page validator rejects", "/search?page=2", http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handled = nil
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+			if rec.Code != tt.code {
+				t.Fatalf("GET %s = %d %q, want %d", tt.path, rec.Code, rec.Body.String(), tt.code)
+			}
+			if tt.code == http.StatusOK {
+				if rec.Body.String() != tt.body {
+					t.Errorf("body = %q, want %q", rec.Body.String(), tt.body)
+				}
+				return
+			}
+			var httpErr *HTTPError
+			if !errors.As(handled, &httpErr) || httpErr.Err == nil {
+				t.Errorf("error handler got %v, want an *HTTPError wrapping the validator error", handled)
+			}
+		})
+	}
+}
+
+type badQueryValidatorPage struct{}
+
+func (badQueryValidatorPage) ValidateQuery(*http.Request) bool { return true }
+
+func (badQueryValidatorPage) Page() component { return testComponent{"bad"} }
+
+func TestValidateQuery_BadSignature(t *testing.T) {
+	_, err := Parse(&struct {
+		Bad badQueryValidatorPage `route:"/bad Bad"`
+	}{}, "/", "App")
+	if err == nil {
+		t.Fatal("Parse accepted a ValidateQuery method not returning error")
+	}
+}
@@ -0,0 +1,169 @@
+package structpages
+
+import (
+	"container/list"
+	"math"
+	"net"
+	"net/http"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultRateLimitCacheSize is the number of (page, client) buckets kept
+// when WithRateLimitCacheSize isn't used.
+const defaultRateLimitCacheSize = 10000
+
+// Rate is a request budget: Requests per Per, refilled continuously, with
+// bursts of up to Requests. A zero Rate means no limit.
+type Rate struct {
+	Requests int
+	Per      time.Duration
+}
+
+func (r Rate) limited() bool { return r.Requests > 0 && r.Per > 0 }
+
+var rateType = reflect.TypeFor[Rate]()
+
+// WithRateLimiting limits how often each client, identified by the host of
+// r.RemoteAddr, may request each page. A page's rate is, in order: what its
+// RateLimit method returns, its type's entry in perPage, then global:
+//
+//	func (loginPage) RateLimit() structpages.Rate {
+//	    return structpages.Rate{Requests: 5, Per: time.Minute}
+//	}
+//
+//	structpages.Mount(mux, pages{}, "/", "App", structpages.WithRateLimiting(
+//	    structpages.Rate{Requests: 100, Per: time.Minute},
+//	    map[reflect.Type]structpages.Rate{reflect.TypeFor[searchPage](): {Requests: 10, Per: time.Second}},
+//	))
+//
+// A request over the limit gets a Retry-After header with the seconds until
+// it would be allowed, and a 429 HTTPError passed to the error handler. The
+// limiter runs before the middlewares from WithMiddlewares. RemoteAddr is
+// the proxy's address behind a reverse proxy; rewrite it from a trusted
+// header first, as in an http.Handler wrapping the mux.
+func WithRateLimiting(global Rate, perPage map[reflect.Type]Rate) func(*StructPages) {
+	return func(sp *StructPages) {
+		sp.rateLimit = &rateLimitConfig{global: global, perPage: perPage}
+	}
+}
+
+// WithRateLimitCacheSize sets how many (page, client) buckets
+// WithRateLimiting keeps, 10000 by default. Past that the least recently
+// seen bucket is dropped, so a client that comes back after being evicted
+// starts with a full budget.
+func WithRateLimitCacheSize(n int) func(*StructPages) {
+	return func(sp *StructPages) {
+		sp.rateLimitCacheSize = n
+	}
+}
+
+type rateLimitConfig struct {
+	global  Rate
+	perPage map[reflect.Type]Rate
+}
+
+// rateFor returns the rate that applies to pn.
+func (c *rateLimitConfig) rateFor(pn *PageNode) Rate {
+	if pn.rateLimit != nil {
+		return *pn.rateLimit
+	}
+	t := pn.Value.Type()
+	if rate, ok := c.perPage[t]; ok {
+		return rate
+	}
+	if t.Kind() == reflect.Pointer {
+		if rate, ok := c.perPage[t.Elem()]; ok {
+			return rate
+		}
+	}
+	return c.global
+}
+
+// rateLimitMiddleware implements WithRateLimiting with buckets shared by
+// all pages of sp.
+func (sp *StructPages) rateLimitMiddleware() MiddlewareFunc {
+	size := sp.rateLimitCacheSize
+	if size <= 0 {
+		size = defaultRateLimitCacheSize
+	}
+	buckets := newRateBuckets(size)
+	return func(next http.Handler, pn *PageNode) http.Handler {
+		rate := sp.rateLimit.rateFor(pn)
+		if !rate.limited() {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			wait := buckets.take(rateKey{page: pn, client: clientHost(r)}, rate, time.Now())
+			if wait > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+				sp.onError(w, r, &HTTPError{Code: http.StatusTooManyRequests})
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// clientHost returns the host part of r.RemoteAddr.
+func clientHost(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+type rateKey struct {
+	page   *PageNode
+	client string
+}
+
+type rateBucket struct {
+	key    rateKey
+	tokens float64
+	last   time.Time
+}
+
+// rateBuckets holds token buckets in least recently used order, dropping
+// the oldest past size.
+type rateBuckets struct {
+	mu    sync.Mutex
+	size  int
+	order *list.List // of *rateBucket, most recently used first
+	byKey map[rateKey]*list.Element
+}
+
+func newRateBuckets(size int) *rateBuckets {
+	return &rateBuckets{size: size, order: list.New(), byKey: make(map[rateKey]*list.Element)}
+}
+
+// take spends a token of key's bucket at now. It returns 0 when one was
+// available, and otherwise how long until the next one is.
+func (b *rateBuckets) take(key rateKey, rate Rate, now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	burst := float64(rate.Requests)
+	perToken := rate.Per / time.Duration(rate.Requests)
+	var bucket *rateBucket
+	if el, ok := b.byKey[key]; ok {
+		b.order.MoveToFront(el)
+		bucket = el.Value.(*rateBucket)
+		bucket.tokens = min(burst, bucket.tokens+float64(now.Sub(bucket.last))/float64(perToken))
+		bucket.last = now
+	} else {
+		bucket = &rateBucket{key: key, tokens: burst, last: now}
+		b.byKey[key] = b.order.PushFront(bucket)
+		if b.order.Len() > b.size {
+			oldest := b.order.Back()
+			b.order.Remove(oldest)
+			delete(b.byKey, oldest.Value.(*rateBucket).key)
+		}
+	}
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return 0
+	}
+	return time.Duration((1 - bucket.tokens) * float64(perToken))
+}
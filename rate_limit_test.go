@@ -0,0 +1,125 @@
+package structpages
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type rateHomePage struct{}
+
+func (rateHomePage) Page() component { return testComponent{"home"} }
+
+type rateSearchPage struct{}
+
+func (rateSearchPage) Page() component { return testComponent{"search"} }
+
+type rateLoginPage struct{}
+
+func (rateLoginPage) Page() component { return testComponent{"login"} }
+
+func (rateLoginPage) RateLimit() Rate { return Rate{Requests: 1, Per: 10 * time.Second} }
+
+type rateOpenPage struct{}
+
+func (rateOpenPage) Page() component { return testComponent{"open"} }
+
+func (rateOpenPage) RateLimit() Rate { return Rate{} }
+
+type ratePages struct {
+	Home   rateHomePage   `route:"/{$} Home"`
+	Search rateSearchPage `route:"/search Search"`
+	Login  rateLoginPage  `route:"/login Login"`
+	Open   rateOpenPage   `route:"/open Open"`
+}
+
+func rateRequest(t *testing.T, h http.Handler, path, client string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, path, http.NoBody)
+	req.RemoteAddr = client + ":1234"
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestWithRateLimiting(t *testing.T) {
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &ratePages{}, "/", "App", WithRateLimiting(
+		Rate{Requests: 3, Per: time.Minute},
+		map[reflect.Type]Rate{reflect.TypeFor[rateSearchPage](): {Requests: 2, Per: time.Minute}},
+	))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	tests := []struct {
+		path       string
+		allowed    int
+		retryAfter string
+	}{
+		{"/", 3, "20"},       // global: a token every 20s
+		{"/search", 2, "30"}, // perPage: a token every 30s
+		{"/login", 1, "10"},  // RateLimit method
+		{"/open", 10, ""},    // a zero Rate from RateLimit disables the limit
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			for i := range tt.allowed {
+				if rec := rateRequest(t, mux, tt.path, "10.0.0.1"); rec.Code != http.StatusOK {
+					t.Fatalf("request %d: status = %d, want 200", i+1, rec.Code)
+				}
+			}
+			if tt.retryAfter == "" {
+				return
+			}
+			rec := rateRequest(t, mux, tt.path, "10.0.0.1")
+			if rec.Code != http.StatusTooManyRequests {
+				t.Fatalf("status over the limit = %d, want 429", rec.Code)
+			}
+			if got := rec.Header().Get("Retry-After"); got != tt.retryAfter {
+				t.Errorf("Retry-After = %q, want %q", got, tt.retryAfter)
+			}
+			if rec := rateRequest(t, mux, tt.path, "10.0.0.2"); rec.Code != http.StatusOK {
+				t.Errorf("another client: status = %d, want 200", rec.Code)
+			}
+		})
+	}
+}
+
+func TestWithRateLimitCacheSize(t *testing.T) {
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &ratePages{}, "/", "App",
+		WithRateLimiting(Rate{Requests: 1, Per: time.Hour}, nil), WithRateLimitCacheSize(2))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	rateRequest(t, mux, "/", "10.0.0.1")
+	if rec := rateRequest(t, mux, "/", "10.0.0.1"); rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("second request: status = %d, want 429", rec.Code)
+	}
+	// Two other clients push the first one's bucket out of the cache.
+	rateRequest(t, mux, "/", "10.0.0.2")
+	rateRequest(t, mux, "/", "10.0.0.3")
+	if rec := rateRequest(t, mux, "/", "10.0.0.1"); rec.Code != http.StatusOK {
+		t.Errorf("after eviction: status = %d, want 200", rec.Code)
+	}
+}
+
+func TestRateBuckets_Refill(t *testing.T) {
+	b := newRateBuckets(10)
+	key := rateKey{client: "10.0.0.1"}
+	rate := Rate{Requests: 2, Per: time.Second}
+	now := time.Now()
+	b.take(key, rate, now)
+	b.take(key, rate, now)
+	if wait := b.take(key, rate, now); wait != 500*time.Millisecond {
+		t.Errorf("wait with an empty bucket = %v, want 500ms", wait)
+	}
+	if wait := b.take(key, rate, now.Add(500*time.Millisecond)); wait != 0 {
+		t.Errorf("wait after a refill = %v, want 0", wait)
+	}
+	if got := b.order.Len(); got != 1 {
+		t.Errorf("buckets = %d, want 1", got)
+	}
+}
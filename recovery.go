@@ -0,0 +1,86 @@
+package structpages
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// WithRecoveryComponent recovers panics in page handlers — Props, component
+// methods, rendering, ServeHTTP — and renders the component fn returns for
+// the recovered value instead, with status 200 and whatever the handler had
+// written discarded:
+//
+//	structpages.WithRecoveryComponent(func(v any, r *http.Request) structpages.Component {
+//	    slog.Error("page panicked", "panic", v, "path", r.URL.Path)
+//	    return errorPage("Something went wrong")
+//	})
+//
+// If fn or the component it returns panics too, the error handler gets an
+// error describing both panics. Panics in middlewares are not recovered,
+// nor is http.ErrAbortHandler, which is re-panicked so the server aborts
+// the response. Responses are buffered to make the discard possible until
+// the page first flushes (a StreamComponent does after each chunk); from
+// then on writes go straight to the client, and a later panic gets the
+// recovery component appended to what was already sent.
+func WithRecoveryComponent(fn func(recovered any, r *http.Request) Component) func(*StructPages) {
+	return func(r *StructPages) {
+		r.recoveryComponent = fn
+	}
+}
+
+// withRecovery implements WithRecoveryComponent for the handler of pn.
+func (sp *StructPages) withRecovery(next http.Handler, pn *PageNode) http.Handler {
+	if sp.recoveryComponent == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := &recoveryWriter{buffered: newBuffered(w)}
+		defer func() { _ = rw.close() }()
+		defer func() {
+			if v := recover(); v != nil {
+				if v == http.ErrAbortHandler {
+					panic(v)
+				}
+				sp.renderRecovery(rw.buffered, r, pn, v)
+			}
+		}()
+		next.ServeHTTP(rw, r)
+	})
+}
+
+// recoveryWriter buffers a response until its first flush and passes
+// writes through after it, so streaming pages are not held in memory.
+type recoveryWriter struct {
+	*buffered
+	flushed bool
+}
+
+func (w *recoveryWriter) Write(b []byte) (int, error) {
+	if w.flushed {
+		return w.ResponseWriter.Write(b)
+	}
+	return w.buffered.Write(b)
+}
+
+func (w *recoveryWriter) Flush() { _ = w.FlushError() }
+
+func (w *recoveryWriter) FlushError() error {
+	w.flushed = true
+	return w.buffered.FlushError()
+}
+
+// renderRecovery replaces the response in w with the recovery component for
+// the panic value v, falling back to the error handler when that panics.
+func (sp *StructPages) renderRecovery(w *buffered, r *http.Request, pn *PageNode, v any) {
+	w.buf.Reset()
+	if !w.headerSent {
+		w.status, w.statusSet = http.StatusOK, false
+	}
+	defer func() {
+		if v2 := recover(); v2 != nil {
+			w.buf.Reset()
+			sp.onError(w, r, fmt.Errorf("page %s panicked: %v; recovery component panicked: %v", pn.Name, v, v2))
+		}
+	}()
+	sp.render(w, r, sp.recoveryComponent(v, r), pn, "")
+}
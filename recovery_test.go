@@ -0,0 +1,150 @@
+package structpages
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type panicPage struct{}
+
+func (panicPage) Page() component { panic("page boom") }
+
+type panicPropsPage struct{}
+
+func (panicPropsPage) Props() (string, error) { panic("props boom") }
+
+func (panicPropsPage) Page(s string) component { return testComponent{s} }
+
+// partialPanicComponent writes some output before panicking, which the
+// recovery component must replace.
+type partialPanicComponent struct{}
+
+func (partialPanicComponent) Render(_ context.Context, w io.Writer) error {
+	_, _ = io.WriteString(w, "partial")
+	panic("render boom")
+}
+
+type partialPanicPage struct{}
+
+func (partialPanicPage) Page() component { return partialPanicComponent{} }
+
+type recoveryPages struct {
+	Page    panicPage        `route:"/page Page"`
+	Props   panicPropsPage   `route:"/props Props"`
+	Partial partialPanicPage `route:"/partial Partial"`
+}
+
+func TestWithRecoveryComponent(t *testing.T) {
+	mux := http.NewServeMux()
+	_, err := Mount(mux, recoveryPages{}, "/", "App",
+		WithRecoveryComponent(func(v any, r *http.Request) Component {
+			return testComponent{fmt.Sprintf("recovered %v at %s", v, r.URL.Path)}
+		}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	tests := []struct{ path, want string }{
+		{"/page", "recovered page boom at /page"},
+		{"/props", "recovered props boom at /props"},
+		{"/partial", "recovered render boom at /partial"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+			if rec.Code != http.StatusOK {
+				t.Errorf("status = %d, want 200", rec.Code)
+			}
+			if rec.Body.String() != tt.want {
+				t.Errorf("body = %q, want %q", rec.Body.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWithRecoveryComponent_RecoveryPanics(t *testing.T) {
+	var gotErr error
+	mux := http.NewServeMux()
+	_, err := Mount(mux, recoveryPages{}, "/", "App",
+		WithRecoveryComponent(func(any, *http.Request) Component { panic("recovery boom") }),
+		WithErrorHandler(func(w http.ResponseWriter, _ *http.Request, err error) {
+			gotErr = err
+			http.Error(w, "error page", http.StatusInternalServerError)
+		}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/page", http.NoBody))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", rec.Code)
+	}
+	if got := rec.Body.String(); got != "error page\n" {
+		t.Errorf("body = %q, want the error handler's output", got)
+	}
+	if gotErr == nil || !strings.Contains(gotErr.Error(), "page boom") ||
+		!strings.Contains(gotErr.Error(), "recovery boom") {
+		t.Errorf("error = %v, want both panic values", gotErr)
+	}
+}
+
+type abortPage struct{}
+
+func (abortPage) ServeHTTP(http.ResponseWriter, *http.Request) { panic(http.ErrAbortHandler) }
+
+// flushPanicPage flushes part of its response, writes more and panics.
+type flushPanicPage struct{}
+
+func (flushPanicPage) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	_, _ = io.WriteString(w, "head ")
+	_ = http.NewResponseController(w).Flush()
+	_, _ = io.WriteString(w, "tail ")
+	panic("late boom")
+}
+
+type recoveryStreamPages struct {
+	Abort  abortPage      `route:"/abort Abort"`
+	Flush  flushPanicPage `route:"/flush Flush"`
+	Stream streamPage     `route:"/stream Stream"`
+}
+
+func TestWithRecoveryComponent_Streaming(t *testing.T) {
+	mux := http.NewServeMux()
+	_, err := Mount(mux, recoveryStreamPages{}, "/", "App",
+		WithRecoveryComponent(func(v any, _ *http.Request) Component {
+			return testComponent{fmt.Sprintf("recovered %v", v)}
+		}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+
+	t.Run("ErrAbortHandler is re-panicked", func(t *testing.T) {
+		defer func() {
+			if v := recover(); v != http.ErrAbortHandler {
+				t.Errorf("recovered %v, want http.ErrAbortHandler", v)
+			}
+		}()
+		mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/abort", http.NoBody))
+	})
+
+	t.Run("writes after a flush are not buffered", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/flush", http.NoBody))
+		if got, want := rec.Body.String(), "head tail recovered late boom"; got != want {
+			t.Errorf("body = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("stream chunks are flushed as they arrive", func(t *testing.T) {
+		rec := &flushRecorder{ResponseRecorder: httptest.NewRecorder()}
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stream", http.NoBody))
+		if len(rec.flushes) != 5 || rec.flushes[4] != "<tr>1</tr><tr>2</tr><tr>3</tr><tr>4</tr><tr>5</tr>" {
+			t.Errorf("flushes = %q, want one per chunk", rec.flushes)
+		}
+	})
+}
@@ -265,6 +265,16 @@ func isComponent(t *reflect.Method) bool {
 	return t.Type.Out(0).Implements(typ)
 }
 
+// returnsComponent checks if a Props method's first result is declared as a
+// component interface, as in Props(...) (component, error).
+func returnsComponent(m *reflect.Method) bool {
+	if m.Type.NumOut() == 0 {
+		return false
+	}
+	out := m.Type.Out(0)
+	return out.Kind() == reflect.Interface && out.Implements(reflect.TypeOf((*component)(nil)).Elem())
+}
+
 // isPromotedMethod checks if a method is promoted from an embedded type.
 // Promoted methods have an autogenerated wrapper that can be detected.
 // See: https://github.com/golang/go/issues/73883
@@ -275,6 +285,31 @@ func isPromotedMethod(method *reflect.Method) bool {
 	return wFile == "<autogenerated>" && wLine == 1
 }
 
+// isEmbeddedMethod reports whether method, found on the struct type st or
+// its pointer, is the method of an anonymous field of st without a route
+// tag rather than one st declares. It catches promoted methods whose code
+// the compiler shares with the embedded type instead of generating a
+// wrapper, which isPromotedMethod can't tell from st's own; a method st
+// declares to shadow the embedded one has code of its own and is kept.
+func isEmbeddedMethod(st reflect.Type, method *reflect.Method) bool {
+	pc := method.Func.Pointer()
+	for i := range st.NumField() {
+		field := st.Field(i)
+		if !field.Anonymous {
+			continue
+		}
+		if _, ok := field.Tag.Lookup("route"); ok {
+			continue
+		}
+		for _, t := range []reflect.Type{field.Type, pointerType(field.Type)} {
+			if m, ok := t.MethodByName(method.Name); ok && m.Func.Pointer() == pc {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // extractError extracts an error from the last return value if it implements error.
 // Returns the remaining values and the extracted error (or nil if no error).
 func extractError(args []reflect.Value) ([]reflect.Value, error) {
@@ -1,6 +1,8 @@
 package structpages
 
 import (
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -188,3 +190,82 @@ func TestExtractMethodInfo_EdgeCases(t *testing.T) {
 		t.Error("Expected error for non-function type")
 	}
 }
+
+type embeddedBaseService struct{}
+
+func (embeddedBaseService) UserList() component { return testComponent{"users"} }
+
+type embeddedServicePage struct {
+	embeddedBaseService
+}
+
+func (embeddedServicePage) Page() component { return testComponent{"page"} }
+
+type embeddedShadowPage struct {
+	embeddedBaseService
+}
+
+func (embeddedShadowPage) Page() component     { return testComponent{"page"} }
+func (embeddedShadowPage) UserList() component { return testComponent{"own users"} }
+
+type embeddedRouteChild struct{}
+
+func (embeddedRouteChild) Page() component    { return testComponent{"child"} }
+func (embeddedRouteChild) Details() component { return testComponent{"details"} }
+
+type embeddedRouteParent struct {
+	embeddedRouteChild `route:"/child Child"`
+}
+
+func (embeddedRouteParent) Page() component { return testComponent{"parent"} }
+
+type embeddedMethodPages struct {
+	Service embeddedServicePage `route:"/service Service"`
+	Shadow  embeddedShadowPage  `route:"/shadow Shadow"`
+	Parent  embeddedRouteParent `route:"/parent Parent"`
+}
+
+func TestEmbeddedMethods(t *testing.T) {
+	sp, err := Parse(&embeddedMethodPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	tests := []struct {
+		page any
+		want []string
+	}{
+		{embeddedServicePage{}, []string{"Page"}},
+		{embeddedShadowPage{}, []string{"Page", "UserList"}},
+		{embeddedRouteParent{}, []string{"Page"}},
+		{embeddedRouteChild{}, []string{"Details", "Page"}},
+	}
+	for _, tt := range tests {
+		got, err := sp.ListComponents(tt.page)
+		if err != nil || !slices.Equal(got, tt.want) {
+			t.Errorf("ListComponents(%T) = %q, %v; want %q", tt.page, got, err, tt.want)
+		}
+	}
+}
+
+func TestIsEmbeddedMethod(t *testing.T) {
+	st := reflect.TypeFor[embeddedServicePage]()
+	// A promoted method sharing the embedded type's code, as the compiler
+	// may emit it instead of a wrapper.
+	shared, _ := reflect.TypeFor[embeddedBaseService]().MethodByName("UserList")
+	if !isEmbeddedMethod(st, &shared) {
+		t.Error("method with the embedded field's code: isEmbeddedMethod = false, want true")
+	}
+	own, _ := reflect.TypeFor[embeddedShadowPage]().MethodByName("UserList")
+	if isEmbeddedMethod(reflect.TypeFor[embeddedShadowPage](), &own) {
+		t.Error("shadowing method: isEmbeddedMethod = true, want false")
+	}
+	page, _ := st.MethodByName("Page")
+	if isEmbeddedMethod(st, &page) {
+		t.Error("Page: isEmbeddedMethod = true, want false")
+	}
+	// Route-tagged fields are child pages, not embedded services.
+	details, _ := reflect.TypeFor[embeddedRouteChild]().MethodByName("Details")
+	if isEmbeddedMethod(reflect.TypeFor[embeddedRouteParent](), &details) {
+		t.Error("route-tagged field: isEmbeddedMethod = true, want false")
+	}
+}
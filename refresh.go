@@ -0,0 +1,76 @@
+package structpages
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+// ErrNotSwappable is returned by Refresh for a StructPages mounted without
+// WithSwappableMux.
+var ErrNotSwappable = errors.New("structpages: Refresh needs WithSwappableMux")
+
+// WithSwappableMux lets Refresh replace the page tree while the server
+// runs, for hot reload in development. Serve the StructPages itself, not the
+// mux it is mounted on, so requests reach whichever tree is current:
+//
+//	sp, err := structpages.Mount(nil, pages{}, "/", "App", structpages.WithSwappableMux())
+//	go http.ListenAndServe(":8080", sp)
+//	...
+//	err = sp.Refresh(nil, pages{}, "/", "App")
+func WithSwappableMux() func(*StructPages) {
+	return func(sp *StructPages) {
+		sp.swappable = true
+	}
+}
+
+// Refresh parses page again and registers the new tree on mux, or on a new
+// http.ServeMux if mux is nil, then switches ServeHTTP over to it
+// atomically: requests already running finish against the old tree, later
+// ones are served by the new one. The new tree is built with the options sp
+// was mounted with, followed by opts. If parsing or registering fails, the
+// old tree keeps serving and the error is returned.
+//
+// http.ServeMux can't deregister routes, so mux must not be one a tree was
+// registered on before. URLFor, ID, Export, Match and the other methods
+// that use the page tree load it atomically, so called during Refresh they
+// see the old tree or the new one. The handler set with SetOnError is not
+// carried over.
+func (sp *StructPages) Refresh(mux Mux, page any, route, title string, opts ...Option) (err error) {
+	sp.checkWritable()
+	if !sp.swappable {
+		return ErrNotSwappable
+	}
+	if !sp.mounted() {
+		return ErrNotMounted
+	}
+	if mux == nil {
+		mux = http.NewServeMux()
+	}
+	next, err := newStructPages(page, route, title, append(slices.Clone(sp.options), opts...))
+	if err != nil {
+		return fmt.Errorf("refresh: %w", err)
+	}
+	defer func() {
+		// ServeMux reports conflicting patterns by panicking.
+		if r := recover(); r != nil {
+			err = fmt.Errorf("refresh: %v", r)
+		}
+	}()
+	if err := next.Mount(mux); err != nil {
+		return fmt.Errorf("refresh: %w", err)
+	}
+	sp.live.Store(next)
+	return nil
+}
+
+// current returns the StructPages serving sp's routes: the tree Refresh
+// last swapped in, or sp itself. Methods that read or change the page tree
+// go through it instead of sp's own fields, which Refresh leaves alone.
+func (sp *StructPages) current() *StructPages {
+	if live := sp.live.Load(); live != nil {
+		return live
+	}
+	return sp
+}
@@ -0,0 +1,171 @@
+package structpages
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+type refreshSlowPage struct{ release, started chan struct{} }
+
+func (p *refreshSlowPage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	close(p.started)
+	<-p.release
+	_, _ = w.Write([]byte("old slow"))
+}
+
+type refreshOldPages struct {
+	Home refreshHomeV1    `route:"/{$} Home"`
+	Slow *refreshSlowPage `route:"/slow Slow"`
+}
+
+type refreshHomeV1 struct{}
+
+func (refreshHomeV1) Page() component { return testComponent{"home v1"} }
+
+type refreshHomeV2 struct{}
+
+func (refreshHomeV2) Page() component { return testComponent{"home v2"} }
+
+type refreshNewsPage struct{}
+
+func (refreshNewsPage) Page() component { return testComponent{"news"} }
+
+type refreshNewPages struct {
+	Home refreshHomeV2   `route:"/{$} Home"`
+	News refreshNewsPage `route:"/news News"`
+}
+
+type refreshBadPage struct{}
+
+func (refreshBadPage) Page() component { return testComponent{"bad"} }
+
+func (refreshBadPage) Aliases() []string { return []string{"no-slash"} }
+
+type refreshBadPages struct {
+	Bad refreshBadPage `route:"/bad Bad"`
+}
+
+func refreshGet(sp *StructPages, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	sp.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+	return rec
+}
+
+func TestRefresh(t *testing.T) {
+	slow := &refreshSlowPage{release: make(chan struct{}), started: make(chan struct{})}
+	sp, err := Mount(http.NewServeMux(), &refreshOldPages{}, "/", "App", WithSwappableMux(), WithSingleton(slow))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	if rec := refreshGet(sp, "/"); rec.Body.String() != "home v1" {
+		t.Fatalf("GET / before Refresh = %q, want home v1", rec.Body.String())
+	}
+	if pn, _, err := sp.Match(http.MethodGet, "/slow"); err != nil || pn.Name != "Slow" {
+		t.Fatalf("Match /slow before Refresh = %v, %v; want Slow", pn, err)
+	}
+
+	inFlight := make(chan *httptest.ResponseRecorder)
+	go func() { inFlight <- refreshGet(sp, "/slow") }()
+	<-slow.started
+
+	if err := sp.Refresh(nil, &refreshNewPages{}, "/", "App"); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	close(slow.release)
+	if rec := <-inFlight; rec.Body.String() != "old slow" {
+		t.Errorf("in-flight request = %q, want old slow", rec.Body.String())
+	}
+
+	if rec := refreshGet(sp, "/"); rec.Body.String() != "home v2" {
+		t.Errorf("GET / after Refresh = %q, want home v2", rec.Body.String())
+	}
+	if rec := refreshGet(sp, "/news"); rec.Code != http.StatusOK || rec.Body.String() != "news" {
+		t.Errorf("GET /news after Refresh = %d %q, want 200 news", rec.Code, rec.Body.String())
+	}
+	if rec := refreshGet(sp, "/slow"); rec.Code != http.StatusNotFound {
+		t.Errorf("GET /slow after Refresh = %d, want 404", rec.Code)
+	}
+	if got, err := sp.URLFor(refreshNewsPage{}); err != nil || got != "/news" {
+		t.Errorf("URLFor(refreshNewsPage) after Refresh = %q, %v; want /news", got, err)
+	}
+	if pn, _, err := sp.Match(http.MethodGet, "/news"); err != nil || pn.Name != "News" {
+		t.Errorf("Match /news after Refresh = %v, %v; want News", pn, err)
+	}
+	if _, _, err := sp.Match(http.MethodGet, "/slow"); !errors.Is(err, ErrRouteNotFound) {
+		t.Errorf("Match /slow after Refresh = %v, want ErrRouteNotFound", err)
+	}
+}
+
+func TestRefresh_ErrorKeepsOldTree(t *testing.T) {
+	sp, err := Mount(http.NewServeMux(), &refreshNewPages{}, "/", "App", WithSwappableMux())
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	if err := sp.Refresh(nil, &refreshBadPages{}, "/", "App"); err == nil {
+		t.Fatal("Refresh with a tree that fails to parse: want an error")
+	}
+	if rec := refreshGet(sp, "/news"); rec.Body.String() != "news" {
+		t.Errorf("GET /news after a failed Refresh = %q, want news", rec.Body.String())
+	}
+	if _, err := sp.URLFor(refreshNewsPage{}); err != nil {
+		t.Errorf("URLFor after a failed Refresh: %v", err)
+	}
+
+	plain, err := Mount(http.NewServeMux(), &refreshNewPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	if err := plain.Refresh(nil, &refreshNewPages{}, "/", "App"); !errors.Is(err, ErrNotSwappable) {
+		t.Errorf("Refresh without WithSwappableMux = %v, want ErrNotSwappable", err)
+	}
+}
+
+// TestRefresh_ConcurrentReaders resolves URLs, ids and routes on sp while
+// Refresh swaps trees. Run with -race.
+func TestRefresh_ConcurrentReaders(t *testing.T) {
+	sp, err := Mount(http.NewServeMux(), &refreshNewPages{}, "/", "App", WithSwappableMux())
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				if got, err := sp.URLFor(Ref("Home")); err != nil || got != "/" {
+					t.Errorf("URLFor(Home) = %q, %v; want /", got, err)
+					return
+				}
+				if pn, _, err := sp.Match(http.MethodGet, "/"); err != nil || pn.Name != "Home" {
+					t.Errorf("Match / = %v, %v; want Home", pn, err)
+					return
+				}
+				if _, err := sp.ID(Ref("Home.Page")); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	for i := range 10 {
+		var page any = &refreshNewPages{}
+		if i%2 == 0 {
+			page = &refreshOldPages{}
+		}
+		if err := sp.Refresh(nil, page, "/", "App"); err != nil {
+			t.Errorf("Refresh: %v", err)
+		}
+	}
+	close(stop)
+	wg.Wait()
+}
@@ -0,0 +1,96 @@
+package structpages
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// RegisterRoute registers h for pattern on the mux the pages were mounted
+// on, like Handle, and records the route so URLFor(ctx, Ref(pattern)),
+// RouteURL and Export know about it:
+//
+//	sp.RegisterRoute(http.MethodGet, "/health", healthHandler)
+//	sp.RegisterRoute("", "/files/{path...}", filesHandler)
+//	url, err := sp.RouteURL("/files/{path...}", "docs/intro.md")
+//
+// method is an HTTP method, or "" or "ALL" for every method. Unlike
+// Handle, the route takes the WithRoutePrefix prefix as page routes do;
+// pattern is the route without it. A pattern can be registered once. It
+// returns ErrNotMounted before Mount.
+func (sp *StructPages) RegisterRoute(method, pattern string, h http.Handler) error {
+	sp.checkWritable()
+	sp = sp.current()
+	if !sp.mounted() {
+		return ErrNotMounted
+	}
+	if !strings.HasPrefix(pattern, "/") {
+		return fmt.Errorf("route %q: pattern must start with /", pattern)
+	}
+	if _, err := parseSegments(pattern); err != nil {
+		return err
+	}
+	if method == "" {
+		method = methodAll
+	}
+	if !sp.pc.addRoute(pattern, method) {
+		return fmt.Errorf("route %s is already registered", pattern)
+	}
+	route := sp.routePrefix + pattern
+	if method != methodAll {
+		route = method + " " + route
+	}
+	sp.mux.Handle(route, h)
+	return nil
+}
+
+// RouteURL returns the URL for the route pattern, registered with
+// RegisterRoute or by a page, with its path parameters filled from args as
+// for URLFor. It is sp.URLFor(Ref(pattern), args...) for callers that only
+// have the pattern.
+func (sp *StructPages) RouteURL(pattern string, args ...any) (string, error) {
+	if !strings.HasPrefix(pattern, "/") {
+		return "", fmt.Errorf("route %q: pattern must start with /", pattern)
+	}
+	return sp.URLFor(Ref(pattern), args...)
+}
+
+// addRoute records a RegisterRoute pattern and its method. It reports false
+// if the pattern is already recorded.
+func (p *parseContext) addRoute(pattern, method string) bool {
+	p.routesMu.Lock()
+	defer p.routesMu.Unlock()
+	if _, ok := p.routes[pattern]; ok {
+		return false
+	}
+	if p.routes == nil {
+		p.routes = make(map[string]string)
+	}
+	p.routes[pattern] = method
+	return true
+}
+
+// hasRoute reports whether pattern was registered with RegisterRoute.
+func (p *parseContext) hasRoute(pattern string) bool {
+	p.routesMu.RLock()
+	defer p.routesMu.RUnlock()
+	_, ok := p.routes[pattern]
+	return ok
+}
+
+// routeExports describes the RegisterRoute routes, sorted by pattern.
+func (p *parseContext) routeExports(prefix string) []RouteExport {
+	p.routesMu.RLock()
+	defer p.routesMu.RUnlock()
+	routes := make([]RouteExport, 0, len(p.routes))
+	for _, pattern := range slices.Sorted(maps.Keys(p.routes)) {
+		routes = append(routes, RouteExport{
+			Method:       p.routes[pattern],
+			Pattern:      prefix + pattern,
+			HasServeHTTP: true,
+		})
+	}
+	return routes
+}
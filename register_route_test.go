@@ -0,0 +1,78 @@
+package structpages
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestRegisterRoute(t *testing.T) {
+	mux := http.NewServeMux()
+	sp, err := Mount(mux, &listPages{}, "/", "App", WithRoutePrefix("/v1"))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write([]byte("ok")) })
+	if err := sp.RegisterRoute(http.MethodGet, "/health", ok); err != nil {
+		t.Fatalf("RegisterRoute: %v", err)
+	}
+	if err := sp.RegisterRoute("", "/files/{owner}/{path...}", ok); err != nil {
+		t.Fatalf("RegisterRoute: %v", err)
+	}
+	if err := sp.RegisterRoute(http.MethodPost, "/health", ok); err == nil {
+		t.Error("RegisterRoute with a registered pattern: want an error")
+	}
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/health", http.NoBody))
+	if rec.Code != http.StatusOK || rec.Body.String() != "ok" {
+		t.Errorf("GET /v1/health = %d %q, want 200 ok", rec.Code, rec.Body.String())
+	}
+
+	tests := []struct {
+		pattern string
+		args    []any
+		want    string
+	}{
+		{"/health", nil, "/v1/health"},
+		{"/files/{owner}/{path...}", []any{"ann", "docs/intro.md"}, "/v1/files/ann/docs/intro.md"},
+		{"/files/{owner}/{path...}", []any{map[string]any{"owner": "bob", "path": "a b"}}, "/v1/files/bob/a%20b"},
+		{"/about", nil, "/v1/about"}, // page routes resolve too
+	}
+	for _, tt := range tests {
+		got, err := sp.RouteURL(tt.pattern, tt.args...)
+		if err != nil || got != tt.want {
+			t.Errorf("RouteURL(%q, %v) = %q, %v; want %q", tt.pattern, tt.args, got, err, tt.want)
+		}
+	}
+	if got, err := URLFor(sp.PageContext(context.Background()), Ref("/health")); err != nil || got != "/v1/health" {
+		t.Errorf("URLFor(Ref(/health)) = %q, %v; want /v1/health", got, err)
+	}
+	if _, err := sp.RouteURL("/metrics"); err == nil {
+		t.Error("RouteURL for an unregistered pattern: want an error")
+	}
+
+	var exported []string
+	for _, r := range sp.Export() {
+		if r.PageName == "" {
+			exported = append(exported, r.Method+" "+r.Pattern)
+		}
+	}
+	want := []string{"ALL /v1/files/{owner}/{path...}", "GET /v1/health"}
+	if !slices.Equal(exported, want) {
+		t.Errorf("Export routes without a page = %q, want %q", exported, want)
+	}
+}
+
+func TestRegisterRoute_NotMounted(t *testing.T) {
+	sp, err := Parse(&listPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if err := sp.RegisterRoute(http.MethodGet, "/health", http.NotFoundHandler()); !errors.Is(err, ErrNotMounted) {
+		t.Errorf("RegisterRoute before Mount = %v, want ErrNotMounted", err)
+	}
+}
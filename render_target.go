@@ -77,6 +77,8 @@ type RenderTarget interface {
 	Is(method any) bool
 }
 
+var renderTargetType = reflect.TypeOf((*RenderTarget)(nil)).Elem()
+
 // TargetSelector determines which component to render for a request.
 // It returns a RenderTarget that will be passed to Props.
 //
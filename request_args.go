@@ -0,0 +1,36 @@
+package structpages
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+)
+
+var (
+	urlPointerType    = reflect.TypeOf((*url.URL)(nil))
+	urlValuesType     = reflect.TypeOf(url.Values(nil))
+	headerPointerType = reflect.TypeOf((*http.Header)(nil))
+)
+
+// requestPartArg fills a *url.URL, url.Values or *http.Header parameter
+// with r.URL, r.URL.Query() or &r.Header of the request among
+// availableArgs. Like the request itself, these never come from the DI
+// registry. ok is false for other types, or when no request is available.
+func requestPartArg(argType reflect.Type, availableArgs map[reflect.Type][]reflect.Value) (reflect.Value, bool) {
+	if argType != urlPointerType && argType != urlValuesType && argType != headerPointerType {
+		return reflect.Value{}, false
+	}
+	reqs := availableArgs[requestPointerType]
+	if len(reqs) == 0 {
+		return reflect.Value{}, false
+	}
+	r := reqs[0].Interface().(*http.Request)
+	switch argType {
+	case urlPointerType:
+		return reflect.ValueOf(r.URL), true
+	case urlValuesType:
+		return reflect.ValueOf(r.URL.Query()), true
+	default:
+		return reflect.ValueOf(&r.Header), true
+	}
+}
@@ -0,0 +1,76 @@
+package structpages
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+type urlArgPage struct{}
+
+func (urlArgPage) Props(u *url.URL) (string, error) { return u.Path + "?" + u.RawQuery, nil }
+
+func (urlArgPage) Page(s string) component { return testComponent{s} }
+
+type queryArgPage struct{}
+
+func (queryArgPage) Props(q url.Values) (string, error) { return q.Get("q"), nil }
+
+func (queryArgPage) Page(s string) component { return testComponent{s} }
+
+type headerArgPage struct{}
+
+func (headerArgPage) Props(h *http.Header) (string, error) { return h.Get("X-Test"), nil }
+
+func (headerArgPage) Page(s string) component { return testComponent{s} }
+
+type requestAndURLPage struct{}
+
+func (requestAndURLPage) Props(r *http.Request, u *url.URL) (string, error) {
+	if r.URL != u {
+		return "different", nil
+	}
+	return "same", nil
+}
+
+func (requestAndURLPage) Page(s string) component { return testComponent{s} }
+
+type requestArgPages struct {
+	URL    urlArgPage        `route:"/url URL"`
+	Query  queryArgPage      `route:"/query Query"`
+	Header headerArgPage     `route:"/header Header"`
+	Both   requestAndURLPage `route:"/both Both"`
+}
+
+func TestRequestPartArgs(t *testing.T) {
+	// Registered values of the same types must not be injected instead.
+	staticURL, _ := url.Parse("http://static.example.com/static")
+	staticHeader := http.Header{"X-Test": {"static"}}
+	mux := http.NewServeMux()
+	sp, err := Validate(requestArgPages{}, "/", "App",
+		WithArgs(staticURL, url.Values{"q": {"static"}}, &staticHeader))
+	if err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if err := sp.Mount(mux); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	tests := []struct{ path, want string }{
+		{"/url?a=1", "/url?a=1"},
+		{"/query?q=go", "go"},
+		{"/header", "from request"},
+		{"/both", "same"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+			req.Header.Set("X-Test", "from request")
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+			if rec.Body.String() != tt.want {
+				t.Errorf("body = %q, want %q", rec.Body.String(), tt.want)
+			}
+		})
+	}
+}
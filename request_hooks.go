@@ -0,0 +1,107 @@
+package structpages
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/jackielii/ctxkey"
+)
+
+// requestErrCtx carries the error the error handler received for the
+// current request, so WithAfterRequest hooks can report it.
+var requestErrCtx = ctxkey.New[*requestErr]("structpages.requestErr", nil)
+
+type requestErr struct{ err error }
+
+// WithOnRequest adds fn to the hooks called for every request to a page
+// rendered by structpages (pages with Props or components; ServeHTTP pages
+// are not included), after the RenderTarget is selected and before Props
+// runs. Hooks run in the order they were added. They are for observation
+// such as audit logging: they cannot change the request or response, and a
+// panicking hook is logged and skipped.
+func WithOnRequest(fn func(r *http.Request, pn *PageNode)) func(*StructPages) {
+	return func(r *StructPages) {
+		r.onRequest = append(r.onRequest, fn)
+	}
+}
+
+// WithAfterRequest adds fn to the hooks called when such a request is done,
+// with the time spent handling it and the error passed to the error
+// handler, or nil. Like WithOnRequest hooks they run in order, observe
+// only, and have their panics logged. They also run when Props returned
+// ErrSkipPageRender or target selection failed.
+func WithAfterRequest(fn func(r *http.Request, pn *PageNode, duration time.Duration, err error)) func(*StructPages) {
+	return func(r *StructPages) {
+		r.afterRequest = append(r.afterRequest, fn)
+	}
+}
+
+// WithAfterRender adds fn to the hooks called after a component rendered
+// successfully, before the body is written to the response, with the page,
+// the component's name (the method name of the selected target, or the
+// function name for a RenderComponent function, "" for a component value),
+// the size of the rendered body and the time Render took. Hooks run in
+// order on the request's goroutine, so keep them fast; like WithOnRequest
+// hooks they observe only and have their panics logged. StreamComponent
+// renders are not reported.
+func WithAfterRender(
+	fn func(pn *PageNode, componentName string, bodyBytes int, duration time.Duration),
+) func(*StructPages) {
+	return func(r *StructPages) {
+		r.afterRender = append(r.afterRender, fn)
+	}
+}
+
+// recordErrors wraps onError so it also stores the error for the
+// WithAfterRequest hooks of the request.
+func recordErrors(
+	onError func(http.ResponseWriter, *http.Request, error),
+) func(http.ResponseWriter, *http.Request, error) {
+	return func(w http.ResponseWriter, r *http.Request, err error) {
+		if rec := requestErrCtx.Value(r.Context()); rec != nil {
+			rec.err = err
+		}
+		onError(w, r, err)
+	}
+}
+
+// runOnRequest calls the WithOnRequest hooks.
+func (sp *StructPages) runOnRequest(r *http.Request, pn *PageNode) {
+	for _, fn := range sp.onRequest {
+		func() {
+			defer recoverHook("OnRequest", pn)
+			fn(r, pn)
+		}()
+	}
+}
+
+// runAfterRequest calls the WithAfterRequest hooks.
+func (sp *StructPages) runAfterRequest(r *http.Request, pn *PageNode, d time.Duration, err error) {
+	for _, fn := range sp.afterRequest {
+		func() {
+			defer recoverHook("AfterRequest", pn)
+			fn(r, pn, d, err)
+		}()
+	}
+}
+
+// runAfterRender calls the WithAfterRender hooks.
+func (sp *StructPages) runAfterRender(pn *PageNode, name string, n int, d time.Duration) {
+	for _, fn := range sp.afterRender {
+		func() {
+			defer recoverHook("AfterRender", pn)
+			fn(pn, name, n, d)
+		}()
+	}
+}
+
+func recoverHook(hook string, pn *PageNode) {
+	if v := recover(); v != nil {
+		name := ""
+		if pn != nil {
+			name = pn.Name
+		}
+		log.Printf("structpages: %s hook panicked for page %s: %v", hook, name, v)
+	}
+}
@@ -0,0 +1,173 @@
+package structpages
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+type hooksPage struct{}
+
+func (hooksPage) Props(r *http.Request, calls *[]string) (string, error) {
+	*calls = append(*calls, "props")
+	switch r.URL.Query().Get("mode") {
+	case "fail":
+		return "", errors.New("props failed")
+	case "skip":
+		return "", ErrSkipPageRender
+	}
+	return "ok", nil
+}
+
+func (hooksPage) Page(s string) component { return testComponent{s} }
+
+type hooksPages struct {
+	hooksPage `route:"/ Hooks"`
+}
+
+type hookCall struct {
+	name string
+	err  error
+}
+
+func TestRequestHooks(t *testing.T) {
+	var calls []string
+	var after []hookCall
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &hooksPages{}, "/", "App",
+		WithArgs(&calls),
+		WithOnRequest(func(r *http.Request, pn *PageNode) { calls = append(calls, "on1:"+pn.Name) }),
+		WithOnRequest(func(r *http.Request, pn *PageNode) { calls = append(calls, "on2") }),
+		WithAfterRequest(func(r *http.Request, pn *PageNode, d time.Duration, err error) {
+			if d <= 0 {
+				t.Errorf("duration = %v, want > 0", d)
+			}
+			calls = append(calls, "after")
+			after = append(after, hookCall{pn.Name, err})
+		}),
+		WithErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+
+	tests := []struct {
+		mode    string
+		wantErr string
+	}{
+		{mode: ""},
+		{mode: "fail", wantErr: "props failed"},
+		{mode: "skip"},
+	}
+	for _, tt := range tests {
+		t.Run("mode="+tt.mode, func(t *testing.T) {
+			calls, after = nil, nil
+			mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/?mode="+tt.mode, http.NoBody))
+			if got := strings.Join(calls, ","); got != "on1:hooksPage,on2,props,after" {
+				t.Errorf("calls = %s, want on1:hooksPage,on2,props,after", got)
+			}
+			if len(after) != 1 {
+				t.Fatalf("after hook ran %d times, want 1", len(after))
+			}
+			if gotErr := after[0].err; (gotErr == nil) != (tt.wantErr == "") ||
+				(gotErr != nil && !strings.Contains(gotErr.Error(), tt.wantErr)) {
+				t.Errorf("after err = %v, want %q", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRequestHooks_PanicIsLogged(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	var calls []string
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &hooksPages{}, "/", "App",
+		WithArgs(&calls),
+		WithOnRequest(func(r *http.Request, pn *PageNode) { panic("audit down") }),
+		WithAfterRequest(func(r *http.Request, pn *PageNode, d time.Duration, err error) { panic("metrics down") }))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	if rec.Code != http.StatusOK || rec.Body.String() != "ok" {
+		t.Errorf("got %d %q, want 200 ok", rec.Code, rec.Body.String())
+	}
+	for _, want := range []string{"OnRequest hook panicked for page hooksPage: audit down", "AfterRequest hook panicked"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("log = %q, want %q", buf.String(), want)
+		}
+	}
+}
+
+type renderHookPage struct{}
+
+func (renderHookPage) Props(r *http.Request) (string, error) {
+	if r.URL.Query().Has("skip") {
+		return "", ErrSkipPageRender
+	}
+	return "", nil
+}
+
+func (renderHookPage) Page() component {
+	return fnComponent(func(ctx context.Context, w io.Writer) error {
+		time.Sleep(time.Millisecond)
+		_, err := io.WriteString(w, "full page")
+		return err
+	})
+}
+
+func (renderHookPage) Row() component { return testComponent{"row"} }
+
+type renderCall struct {
+	page, component string
+	bytes           int
+	duration        time.Duration
+}
+
+func TestWithAfterRender(t *testing.T) {
+	var calls []renderCall
+	record := func(pn *PageNode, name string, n int, d time.Duration) {
+		calls = append(calls, renderCall{pn.Name, name, n, d})
+	}
+	var second int
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &struct {
+		Report renderHookPage `route:"/report Report"`
+	}{}, "/", "App", WithAfterRender(record), WithAfterRender(func(*PageNode, string, int, time.Duration) {
+		second++
+	}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/report", http.NoBody))
+	req := httptest.NewRequest(http.MethodGet, "/report", http.NoBody)
+	req.Header.Set("HX-Request", "true")
+	req.Header.Set("HX-Target", "report-row")
+	mux.ServeHTTP(httptest.NewRecorder(), req)
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/report?skip", http.NoBody))
+
+	if len(calls) != 2 || second != 2 {
+		t.Fatalf("hooks ran %d and %d times, want 2 each (none for ErrSkipPageRender): %+v", len(calls), second, calls)
+	}
+	if c := calls[0]; c.page != "Report" || c.component != "Page" || c.bytes != len("full page") ||
+		c.duration < time.Millisecond {
+		t.Errorf("full render = %+v, want Report.Page, %d bytes, at least 1ms", c, len("full page"))
+	}
+	if c := calls[1]; c.component != "Row" || c.bytes != len("row") {
+		t.Errorf("partial render = %+v, want Row with %d bytes", c, len("row"))
+	}
+}
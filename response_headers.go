@@ -0,0 +1,125 @@
+package structpages
+
+import (
+	"maps"
+	"net/http"
+)
+
+// ResponseHeadersPolicy decides what happens when a header configured with
+// WithResponseHeaders or WithResponseHeadersFunc has already been set by a
+// page, its Props, or a middleware.
+type ResponseHeadersPolicy int
+
+const (
+	// ResponseHeadersOverride replaces headers the page already set with the
+	// configured values. This is the default.
+	ResponseHeadersOverride ResponseHeadersPolicy = iota
+	// ResponseHeadersPreserve keeps headers the page already set and only
+	// fills in the missing ones.
+	ResponseHeadersPreserve
+)
+
+// WithResponseHeaders sets headers on every response produced by
+// structpages, including HTMX partials and error responses, e.g. security
+// headers such as Strict-Transport-Security or X-Content-Type-Options.
+// Repeated calls merge, later values winning.
+//
+// The headers are applied when the response is written, so the policy set by
+// WithResponseHeadersPolicy decides between them and values set by Props or
+// page middlewares.
+func WithResponseHeaders(headers map[string]string) func(*StructPages) {
+	return func(r *StructPages) {
+		if r.responseHeaders == nil {
+			r.responseHeaders = make(map[string]string, len(headers))
+		}
+		maps.Copy(r.responseHeaders, headers)
+	}
+}
+
+// WithResponseHeadersFunc adds headers computed per request, e.g. a
+// Content-Security-Policy that depends on the page. Its result is applied
+// after the static WithResponseHeaders values and takes precedence over them.
+func WithResponseHeadersFunc(fn func(*http.Request, *PageNode) map[string]string) func(*StructPages) {
+	return func(r *StructPages) {
+		r.responseHeadersFuncs = append(r.responseHeadersFuncs, fn)
+	}
+}
+
+// WithResponseHeadersPolicy sets whether configured response headers
+// override or preserve values already set by the page. The default is
+// ResponseHeadersOverride.
+func WithResponseHeadersPolicy(policy ResponseHeadersPolicy) func(*StructPages) {
+	return func(r *StructPages) {
+		r.responseHeadersPolicy = policy
+	}
+}
+
+// responseHeadersMiddleware returns the outermost global middleware applying
+// the configured headers, or nil when none are configured.
+func (sp *StructPages) responseHeadersMiddleware() MiddlewareFunc {
+	if len(sp.responseHeaders) == 0 && len(sp.responseHeadersFuncs) == 0 {
+		return nil
+	}
+	return func(next http.Handler, pn *PageNode) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			headers := maps.Clone(sp.responseHeaders)
+			for _, fn := range sp.responseHeadersFuncs {
+				if headers == nil {
+					headers = make(map[string]string)
+				}
+				maps.Copy(headers, fn(r, pn))
+			}
+			hw := &headersWriter{ResponseWriter: w, headers: headers, policy: sp.responseHeadersPolicy}
+			next.ServeHTTP(hw, r)
+			hw.apply()
+		})
+	}
+}
+
+// headersWriter applies headers just before the status line is written,
+// after the handler has had its chance to set them.
+type headersWriter struct {
+	http.ResponseWriter
+	headers map[string]string
+	policy  ResponseHeadersPolicy
+	applied bool
+}
+
+func (w *headersWriter) apply() {
+	if w.applied {
+		return
+	}
+	w.applied = true
+	h := w.ResponseWriter.Header()
+	for k, v := range w.headers {
+		if w.policy == ResponseHeadersPreserve && h.Get(k) != "" {
+			continue
+		}
+		h.Set(k, v)
+	}
+}
+
+func (w *headersWriter) WriteHeader(statusCode int) {
+	w.apply()
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *headersWriter) Write(b []byte) (int, error) {
+	w.apply()
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *headersWriter) Flush() {
+	w.apply()
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the underlying ResponseWriter for http.ResponseController.
+func (w *headersWriter) Unwrap() http.ResponseWriter { return w.ResponseWriter }
+
+// Push forwards an HTTP/2 server push to the underlying ResponseWriter.
+func (w *headersWriter) Push(target string, opts *http.PushOptions) error {
+	return push(w.ResponseWriter, target, opts)
+}
@@ -0,0 +1,113 @@
+package structpages
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type headersIndexPage struct{}
+
+func (headersIndexPage) Page() component { return testComponent{"index"} }
+
+func (headersIndexPage) Partial() component { return testComponent{"partial"} }
+
+type headersFramePage struct{}
+
+func (headersFramePage) Props(w http.ResponseWriter) error {
+	w.Header().Set("X-Frame-Options", "SAMEORIGIN")
+	return nil
+}
+
+func (headersFramePage) Page() component { return testComponent{"frame"} }
+
+type headersHandlerPage struct{}
+
+func (headersHandlerPage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusAccepted)
+}
+
+type headersPages struct {
+	Index   headersIndexPage   `route:"/{$} Index"`
+	Frame   headersFramePage   `route:"/frame Frame"`
+	Handler headersHandlerPage `route:"POST /submit Submit"`
+}
+
+var securityHeaders = map[string]string{
+	"X-Frame-Options":        "DENY",
+	"X-Content-Type-Options": "nosniff",
+}
+
+func TestWithResponseHeaders(t *testing.T) {
+	mux := http.NewServeMux()
+	sp, err := Mount(mux, &headersPages{}, "/", "App", WithResponseHeaders(securityHeaders))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	partialID, err := sp.ID(headersIndexPage.Partial)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name, method, path, hxTarget, wantFrame string
+	}{
+		{name: "page", method: http.MethodGet, path: "/", wantFrame: "DENY"},
+		{name: "htmx partial", method: http.MethodGet, path: "/", hxTarget: partialID, wantFrame: "DENY"},
+		{name: "Props header overridden", method: http.MethodGet, path: "/frame", wantFrame: "DENY"},
+		{name: "handler without body", method: http.MethodPost, path: "/submit", wantFrame: "DENY"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, http.NoBody)
+			if tt.hxTarget != "" {
+				req.Header.Set("HX-Request", "true")
+				req.Header.Set("HX-Target", tt.hxTarget)
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+			if got := rec.Header().Get("X-Frame-Options"); got != tt.wantFrame {
+				t.Errorf("X-Frame-Options = %q, want %q", got, tt.wantFrame)
+			}
+			if got := rec.Header().Get("X-Content-Type-Options"); got != "nosniff" {
+				t.Errorf("X-Content-Type-Options = %q, want nosniff", got)
+			}
+		})
+	}
+}
+
+func TestWithResponseHeadersPolicy_Preserve(t *testing.T) {
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &headersPages{}, "/", "App",
+		WithResponseHeaders(securityHeaders),
+		WithResponseHeadersPolicy(ResponseHeadersPreserve))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	for path, want := range map[string]string{"/frame": "SAMEORIGIN", "/": "DENY"} {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+		if got := rec.Header().Get("X-Frame-Options"); got != want {
+			t.Errorf("GET %s: X-Frame-Options = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestWithResponseHeadersFunc(t *testing.T) {
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &headersPages{}, "/", "App",
+		WithResponseHeaders(map[string]string{"X-Page": "static", "X-Static": "yes"}),
+		WithResponseHeadersFunc(func(r *http.Request, pn *PageNode) map[string]string {
+			return map[string]string{"X-Page": pn.Name, "X-Path": r.URL.Path}
+		}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/frame", http.NoBody))
+	for k, want := range map[string]string{"X-Page": "Frame", "X-Path": "/frame", "X-Static": "yes"} {
+		if got := rec.Header().Get(k); got != want {
+			t.Errorf("%s = %q, want %q", k, got, want)
+		}
+	}
+}
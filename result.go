@@ -0,0 +1,98 @@
+package structpages
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+)
+
+// Result is a Props return value that sets the response status, headers
+// and cookies along with the component to render:
+//
+//	func (p createUser) Props(r *http.Request) (structpages.Result[templ.Component], error) {
+//	    u, err := p.store.Create(r.Context(), r.FormValue("name"))
+//	    if err != nil {
+//	        return structpages.Result[templ.Component]{}, err
+//	    }
+//	    return structpages.Result[templ.Component]{
+//	        Status:    http.StatusCreated,
+//	        Headers:   map[string]string{"Location": "/users/" + u.ID},
+//	        Component: p.Row(u),
+//	    }, nil
+//	}
+//
+// Component renders as with RenderComponent, and must not be nil. A zero
+// Status leaves the default 200. Headers replace values already set, and
+// run before the page's HXTrigger and swap headers are added.
+type Result[T Component] struct {
+	Status    int
+	Headers   map[string]string
+	Component T
+	Cookies   []*http.Cookie
+}
+
+// ResultProvider is implemented by Result, whatever its component type.
+// A Props method whose first result implements it answers the request with
+// that Result.
+type ResultProvider interface {
+	Result() Result[Component]
+}
+
+// Result returns r with its component as a Component.
+func (r Result[T]) Result() Result[Component] {
+	return Result[Component]{Status: r.Status, Headers: r.Headers, Component: r.Component, Cookies: r.Cookies}
+}
+
+var resultProviderType = reflect.TypeFor[ResultProvider]()
+
+// returnsResult reports whether m's first result is a Result.
+func returnsResult(m *reflect.Method) bool {
+	return m.Type.NumOut() > 0 && m.Type.Out(0).Implements(resultProviderType)
+}
+
+// applyResult writes the headers and cookies of res to w and returns the
+// renderOp for its component.
+func applyResult(w http.ResponseWriter, res Result[Component]) (*renderOp, error) {
+	if res.Component == nil {
+		return nil, errors.New("result has no Component")
+	}
+	for k, v := range res.Headers {
+		w.Header().Set(k, v)
+	}
+	for _, c := range res.Cookies {
+		http.SetCookie(w, c)
+	}
+	return &renderOp{component: res.Component, status: res.Status}, nil
+}
+
+// statusWriter sends status with the first write, unless the handler set
+// one itself, such as an error response.
+type statusWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (w *statusWriter) WriteHeader(statusCode int) {
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(w.status)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *statusWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(w.status)
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the underlying ResponseWriter for http.ResponseController.
+func (w *statusWriter) Unwrap() http.ResponseWriter { return w.ResponseWriter }
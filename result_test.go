@@ -0,0 +1,74 @@
+package structpages
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type resultPage struct{}
+
+func (resultPage) Props(r *http.Request) (Result[testComponent], error) {
+	return Result[testComponent]{
+		Status:    http.StatusCreated,
+		Headers:   map[string]string{"Location": "/items/7"},
+		Component: testComponent{"created"},
+		Cookies:   []*http.Cookie{{Name: "last", Value: "7"}},
+	}, nil
+}
+
+func (resultPage) Page() component { return testComponent{"page"} }
+
+func (resultPage) HXTrigger(r *http.Request) ([]HXEvent, error) {
+	return []HXEvent{HXEventSimple("itemCreated")}, nil
+}
+
+type resultIfacePage struct{}
+
+func (resultIfacePage) Props(r *http.Request) (Result[Component], error) {
+	if r.URL.Query().Has("empty") {
+		return Result[Component]{Status: http.StatusAccepted}, nil
+	}
+	return Result[Component]{Status: http.StatusAccepted, Component: testComponent{"queued"}}, nil
+}
+
+func TestResult(t *testing.T) {
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &struct {
+		Create resultPage      `route:"POST /items Create"`
+		Queue  resultIfacePage `route:"/queue Queue"`
+	}{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/items", http.NoBody))
+	if rec.Code != http.StatusCreated {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusCreated)
+	}
+	if got := rec.Body.String(); got != "created" {
+		t.Errorf("body = %q, want the Result component", got)
+	}
+	if got := rec.Header().Get("Location"); got != "/items/7" {
+		t.Errorf("Location = %q, want /items/7", got)
+	}
+	if got := rec.Header().Get("HX-Trigger"); got != "itemCreated" {
+		t.Errorf("HX-Trigger = %q, want itemCreated", got)
+	}
+	if cookies := rec.Result().Cookies(); len(cookies) != 1 || cookies[0].Name != "last" || cookies[0].Value != "7" {
+		t.Errorf("cookies = %v, want last=7", cookies)
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/queue", http.NoBody))
+	if rec.Code != http.StatusAccepted || rec.Body.String() != "queued" {
+		t.Errorf("Result[Component] = %d %q, want 202 %q", rec.Code, rec.Body.String(), "queued")
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/queue?empty", http.NoBody))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Result without a Component: status = %d, want 500", rec.Code)
+	}
+}
@@ -0,0 +1,92 @@
+package structpages
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrPageNotFound is returned (wrapped) by URLFor and friends when no page
+// matches the lookup, including pages disabled with StructPages.Revert.
+var ErrPageNotFound = errors.New("structpages: page not found")
+
+// Revert disables page and every page below it at runtime: their routes
+// answer 404 and URLFor no longer finds them. page is identified the same
+// way as in URLFor (a page value, Ref or func(*PageNode) bool predicate).
+//
+// http.ServeMux cannot deregister a pattern, so the routes stay registered
+// and the mux slot stays allocated; the handler checks the revert on every
+// request. Restore undoes a Revert. This is meant for tests, plugins and
+// feature toggles, not for reshaping the tree under load.
+func (sp *StructPages) Revert(page any) error {
+	sp.checkWritable()
+	sp = sp.current()
+	pn, err := sp.pc.lookupPageNode(page, false)
+	if err != nil {
+		return fmt.Errorf("revert: %w", err)
+	}
+	sp.pc.setReverted(pn, true)
+	return nil
+}
+
+// RevertAll disables every page of the tree, as Revert on the root does.
+func (sp *StructPages) RevertAll() error {
+	sp.checkWritable()
+	sp = sp.current()
+	sp.pc.setReverted(sp.pc.root(), true)
+	return nil
+}
+
+// Restore re-enables a page disabled by Revert, together with the pages
+// below it that were not reverted on their own.
+func (sp *StructPages) Restore(page any) error {
+	sp.checkWritable()
+	sp = sp.current()
+	pn, err := sp.pc.lookupPageNode(page, false)
+	if err != nil {
+		return fmt.Errorf("restore: %w", err)
+	}
+	sp.pc.setReverted(pn, false)
+	return nil
+}
+
+// setReverted records pn by its key, so the revert also covers the copies
+// AddChildRoute makes of the tree.
+func (p *parseContext) setReverted(pn *PageNode, reverted bool) {
+	p.revertedMu.Lock()
+	defer p.revertedMu.Unlock()
+	if !reverted {
+		delete(p.reverted, pn.key())
+		return
+	}
+	if p.reverted == nil {
+		p.reverted = make(map[*PageNode]struct{})
+	}
+	p.reverted[pn.key()] = struct{}{}
+}
+
+// isReverted reports whether pn or one of its ancestors has been reverted.
+func (p *parseContext) isReverted(pn *PageNode) bool {
+	p.revertedMu.RLock()
+	defer p.revertedMu.RUnlock()
+	if len(p.reverted) == 0 {
+		return false
+	}
+	for n := pn; n != nil; n = n.Parent {
+		if _, ok := p.reverted[n.key()]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// withRevert answers 404 for a page disabled by Revert.
+func (sp *StructPages) withRevert(next http.Handler, pn *PageNode) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if sp.pc.isReverted(pn) {
+			http.NotFound(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
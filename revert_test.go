@@ -0,0 +1,105 @@
+package structpages
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type revertIndexPage struct{}
+
+func (revertIndexPage) Page() component { return testComponent{"index"} }
+
+type revertPluginPage struct{}
+
+func (revertPluginPage) Page() component { return testComponent{"plugin"} }
+
+type revertPluginSettingsPage struct{}
+
+func (revertPluginSettingsPage) Page() component { return testComponent{"settings"} }
+
+type revertPluginSection struct {
+	revertPluginPage         `route:"/{$} Plugin"`
+	revertPluginSettingsPage `route:"/settings Settings"`
+}
+
+type revertPages struct {
+	revertIndexPage     `route:"/{$} Index"`
+	revertPluginSection `route:"/plugin/ Section"`
+}
+
+func revertStatus(mux http.Handler, path string) int {
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+	return rec.Code
+}
+
+func TestRevert(t *testing.T) {
+	mux := http.NewServeMux()
+	sp, err := Mount(mux, &revertPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	if err := sp.Revert(revertPluginSection{}); err != nil {
+		t.Fatalf("Revert: %v", err)
+	}
+
+	for path, want := range map[string]int{
+		"/":                http.StatusOK,
+		"/plugin/":         http.StatusNotFound,
+		"/plugin/settings": http.StatusNotFound,
+	} {
+		if got := revertStatus(mux, path); got != want {
+			t.Errorf("GET %s = %d, want %d", path, got, want)
+		}
+	}
+	if _, err := sp.URLFor(revertPluginSettingsPage{}); !errors.Is(err, ErrPageNotFound) {
+		t.Errorf("URLFor after Revert: err = %v, want ErrPageNotFound", err)
+	}
+	if _, err := sp.URLFor(Ref("/plugin/settings")); !errors.Is(err, ErrPageNotFound) {
+		t.Errorf("URLFor(Ref) after Revert: err = %v, want ErrPageNotFound", err)
+	}
+	if _, _, err := sp.Match(http.MethodGet, "/plugin/settings"); !errors.Is(err, ErrRouteNotFound) {
+		t.Errorf("Match after Revert: err = %v, want ErrRouteNotFound", err)
+	}
+
+	if err := sp.Restore(revertPluginSection{}); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if got := revertStatus(mux, "/plugin/settings"); got != http.StatusOK {
+		t.Errorf("GET /plugin/settings after Restore = %d, want 200", got)
+	}
+	if url, err := sp.URLFor(revertPluginSettingsPage{}); err != nil || url != "/plugin/settings" {
+		t.Errorf("URLFor after Restore = %q, %v", url, err)
+	}
+}
+
+func TestRevertAll(t *testing.T) {
+	mux := http.NewServeMux()
+	sp, err := Mount(mux, &revertPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	if err := sp.RevertAll(); err != nil {
+		t.Fatalf("RevertAll: %v", err)
+	}
+	for _, path := range []string{"/", "/plugin/", "/plugin/settings"} {
+		if got := revertStatus(mux, path); got != http.StatusNotFound {
+			t.Errorf("GET %s = %d, want 404", path, got)
+		}
+	}
+	if _, err := sp.URLFor(revertIndexPage{}); !errors.Is(err, ErrPageNotFound) {
+		t.Errorf("URLFor after RevertAll: err = %v, want ErrPageNotFound", err)
+	}
+}
+
+func TestRevert_UnknownPage(t *testing.T) {
+	sp, err := Mount(http.NewServeMux(), &revertPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	if err := sp.Revert(headersFramePage{}); !errors.Is(err, ErrPageNotFound) {
+		t.Errorf("err = %v, want ErrPageNotFound", err)
+	}
+}
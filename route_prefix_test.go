@@ -0,0 +1,109 @@
+package structpages
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type prefixUserPage struct{}
+
+func (prefixUserPage) Page() component { return testComponent{content: "user"} }
+
+type prefixSettingsPage struct{}
+
+func (prefixSettingsPage) Page() component { return testComponent{content: "settings"} }
+
+type prefixAdmin struct {
+	settings prefixSettingsPage `route:"/settings Settings"`
+}
+
+type prefixRoot struct {
+	users subpathUsers   `route:"GET /users Users"`
+	user  prefixUserPage `route:"GET /users/{id} User"`
+	admin prefixAdmin    `route:"/admin Admin"`
+	home  subpathHome    `route:"GET /{$} Home"`
+}
+
+func TestWithRoutePrefix(t *testing.T) {
+	tests := []struct {
+		name   string
+		route  string
+		prefix string
+		paths  map[string]int
+		urls   map[any]string
+	}{
+		{
+			name:   "mounted at root",
+			route:  "/",
+			prefix: "/v2",
+			paths: map[string]int{
+				"/v2/users":          http.StatusOK,
+				"/v2/users/7":        http.StatusOK,
+				"/v2/admin/settings": http.StatusOK,
+				"/v2/":               http.StatusOK,
+				"/users":             http.StatusNotFound,
+				"/v2/v2/users":       http.StatusNotFound,
+			},
+			urls: map[any]string{
+				subpathUsers{}:       "/v2/users",
+				prefixSettingsPage{}: "/v2/admin/settings",
+			},
+		},
+		{
+			name:   "combined with Mount at a subpath",
+			route:  "/app",
+			prefix: "v2/", // normalized to /v2
+			paths: map[string]int{
+				"/v2/app/users":          http.StatusOK,
+				"/v2/app/admin/settings": http.StatusOK,
+				"/app/users":             http.StatusNotFound,
+			},
+			urls: map[any]string{
+				subpathUsers{}:       "/v2/app/users",
+				prefixSettingsPage{}: "/v2/app/admin/settings",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			sp, err := Mount(mux, prefixRoot{}, tt.route, "App", WithRoutePrefix(tt.prefix))
+			if err != nil {
+				t.Fatalf("Mount: %v", err)
+			}
+			for path, want := range tt.paths {
+				rec := httptest.NewRecorder()
+				mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+				if rec.Code != want {
+					t.Errorf("GET %s: status = %d, want %d", path, rec.Code, want)
+				}
+			}
+			for page, want := range tt.urls {
+				got, err := sp.URLFor(page)
+				if err != nil {
+					t.Fatalf("URLFor(%T): %v", page, err)
+				}
+				if got != want {
+					t.Errorf("URLFor(%T) = %q, want %q", page, got, want)
+				}
+			}
+			if got, err := sp.URLFor(prefixUserPage{}, "42"); err != nil || got != tt.urls[subpathUsers{}]+"/42" {
+				t.Errorf("URLFor(user, 42) = %q, %v", got, err)
+			}
+			if users, err := sp.pc.findPageNode(subpathUsers{}); err != nil || users.Route != "/users" {
+				t.Errorf("PageNode.Route = %q, %v; want the declared /users", users.Route, err)
+			}
+		})
+	}
+}
+
+func TestWithRoutePrefix_WithURLPrefix(t *testing.T) {
+	sp, err := Parse(prefixRoot{}, "/", "App", WithRoutePrefix("/v2"), WithURLPrefix("/edge"))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if got, err := sp.URLFor(subpathUsers{}); err != nil || got != "/edge/v2/users" {
+		t.Errorf("URLFor = %q, %v; want /edge/v2/users", got, err)
+	}
+}
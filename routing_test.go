@@ -151,10 +151,10 @@ func TestRegisterPageItem_ErrorScenarios(t *testing.T) {
 
 			sp.pc = pc // Set the pc on the StructPages instance
 			if tt.setupPage != nil {
-				tt.setupPage(pc.root)
+				tt.setupPage(pc.root())
 			}
 
-			err = sp.registerPageItem(mux, pc.root, tt.middlewares)
+			err = sp.registerPageItem(mux, pc.root(), tt.middlewares)
 			if tt.wantErr != "" {
 				if err == nil {
 					t.Errorf("expected error containing %q, got nil", tt.wantErr)
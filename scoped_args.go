@@ -0,0 +1,100 @@
+package structpages
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"reflect"
+
+	"github.com/jackielii/ctxkey"
+)
+
+// argScope is the request-scoped DI registry stored by WithScopedArg.
+type argScope struct {
+	args     []any
+	registry argRegistry
+}
+
+var scopedArgsCtx = ctxkey.New[*argScope]("structpages.scopedArgs", nil)
+
+// WithScopedArg returns a copy of ctx carrying dependency injection args for
+// a single request. When a page method runs for a request whose context
+// carries scoped args, they take precedence over values registered with
+// WithArgs, e.g. a per-tenant database handle set by a middleware:
+//
+//	func tenantDB(next http.Handler, pn *structpages.PageNode) http.Handler {
+//	    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//	        ctx := structpages.WithScopedArg(r.Context(), dbForTenant(r))
+//	        next.ServeHTTP(w, r.WithContext(ctx))
+//	    })
+//	}
+//
+// Calls nest: a value replaces an outer scoped value of the same type.
+func WithScopedArg(ctx context.Context, args ...any) context.Context {
+	scope := &argScope{registry: make(argRegistry)}
+	if parent := scopedArgsCtx.Value(ctx); parent != nil {
+		maps.Copy(scope.registry, parent.registry)
+		for _, v := range parent.args {
+			if !hasArgOfType(args, reflect.TypeOf(v)) {
+				scope.args = append(scope.args, v)
+			}
+		}
+	}
+	for _, v := range args {
+		if v == nil {
+			continue
+		}
+		scope.registry[reflect.TypeOf(v)] = reflect.ValueOf(v)
+		scope.args = append(scope.args, v)
+	}
+	return scopedArgsCtx.WithValue(ctx, scope)
+}
+
+// ScopedArgs returns the args stored in r's context by WithScopedArg.
+func ScopedArgs(r *http.Request) []any {
+	scope := scopedArgsCtx.Value(r.Context())
+	if scope == nil {
+		return nil
+	}
+	return scope.args
+}
+
+func hasArgOfType(args []any, t reflect.Type) bool {
+	for _, v := range args {
+		if v != nil && reflect.TypeOf(v) == t {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveScoped looks t up in the scoped args of the request being served,
+// if any, following WithDIAlias aliases like resolveRegistered.
+func (p *parseContext) resolveScoped(
+	t reflect.Type, availableArgs map[reflect.Type][]reflect.Value,
+) (reflect.Value, error) {
+	reqs := availableArgs[requestPointerType]
+	if len(reqs) == 0 {
+		return reflect.Value{}, nil
+	}
+	scope := scopedArgsCtx.Value(reqs[0].Interface().(*http.Request).Context())
+	if scope == nil {
+		return reflect.Value{}, nil
+	}
+	p.argsMu.RLock()
+	to, ok := p.aliases[t]
+	p.argsMu.RUnlock()
+	if ok {
+		v, err := scope.registry.resolveArg(to)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("scoped alias %s -> %s: %w", t, to, err)
+		}
+		return v, nil
+	}
+	v, err := scope.registry.resolveArg(t)
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("scoped args: %w", err)
+	}
+	return v, nil
+}
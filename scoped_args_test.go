@@ -0,0 +1,75 @@
+package structpages
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type tenantDB struct{ name string }
+
+type tenantPage struct{}
+
+func (tenantPage) Props(db *tenantDB, cfg *updateArgConfig) (string, error) {
+	return db.name + " " + cfg.greeting, nil
+}
+
+func (tenantPage) Page(s string) component { return testComponent{s} }
+
+type tenantHandlerPage struct{}
+
+func (tenantHandlerPage) ServeHTTP(w http.ResponseWriter, r *http.Request, db *tenantDB) {
+	_, _ = w.Write([]byte(db.name))
+}
+
+type tenantPages struct {
+	Home    tenantPage        `route:"/{$} Home"`
+	Handler tenantHandlerPage `route:"/raw Raw"`
+}
+
+func tenantMiddleware(next http.Handler, pn *PageNode) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tenant := r.URL.Query().Get("tenant"); tenant != "" {
+			r = r.WithContext(WithScopedArg(r.Context(), &tenantDB{name: tenant}))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func TestWithScopedArg(t *testing.T) {
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &tenantPages{}, "/", "App",
+		WithArgs(&tenantDB{name: "global"}, &updateArgConfig{greeting: "hi"}),
+		WithMiddlewares(tenantMiddleware))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	tests := []struct{ path, want string }{
+		{"/", "global hi"},
+		{"/?tenant=acme", "acme hi"},
+		{"/raw", "global"},
+		{"/raw?tenant=acme", "acme"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+		if rec.Code != http.StatusOK || rec.Body.String() != tt.want {
+			t.Errorf("GET %s = %d %q, want 200 %q", tt.path, rec.Code, rec.Body.String(), tt.want)
+		}
+	}
+}
+
+func TestScopedArgs(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	if got := ScopedArgs(r); got != nil {
+		t.Errorf("ScopedArgs without scope = %v, want nil", got)
+	}
+	cfg := &updateArgConfig{greeting: "hi"}
+	ctx := WithScopedArg(context.Background(), &tenantDB{name: "a"}, cfg)
+	ctx = WithScopedArg(ctx, &tenantDB{name: "b"})
+	got := ScopedArgs(r.WithContext(ctx))
+	if len(got) != 2 || got[0] != cfg || got[1].(*tenantDB).name != "b" {
+		t.Errorf("ScopedArgs = %v, want [cfg, tenant b]", got)
+	}
+}
@@ -0,0 +1,114 @@
+package structpages
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type serveHomePage struct{}
+
+func (serveHomePage) Page() component { return testComponent{"home"} }
+
+type serveAboutPage struct{}
+
+func (serveAboutPage) Page() component { return testComponent{"about"} }
+
+type servePages struct {
+	Home  serveHomePage  `route:"/{$} Home"`
+	About serveAboutPage `route:"/about About"`
+}
+
+func TestStructPages_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name  string
+		mount func() (*StructPages, error)
+	}{
+		{
+			name: "mux passed to Mount",
+			mount: func() (*StructPages, error) {
+				return Mount(http.NewServeMux(), &servePages{}, "/", "App")
+			},
+		},
+		{
+			name: "WithMux",
+			mount: func() (*StructPages, error) {
+				return Mount(nil, &servePages{}, "/", "App", WithMux(http.NewServeMux()))
+			},
+		},
+		{
+			name: "Validate then Mount",
+			mount: func() (*StructPages, error) {
+				sp, err := Validate(&servePages{}, "/", "App")
+				if err != nil {
+					return nil, err
+				}
+				return sp, sp.Mount(http.NewServeMux())
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sp, err := tt.mount()
+			if err != nil {
+				t.Fatalf("Mount: %v", err)
+			}
+			for path, want := range map[string]string{"/": "home", "/about": "about"} {
+				rec := httptest.NewRecorder()
+				sp.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+				if rec.Code != http.StatusOK || rec.Body.String() != want {
+					t.Errorf("GET %s = %d %q, want 200 %q", path, rec.Code, rec.Body.String(), want)
+				}
+			}
+			rec := httptest.NewRecorder()
+			sp.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/missing", http.NoBody))
+			if rec.Code != http.StatusNotFound {
+				t.Errorf("GET /missing = %d, want 404", rec.Code)
+			}
+		})
+	}
+}
+
+func TestStructPages_ServeHTTP_WrappedInMiddleware(t *testing.T) {
+	sp, err := Mount(http.NewServeMux(), &servePages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	var logged []string
+	logging := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			logged = append(logged, r.Method+" "+r.URL.Path)
+			next.ServeHTTP(w, r)
+		})
+	}
+	srv := httptest.NewServer(logging(sp))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/about")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want 200", resp.StatusCode)
+	}
+	if len(logged) != 1 || logged[0] != "GET /about" {
+		t.Errorf("logged = %v, want [GET /about]", logged)
+	}
+}
+
+type handleOnlyMux struct{}
+
+func (handleOnlyMux) Handle(pattern string, handler http.Handler) {}
+
+func TestStructPages_ServeHTTP_MuxWithoutServeHTTP(t *testing.T) {
+	sp, err := Mount(handleOnlyMux{}, &servePages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	sp.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", rec.Code)
+	}
+}
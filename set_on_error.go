@@ -0,0 +1,92 @@
+package structpages
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+)
+
+// errorHandler is the type of the WithErrorHandler callback.
+type errorHandler = func(http.ResponseWriter, *http.Request, error)
+
+// errorOverride holds the handler installed with SetOnError or
+// CaptureErrors, and the errors CaptureErrors collected.
+type errorOverride struct {
+	handler  atomic.Pointer[errorHandler]
+	mu       sync.Mutex
+	captured []error
+}
+
+// overridable returns onError deferring to the handler installed with
+// SetOnError, if any.
+func (o *errorOverride) overridable(onError errorHandler) errorHandler {
+	return func(w http.ResponseWriter, r *http.Request, err error) {
+		if h := o.handler.Load(); h != nil {
+			(*h)(w, r, err)
+			return
+		}
+		onError(w, r, err)
+	}
+}
+
+// SetOnError replaces the error handler of sp, set at mount with
+// WithErrorHandler, for the requests that start after it returns. It is
+// safe to call while sp serves requests; SetOnError(nil) reinstates the
+// mount-time handler. It is meant for tests — see CaptureErrors.
+func (sp *StructPages) SetOnError(fn func(http.ResponseWriter, *http.Request, error)) {
+	sp.checkWritable()
+	if fn == nil {
+		sp.errorOverride.handler.Store(nil)
+		return
+	}
+	sp.errorOverride.handler.Store(&fn)
+}
+
+// CaptureErrors installs an error handler that records every error for
+// LastError and responds with the error's HTTPError status, or 500, and
+// message. It returns a function reinstating the previous handler, which
+// also runs when t's test ends:
+//
+//	t.Run("missing todo", func(t *testing.T) {
+//	    sp.CaptureErrors(t)
+//	    get(t, mux, "/todos/404")
+//	    if !errors.Is(sp.LastError(), ErrNoTodo) { ... }
+//	})
+func (sp *StructPages) CaptureErrors(t TB) (restore func()) {
+	t.Helper()
+	sp.checkWritable()
+	o := &sp.errorOverride
+	o.mu.Lock()
+	o.captured = nil
+	o.mu.Unlock()
+	prev := o.handler.Load()
+	capture := errorHandler(func(w http.ResponseWriter, _ *http.Request, err error) {
+		o.mu.Lock()
+		o.captured = append(o.captured, err)
+		o.mu.Unlock()
+		code := http.StatusInternalServerError
+		var httpErr *HTTPError
+		if errors.As(err, &httpErr) {
+			code = httpErr.Code
+		}
+		http.Error(w, err.Error(), code)
+	})
+	o.handler.Store(&capture)
+	var once sync.Once
+	restore = func() { once.Do(func() { o.handler.Store(prev) }) }
+	t.Cleanup(restore)
+	return restore
+}
+
+// LastError returns the most recent error recorded since CaptureErrors was
+// last called, or nil.
+func (sp *StructPages) LastError() error {
+	o := &sp.errorOverride
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.captured) == 0 {
+		return nil
+	}
+	return o.captured[len(o.captured)-1]
+}
@@ -0,0 +1,124 @@
+package structpages
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+var errOnErrorPage = errors.New("on error page failed")
+
+type onErrorPage struct{}
+
+func (onErrorPage) Props() (string, error) { return "", errOnErrorPage }
+
+func (onErrorPage) Page(s string) component { return testComponent{s} }
+
+type onErrorPages struct {
+	Fail onErrorPage `route:"/fail Fail"`
+}
+
+func mountOnErrorPages(t *testing.T) (*StructPages, *http.ServeMux) {
+	t.Helper()
+	mux := http.NewServeMux()
+	sp, err := Mount(mux, onErrorPages{}, "/", "App",
+		WithErrorHandler(func(w http.ResponseWriter, _ *http.Request, _ error) {
+			http.Error(w, "mount handler", http.StatusTeapot)
+		}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	return sp, mux
+}
+
+func getFail(mux *http.ServeMux) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/fail", http.NoBody))
+	return rec
+}
+
+func TestSetOnError(t *testing.T) {
+	sp, mux := mountOnErrorPages(t)
+	var got error
+	sp.SetOnError(func(w http.ResponseWriter, _ *http.Request, err error) {
+		got = err
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	if rec := getFail(mux); rec.Code != http.StatusBadGateway {
+		t.Errorf("status = %d, want 502 from the replaced handler", rec.Code)
+	}
+	if !errors.Is(got, errOnErrorPage) {
+		t.Errorf("err = %v, want %v", got, errOnErrorPage)
+	}
+	sp.SetOnError(nil)
+	if rec := getFail(mux); rec.Code != http.StatusTeapot {
+		t.Errorf("status = %d, want 418 from the mount-time handler", rec.Code)
+	}
+}
+
+func TestSetOnError_Concurrent(t *testing.T) {
+	sp, mux := mountOnErrorPages(t)
+	var calls atomic.Int64
+	counting := func(w http.ResponseWriter, _ *http.Request, _ error) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 50 {
+				if i == 0 && j%2 == 0 {
+					sp.SetOnError(counting)
+				}
+				if rec := getFail(mux); rec.Code != http.StatusBadGateway && rec.Code != http.StatusTeapot {
+					t.Errorf("status = %d, want 502 or 418", rec.Code)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	sp.SetOnError(counting)
+	before := calls.Load()
+	getFail(mux)
+	if calls.Load() != before+1 {
+		t.Error("handler set last is not the one called")
+	}
+}
+
+func TestCaptureErrors(t *testing.T) {
+	sp, mux := mountOnErrorPages(t)
+	for i := range 2 {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			sp.CaptureErrors(t)
+			if err := sp.LastError(); err != nil {
+				t.Fatalf("LastError before any request = %v, want nil", err)
+			}
+			rec := getFail(mux)
+			if rec.Code != http.StatusInternalServerError {
+				t.Errorf("status = %d, want 500", rec.Code)
+			}
+			if !errors.Is(sp.LastError(), errOnErrorPage) {
+				t.Errorf("LastError = %v, want %v", sp.LastError(), errOnErrorPage)
+			}
+		})
+	}
+	if rec := getFail(mux); rec.Code != http.StatusTeapot {
+		t.Errorf("status after the sub-tests = %d, want 418: cleanup restores the handler", rec.Code)
+	}
+}
+
+func TestCaptureErrors_Restore(t *testing.T) {
+	sp, mux := mountOnErrorPages(t)
+	restore := sp.CaptureErrors(t)
+	getFail(mux)
+	restore()
+	if rec := getFail(mux); rec.Code != http.StatusTeapot {
+		t.Errorf("status after restore = %d, want 418", rec.Code)
+	}
+}
@@ -0,0 +1,53 @@
+package structpages
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// WithSingleton makes page, a non-nil pointer to a page struct, the value
+// every field of its type in the page tree is mounted with, instead of a
+// fresh value built from the page literal. Its methods are called on page
+// itself, so state set before Mount — a cache, a client, counters — is
+// seen by, and shared between, all requests:
+//
+//	search := &searchPage{index: buildIndex()}
+//	structpages.Mount(mux, pages{}, "/", "App", structpages.WithSingleton(search))
+//
+// Requests are served concurrently, so any state a singleton's methods
+// change must be guarded with a mutex or atomics; prefer DI args for
+// shared services and keep page structs stateless where possible. A type
+// mounted under several fields uses the same instance for all of them.
+func WithSingleton(page any) func(*StructPages) {
+	return func(r *StructPages) {
+		r.singletons = append(r.singletons, page)
+	}
+}
+
+// addSingletons records the WithSingleton pages by struct type.
+func (p *parseContext) addSingletons(pages []any) error {
+	for _, page := range pages {
+		v := reflect.ValueOf(page)
+		if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+			return fmt.Errorf("WithSingleton: page must be a non-nil pointer to a struct, got %T", page)
+		}
+		if _, ok := p.singletons[v.Type().Elem()]; ok {
+			return fmt.Errorf("WithSingleton: %s registered twice", v.Type().Elem())
+		}
+		if p.singletons == nil {
+			p.singletons = make(map[reflect.Type]reflect.Value)
+		}
+		p.singletons[v.Type().Elem()] = v
+	}
+	return nil
+}
+
+// singletonFor returns the WithSingleton page for a field of type typ, if
+// any.
+func (p *parseContext) singletonFor(typ reflect.Type) (reflect.Value, bool) {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	v, ok := p.singletons[typ]
+	return v, ok
+}
@@ -0,0 +1,76 @@
+package structpages
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type singletonGreeter struct{ greeting string }
+
+// counterPage keeps a visit count across requests, which is only possible
+// because WithSingleton mounts the same instance for every request.
+type counterPage struct {
+	mu     sync.Mutex
+	visits int
+	label  string
+}
+
+func (p *counterPage) Props(g *singletonGreeter) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.visits++
+	return fmt.Sprintf("%s %s #%d", g.greeting, p.label, p.visits), nil
+}
+
+func (p *counterPage) Page(s string) component { return testComponent{s} }
+
+type singletonPages struct {
+	Counter counterPage `route:"/counter Counter"`
+}
+
+func TestWithSingleton(t *testing.T) {
+	counter := &counterPage{label: "visitor"}
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &singletonPages{}, "/", "App",
+		WithSingleton(counter), WithArgs(&singletonGreeter{greeting: "hello"}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	for i := 1; i <= 3; i++ {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/counter", http.NoBody))
+		if want := fmt.Sprintf("hello visitor #%d", i); rec.Body.String() != want {
+			t.Errorf("request %d: body = %q, want %q", i, rec.Body.String(), want)
+		}
+	}
+	if counter.visits != 3 {
+		t.Errorf("counter.visits = %d, want 3: the singleton instance itself is used", counter.visits)
+	}
+}
+
+func TestWithSingleton_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{"not a pointer", []Option{WithSingleton(singletonGreeter{})}, "non-nil pointer to a struct"},
+		{"nil pointer", []Option{WithSingleton((*counterPage)(nil))}, "non-nil pointer to a struct"},
+		{
+			"registered twice", []Option{WithSingleton(&counterPage{}), WithSingleton(&counterPage{})},
+			"registered twice",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Mount(http.NewServeMux(), &singletonPages{}, "/", "App", tt.opts...)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Mount error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package structpages
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/jackielii/ctxkey"
+)
+
+// renderedComponentCtx holds where render records the name of the
+// component it renders, for SlogMiddleware's component_name.
+var renderedComponentCtx = ctxkey.New[*string]("structpages.renderedComponent", nil)
+
+// slogMaxBody is how much of a request body SlogWithBody logs.
+const slogMaxBody = 4 << 10
+
+// SlogOption configures SlogMiddleware.
+type SlogOption func(*slogConfig)
+
+type slogConfig struct {
+	requestID bool
+	body      bool
+	headers   []string
+	pageName  bool
+}
+
+// SlogWithRequestID adds a request_id attribute: the X-Request-ID request
+// header, or a generated id that is also set as the X-Request-ID response
+// header. Off by default.
+func SlogWithRequestID(enabled bool) SlogOption {
+	return func(c *slogConfig) { c.requestID = enabled }
+}
+
+// SlogWithBody adds a body attribute with up to the first 4 KiB of the
+// request body. The handler still reads the whole body. Off by default.
+func SlogWithBody(enabled bool) SlogOption {
+	return func(c *slogConfig) { c.body = enabled }
+}
+
+// SlogWithHeaders adds a headers group with the values of the named
+// request headers that are present.
+func SlogWithHeaders(names []string) SlogOption {
+	return func(c *slogConfig) { c.headers = names }
+}
+
+// SlogWithPageName controls the page_name attribute. On by default.
+func SlogWithPageName(enabled bool) SlogOption {
+	return func(c *slogConfig) { c.pageName = enabled }
+}
+
+// SlogMiddleware returns a middleware logging one structured record per
+// request to logger, or slog.Default() if it is nil:
+//
+//	structpages.WithMiddlewares(structpages.SlogMiddleware(logger,
+//	    structpages.SlogWithRequestID(true),
+//	    structpages.SlogWithHeaders([]string{"User-Agent"})))
+//
+// The record has the attributes method, path, status, duration_ms,
+// page_name and component_name, the component structpages rendered, if
+// any. It is logged at Info level, or at Error level when the response
+// status is 500 or above, as it is for an error reaching the error handler
+// without an HTTPError code.
+func SlogMiddleware(logger *slog.Logger, opts ...SlogOption) MiddlewareFunc {
+	cfg := slogConfig{pageName: true}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return func(next http.Handler, pn *PageNode) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			l := logger
+			if l == nil {
+				l = slog.Default()
+			}
+			attrs := []slog.Attr{slog.String("method", r.Method), slog.String("path", r.URL.Path)}
+			if cfg.requestID {
+				id := r.Header.Get("X-Request-ID")
+				if id == "" {
+					id = rand.Text()
+					w.Header().Set("X-Request-ID", id)
+				}
+				attrs = append(attrs, slog.String("request_id", id))
+			}
+			if cfg.body && r.Body != nil && r.Body != http.NoBody {
+				head, err := io.ReadAll(io.LimitReader(r.Body, slogMaxBody))
+				r.Body = struct {
+					io.Reader
+					io.Closer
+				}{io.MultiReader(bytes.NewReader(head), r.Body), r.Body}
+				if err == nil {
+					attrs = append(attrs, slog.String("body", string(head)))
+				}
+			}
+			if len(cfg.headers) > 0 {
+				var headers []any
+				for _, name := range cfg.headers {
+					if v := r.Header.Values(name); len(v) > 0 {
+						headers = append(headers, slog.Any(name, v))
+					}
+				}
+				attrs = append(attrs, slog.Group("headers", headers...))
+			}
+
+			var component string
+			r = r.WithContext(renderedComponentCtx.WithValue(r.Context(), &component))
+			rec := &statusRecorder{ResponseWriter: w}
+			start := time.Now()
+			next.ServeHTTP(rec, r)
+			elapsed := time.Since(start)
+
+			status := rec.Status()
+			attrs = append(attrs,
+				slog.Int("status", status),
+				slog.Float64("duration_ms", float64(elapsed.Microseconds())/1000))
+			if cfg.pageName {
+				attrs = append(attrs, slog.String("page_name", pn.Name))
+			}
+			if component != "" {
+				attrs = append(attrs, slog.String("component_name", component))
+			}
+			level := slog.LevelInfo
+			if status >= http.StatusInternalServerError {
+				level = slog.LevelError
+			}
+			l.LogAttrs(r.Context(), level, "request", attrs...)
+		})
+	}
+}
+
+// statusRecorder records the status a handler responds with.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusRecorder) WriteHeader(statusCode int) {
+	if w.status == 0 {
+		w.status = statusCode
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *statusRecorder) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Status returns the response status, 200 if none was written.
+func (w *statusRecorder) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+// Flush flushes the underlying writer if it supports flushing.
+func (w *statusRecorder) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		if w.status == 0 {
+			w.status = http.StatusOK
+		}
+		f.Flush()
+	}
+}
+
+// Unwrap returns the underlying writer for http.ResponseController.
+func (w *statusRecorder) Unwrap() http.ResponseWriter { return w.ResponseWriter }
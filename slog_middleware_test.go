@@ -0,0 +1,109 @@
+package structpages
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type slogItemPage struct{}
+
+func (slogItemPage) Page() component { return testComponent{"item"} }
+
+func (slogItemPage) Details() component { return testComponent{"details"} }
+
+type slogFailPage struct{}
+
+func (slogFailPage) Props() (string, error) { return "", errors.New("database down") }
+
+func (slogFailPage) Page(string) component { return testComponent{"fail"} }
+
+type slogPages struct {
+	Item slogItemPage `route:"POST /items/{id} Item"`
+	Fail slogFailPage `route:"/fail Fail"`
+}
+
+func slogRecord(t *testing.T, out *strings.Builder) map[string]any {
+	t.Helper()
+	var rec map[string]any
+	if err := json.Unmarshal([]byte(out.String()), &rec); err != nil {
+		t.Fatalf("log output %q: %v", out.String(), err)
+	}
+	return rec
+}
+
+func TestSlogMiddleware(t *testing.T) {
+	var out strings.Builder
+	logger := slog.New(slog.NewJSONHandler(&out, nil))
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &slogPages{}, "/", "App", WithMiddlewares(SlogMiddleware(logger,
+		SlogWithRequestID(true), SlogWithBody(true), SlogWithHeaders([]string{"User-Agent", "X-Missing"}))))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/items/7", strings.NewReader("name=widget"))
+	req.Header.Set("X-Request-ID", "req-1")
+	req.Header.Set("User-Agent", "test-agent")
+	req.Header.Set("HX-Request", "true")
+	req.Header.Set("HX-Target", "item-details")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || rec.Body.String() != "details" {
+		t.Fatalf("response = %d %q, want 200 details", rec.Code, rec.Body.String())
+	}
+	got := slogRecord(t, &out)
+	want := map[string]any{
+		"level":          "INFO",
+		"msg":            "request",
+		"method":         "POST",
+		"path":           "/items/7",
+		"status":         float64(200),
+		"page_name":      "Item",
+		"component_name": "Details",
+		"request_id":     "req-1",
+		"body":           "name=widget",
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s = %v, want %v", k, got[k], v)
+		}
+	}
+	if _, ok := got["duration_ms"].(float64); !ok {
+		t.Errorf("duration_ms = %v, want a number", got["duration_ms"])
+	}
+	headers, _ := got["headers"].(map[string]any)
+	if ua, _ := headers["User-Agent"].([]any); len(ua) != 1 || ua[0] != "test-agent" || len(headers) != 1 {
+		t.Errorf("headers = %v, want only User-Agent [test-agent]", got["headers"])
+	}
+}
+
+func TestSlogMiddleware_Error(t *testing.T) {
+	var out strings.Builder
+	logger := slog.New(slog.NewJSONHandler(&out, nil))
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &slogPages{}, "/", "App",
+		WithMiddlewares(SlogMiddleware(logger, SlogWithPageName(false), SlogWithRequestID(true))))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/fail", http.NoBody))
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", rec.Code)
+	}
+	got := slogRecord(t, &out)
+	if got["level"] != "ERROR" || got["status"] != float64(500) || got["path"] != "/fail" {
+		t.Errorf("record = %v, want an ERROR with status 500 for /fail", got)
+	}
+	if _, ok := got["page_name"]; ok {
+		t.Errorf("page_name = %v with SlogWithPageName(false)", got["page_name"])
+	}
+	if id, _ := got["request_id"].(string); id == "" || rec.Header().Get("X-Request-ID") != id {
+		t.Errorf("request_id = %q, X-Request-ID = %q; want the same generated id", id, rec.Header().Get("X-Request-ID"))
+	}
+}
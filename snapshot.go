@@ -0,0 +1,103 @@
+package structpages
+
+import (
+	"maps"
+	"slices"
+)
+
+// readOnlyMsg is the panic value of the methods that change a StructPages
+// when called on a Snapshot.
+const readOnlyMsg = "structpages: snapshot is read-only"
+
+// Snapshot returns a read-only copy of sp taken at a single point in time,
+// for code that inspects the page tree while other goroutines call
+// Revert, Restore, AddArg or UpdateArg. URLFor, ID, IDTarget, Export,
+// Match, Stats and ListComponents on the snapshot see the page tree,
+// reverted pages and DI args as they were when Snapshot was called.
+//
+// The PageNode tree is copied; the page values the nodes point to and the
+// DI args themselves are shared. Mount, MountPage, Revert, RevertAll,
+// Restore, AddArg, UpdateArg, RegisterGlobal and RegisterPlugin panic on a
+// snapshot.
+func (sp *StructPages) Snapshot() *StructPages {
+	sp = sp.current()
+	sp.pc.argsMu.RLock()
+	defer sp.pc.argsMu.RUnlock()
+	sp.pc.revertedMu.RLock()
+	defer sp.pc.revertedMu.RUnlock()
+
+	snap := configure(sp.options)
+	snap.pc = sp.pc.clone()
+	snap.args = slices.Clone(sp.args)
+	snap.components = sp.components
+	snap.contextFuncs.add(sp.contextFuncs.list()...)
+	snap.mux = sp.mux
+	snap.mountedAt = sp.mountedAt
+	for _, ps := range sp.plugins {
+		snap.plugins = append(snap.plugins, ps.Snapshot())
+	}
+	snap.readOnly = true
+	return snap
+}
+
+// checkWritable panics when sp is a Snapshot.
+func (sp *StructPages) checkWritable() {
+	if sp.readOnly {
+		panic(readOnlyMsg)
+	}
+}
+
+// clone deep-copies p's page tree and the state that changes after Mount.
+// The caller holds p.argsMu and p.revertedMu for reading.
+func (p *parseContext) clone() *parseContext {
+	nodes := make(map[*PageNode]*PageNode)
+	c := &parseContext{
+		args:                maps.Clone(p.args),
+		aliases:             maps.Clone(p.aliases),
+		urlPrefix:           p.urlPrefix,
+		routePrefix:         p.routePrefix,
+		maxIDLen:            p.maxIDLen,
+		multipartMaxMemory:  p.multipartMaxMemory,
+		cookieDecoder:       p.cookieDecoder,
+		htmxHistoryDisabled: p.htmxHistoryDisabled,
+		componentChain:      slices.Clone(p.componentChain),
+		maxDepth:            p.maxDepth,
+		htmx:                p.htmx,
+	}
+	c.setRoot(cloneNode(p.root(), nil, nodes))
+	p.segmentCacheMu.RLock()
+	c.segmentCache = maps.Clone(p.segmentCache)
+	p.segmentCacheMu.RUnlock()
+	p.routesMu.RLock()
+	c.routes = maps.Clone(p.routes)
+	p.routesMu.RUnlock()
+	if p.pageNames != nil {
+		c.pageNames = make(map[string]*PageNode, len(p.pageNames))
+		for name, pn := range p.pageNames {
+			c.pageNames[name] = nodes[pn]
+		}
+	}
+	c.reverted = maps.Clone(p.reverted) // keyed by node keys, which copies keep
+	return c
+}
+
+// cloneNode copies pn and its descendants, recording each copy in nodes.
+func cloneNode(pn, parent *PageNode, nodes map[*PageNode]*PageNode) *PageNode {
+	c := *pn
+	c.Parent = parent
+	c.origin = pn.key()
+	c.routeSegments = slices.Clone(pn.routeSegments)
+	c.Props = maps.Clone(pn.Props)
+	c.Components = maps.Clone(pn.Components)
+	c.Meta = maps.Clone(pn.Meta)
+	c.Aliases = slices.Clone(pn.Aliases)
+	c.idPath = slices.Clone(pn.idPath)
+	c.componentChain = slices.Clone(pn.componentChain)
+	c.environments = slices.Clone(pn.environments)
+	c.Children = make([]*PageNode, len(pn.Children))
+	nodes[pn] = &c
+	for i, child := range pn.Children {
+		c.Children[i] = cloneNode(child, &c, nodes)
+	}
+	return &c
+}
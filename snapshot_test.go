@@ -0,0 +1,123 @@
+package structpages
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+type snapshotDB struct{ name string }
+
+type snapshotCache struct{}
+
+type snapshotHome struct{}
+
+func (snapshotHome) Page() component { return testComponent{"home"} }
+
+type snapshotItem struct{}
+
+func (snapshotItem) Page() component { return testComponent{"item"} }
+
+type snapshotPages struct {
+	Home snapshotHome `route:"/{$} Home"`
+	Item snapshotItem `route:"/items/{id} Item"`
+}
+
+func TestSnapshot(t *testing.T) {
+	sp, err := Mount(http.NewServeMux(), &snapshotPages{}, "/", "App", WithArgs(&snapshotDB{name: "v1"}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	want, err := sp.URLFor(snapshotItem{}, map[string]any{"id": 1})
+	if err != nil {
+		t.Fatalf("URLFor: %v", err)
+	}
+	snap := sp.Snapshot()
+
+	if err := sp.Revert(snapshotItem{}); err != nil {
+		t.Fatalf("Revert: %v", err)
+	}
+	if err := sp.UpdateArg(&snapshotDB{name: "v2"}); err != nil {
+		t.Fatalf("UpdateArg: %v", err)
+	}
+	if _, err := sp.URLFor(snapshotItem{}, map[string]any{"id": 1}); !errors.Is(err, ErrPageNotFound) {
+		t.Fatalf("original URLFor after Revert = %v, want ErrPageNotFound", err)
+	}
+
+	got, err := snap.URLFor(snapshotItem{}, map[string]any{"id": 1})
+	if err != nil || got != want {
+		t.Errorf("snapshot URLFor = %q, %v; want %q", got, err, want)
+	}
+	if pn, _, err := snap.Match(http.MethodGet, "/items/1"); err != nil || pn.Name != "Item" {
+		t.Errorf("snapshot Match = %v, %v; want Item", pn, err)
+	}
+	if n := len(snap.Export()); n != len(sp.Export()) {
+		t.Errorf("snapshot Export has %d routes, original %d", n, len(sp.Export()))
+	}
+	v, err := snap.pc.resolveRegistered(reflect.TypeFor[*snapshotDB]())
+	if err != nil || v.Interface().(*snapshotDB).name != "v1" {
+		t.Errorf("snapshot arg = %v, %v; want v1", v, err)
+	}
+	if snap.pc.root() == sp.pc.root() || snap.pc.root().Children[1].Parent != snap.pc.root() {
+		t.Error("snapshot shares or mislinks the original page tree")
+	}
+}
+
+func TestSnapshot_ReadOnly(t *testing.T) {
+	sp, err := Mount(http.NewServeMux(), &snapshotPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	snap := sp.Snapshot()
+	tests := map[string]func(){
+		"Mount":     func() { _ = snap.Mount(http.NewServeMux()) },
+		"MountPage": func() { _ = snap.MountPage(http.NewServeMux(), &snapshotPages{}, "/", "App") },
+		"Revert":    func() { _ = snap.Revert(snapshotItem{}) },
+		"RevertAll": func() { _ = snap.RevertAll() },
+		"Restore":   func() { _ = snap.Restore(snapshotItem{}) },
+		"AddArg":    func() { _ = snap.AddArg(&snapshotCache{}) },
+		"UpdateArg": func() { _ = snap.UpdateArg(&snapshotDB{}) },
+	}
+	for name, fn := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != readOnlyMsg {
+					t.Errorf("recovered %v, want %q", r, readOnlyMsg)
+				}
+			}()
+			fn()
+		})
+	}
+}
+
+// TestSnapshot_Concurrent is meant for go test -race: snapshots are taken
+// while other goroutines revert pages and add args.
+func TestSnapshot_Concurrent(t *testing.T) {
+	sp, err := Mount(http.NewServeMux(), &snapshotPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			_ = sp.Revert(snapshotItem{})
+			_ = sp.Restore(snapshotItem{})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		_ = sp.AddArg(&snapshotCache{})
+	}()
+	for range 100 {
+		snap := sp.Snapshot()
+		if _, err := snap.URLFor(snapshotHome{}); err != nil {
+			t.Errorf("snapshot URLFor: %v", err)
+		}
+		_, _ = snap.URLFor(snapshotItem{}, map[string]any{"id": 1})
+	}
+	wg.Wait()
+}
@@ -0,0 +1,60 @@
+package structpages
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// Stats summarizes a mounted page tree, as returned by StructPages.Stats.
+type Stats struct {
+	// RouteCount is the number of routes Export lists.
+	RouteCount int `json:"routeCount"`
+	// PageCount is the number of nodes in the page tree, including page
+	// groups that register no route of their own.
+	PageCount int `json:"pageCount"`
+	// DIArgCount is the number of values registered for dependency
+	// injection (WithArgs, AddArg).
+	DIArgCount int `json:"diArgCount"`
+	// ComponentCount is the number of component methods across all pages.
+	ComponentCount int `json:"componentCount"`
+	// MountedAt is when Mount finished registering the routes; zero for a
+	// StructPages that was never mounted.
+	MountedAt time.Time `json:"mountedAt"`
+	// GlobalMiddlewareCount is the number of WithMiddlewares middlewares.
+	GlobalMiddlewareCount int `json:"globalMiddlewareCount"`
+}
+
+// Stats returns metadata about the page tree for health checks and
+// monitoring. It is safe to call concurrently with requests.
+func (sp *StructPages) Stats() Stats {
+	sp = sp.current()
+	s := Stats{
+		RouteCount:            len(sp.Export()),
+		MountedAt:             sp.mountedAt,
+		GlobalMiddlewareCount: len(sp.middlewares),
+	}
+	for pn := range sp.pc.root().All() {
+		s.PageCount++
+		s.ComponentCount += len(pn.Components)
+	}
+	sp.pc.argsMu.RLock()
+	s.DIArgCount = len(sp.pc.args)
+	sp.pc.argsMu.RUnlock()
+	return s
+}
+
+// ServeStatsHandler returns a handler serving Stats as JSON:
+//
+//	mux.Handle("GET /healthz/routes", sp.ServeStatsHandler())
+func (sp *StructPages) ServeStatsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := json.Marshal(sp.Stats())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(b)
+	})
+}
@@ -0,0 +1,104 @@
+package structpages
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+type statsIndexPage struct{}
+
+func (statsIndexPage) Page() component    { return testComponent{"index"} }
+func (statsIndexPage) Content() component { return testComponent{"content"} }
+
+type statsItemPage struct{}
+
+func (statsItemPage) Page() component { return testComponent{"item"} }
+
+type statsSection struct {
+	statsItemPage `route:"/{id} Item"`
+}
+
+type statsPages struct {
+	statsIndexPage `route:"/{$} Index"`
+	statsSection   `route:"/items Items"`
+}
+
+type statsDB struct{}
+
+func TestStats(t *testing.T) {
+	before := time.Now()
+	sp, err := Mount(http.NewServeMux(), &statsPages{}, "/", "App",
+		WithArgs(&statsDB{}, "config"),
+		WithMiddlewares(func(h http.Handler, _ *PageNode) http.Handler { return h }))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	got := sp.Stats()
+	if got.MountedAt.Before(before) || got.MountedAt.After(time.Now()) {
+		t.Errorf("MountedAt = %v, want the time of Mount", got.MountedAt)
+	}
+	got.MountedAt = time.Time{}
+	want := Stats{
+		RouteCount:            2, // the items group registers no route of its own
+		PageCount:             4,
+		DIArgCount:            2,
+		ComponentCount:        3,
+		GlobalMiddlewareCount: 1,
+	}
+	if got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestStats_NotMounted(t *testing.T) {
+	sp, err := Parse(&statsPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if got := sp.Stats(); !got.MountedAt.IsZero() || got.PageCount != 4 {
+		t.Errorf("Stats() = %+v, want zero MountedAt and 4 pages", got)
+	}
+}
+
+func TestServeStatsHandler(t *testing.T) {
+	sp, err := Mount(http.NewServeMux(), &statsPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	sp.ServeStatsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stats", http.NoBody))
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+	var got Stats
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", rec.Body.String(), err)
+	}
+	if got.RouteCount != 2 || !got.MountedAt.Equal(sp.Stats().MountedAt) {
+		t.Errorf("decoded %+v, want %+v", got, sp.Stats())
+	}
+}
+
+func TestStats_Concurrent(t *testing.T) {
+	sp, err := Mount(http.NewServeMux(), &statsPages{}, "/", "App", WithArgs(&statsDB{}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if i%2 == 0 {
+				_ = sp.Stats()
+				return
+			}
+			_ = sp.UpdateArg(&statsDB{})
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,51 @@
+package structpages
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// StatusComponent renders Component with the response status Status, for
+// a component method, Props or RenderComponent that answers with something
+// other than 200:
+//
+//	func (p createPage) Props(r *http.Request) (component, error) {
+//	    item, err := p.store.Create(r.Context(), r.FormValue("name"))
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    return structpages.Created(itemRow(item)), nil
+//	}
+//
+// The status is sent when the rendered body is written, after the page's
+// HTMX headers are set; an error while rendering still gets the error
+// handler's status. A nil Component renders no body.
+type StatusComponent struct {
+	Status    int
+	Component component
+}
+
+// Render renders s.Component, if any. The status is applied by structpages
+// when it writes the response, as an io.Writer has no status to set.
+func (s StatusComponent) Render(ctx context.Context, w io.Writer) error {
+	if s.Component == nil {
+		return nil
+	}
+	return s.Component.Render(ctx, w)
+}
+
+// Created renders comp with status 201 Created.
+func Created(comp component) StatusComponent {
+	return StatusComponent{Status: http.StatusCreated, Component: comp}
+}
+
+// Accepted renders comp with status 202 Accepted.
+func Accepted(comp component) StatusComponent {
+	return StatusComponent{Status: http.StatusAccepted, Component: comp}
+}
+
+// NoContent answers with status 204 No Content and no body.
+func NoContent() StatusComponent {
+	return StatusComponent{Status: http.StatusNoContent}
+}
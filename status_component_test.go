@@ -0,0 +1,88 @@
+package structpages
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type statusCreatePage struct{}
+
+func (statusCreatePage) Props(r *http.Request) (component, error) {
+	switch r.URL.Query().Get("as") {
+	case "accepted":
+		return Accepted(testComponent{"queued"}), nil
+	case "none":
+		return NoContent(), nil
+	}
+	return Created(testComponent{"created"}), nil
+}
+
+func (statusCreatePage) Page() component { return testComponent{"page"} }
+
+type statusFormPage struct{}
+
+func (statusFormPage) Page() component { return testComponent{"form"} }
+
+func (statusFormPage) Result() component {
+	return StatusComponent{Status: http.StatusUnprocessableEntity, Component: testComponent{"invalid"}}
+}
+
+func (statusFormPage) HXTrigger(r *http.Request) ([]HXEvent, error) {
+	return []HXEvent{HXEventSimple("formInvalid")}, nil
+}
+
+type statusPages struct {
+	Create statusCreatePage `route:"POST /items Create"`
+	Form   statusFormPage   `route:"/form Form"`
+}
+
+func TestStatusComponent(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   []Option
+		req    *http.Request
+		status int
+		body   string
+	}{
+		{"created", nil, httptest.NewRequest(http.MethodPost, "/items", http.NoBody), http.StatusCreated, "created"},
+		{"accepted", nil, httptest.NewRequest(http.MethodPost, "/items?as=accepted", http.NoBody),
+			http.StatusAccepted, "queued"},
+		{"no content", nil, httptest.NewRequest(http.MethodPost, "/items?as=none", http.NoBody),
+			http.StatusNoContent, ""},
+		{"buffered by recovery", []Option{WithRecoveryComponent(func(any, *http.Request) Component {
+			return testComponent{"oops"}
+		})}, httptest.NewRequest(http.MethodPost, "/items", http.NoBody), http.StatusCreated, "created"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			if _, err := Mount(mux, &statusPages{}, "/", "App", tt.opts...); err != nil {
+				t.Fatalf("Mount: %v", err)
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, tt.req)
+			if rec.Code != tt.status || rec.Body.String() != tt.body {
+				t.Errorf("response = %d %q, want %d %q", rec.Code, rec.Body.String(), tt.status, tt.body)
+			}
+		})
+	}
+}
+
+func TestStatusComponent_HTMXPartial(t *testing.T) {
+	mux := http.NewServeMux()
+	if _, err := Mount(mux, &statusPages{}, "/", "App"); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/form", http.NoBody)
+	req.Header.Set("HX-Request", "true")
+	req.Header.Set("HX-Target", "form-result")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnprocessableEntity || rec.Body.String() != "invalid" {
+		t.Errorf("response = %d %q, want 422 %q", rec.Code, rec.Body.String(), "invalid")
+	}
+	if got := rec.Header().Get("HX-Trigger"); got != "formInvalid" {
+		t.Errorf("HX-Trigger = %q, want formInvalid", got)
+	}
+}
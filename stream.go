@@ -0,0 +1,92 @@
+package structpages
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+)
+
+// StreamComponent is a component that renders incrementally instead of
+// into the buffer structpages normally renders into, for responses too large
+// to hold in memory such as tables with thousands of rows.
+//
+// StreamRender writes to w from its own goroutine and sends on the returned
+// channel each time a chunk is ready to be flushed to the client (nil) or
+// when rendering fails (non-nil), closing the channel when done:
+//
+//	func (t rowsTable) StreamRender(ctx context.Context, w io.Writer) <-chan error {
+//	    ch := make(chan error)
+//	    go func() {
+//	        defer close(ch)
+//	        for batch := range t.batches(ctx) {
+//	            if err := renderRows(ctx, w, batch); err != nil {
+//	                ch <- err
+//	                return
+//	            }
+//	            ch <- nil // flush this batch
+//	        }
+//	    }()
+//	    return ch
+//	}
+//
+// Writes go straight to the response, so once anything has been written an
+// error can no longer become an error page: it is logged and the response is
+// cut short. An error before the first byte is passed to the error handler
+// as usual. Go's http server chunks the response since it has no
+// Content-Length.
+type StreamComponent interface {
+	StreamRender(ctx context.Context, w io.Writer) <-chan error
+}
+
+// countingWriter records whether anything reached the response. The
+// producer writes from its own goroutine while stream flushes and handles
+// errors on the handler's, so every use of w goes through mu: a
+// ResponseWriter can't take a Write and a Flush at the same time.
+type countingWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+	n  int64
+}
+
+func (cw *countingWriter) Write(b []byte) (int, error) {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+	n, err := cw.w.Write(b)
+	cw.n += int64(n)
+	return n, err
+}
+
+// locked runs fn with the writer held, passing the bytes written so far.
+func (cw *countingWriter) locked(fn func(n int64)) {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+	fn(cw.n)
+}
+
+func (sp *StructPages) stream(ctx context.Context, w http.ResponseWriter, r *http.Request, sc StreamComponent) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	rc := http.NewResponseController(w)
+	cw := &countingWriter{w: w}
+	failed := false
+	for err := range sc.StreamRender(ctx, cw) {
+		if failed {
+			continue // drain so the producer can finish
+		}
+		if err != nil {
+			failed = true
+			cw.locked(func(n int64) {
+				if n == 0 {
+					w.Header().Del("Content-Type")
+					sp.onError(w, r, err)
+				} else {
+					log.Printf("structpages: streaming %s failed after %d bytes: %v", r.URL.Path, n, err)
+				}
+			})
+			continue
+		}
+		// not every writer can flush; the data still arrives at the end
+		cw.locked(func(int64) { _ = rc.Flush() })
+	}
+}
@@ -0,0 +1,120 @@
+package structpages
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type chunkStream struct {
+	chunks int
+	failAt int // 1-based chunk index that fails; 0 never fails
+	delay  time.Duration
+}
+
+func (s chunkStream) StreamRender(ctx context.Context, w io.Writer) <-chan error {
+	ch := make(chan error)
+	go func() {
+		defer close(ch)
+		for i := 1; i <= s.chunks; i++ {
+			if i == s.failAt {
+				ch <- fmt.Errorf("chunk %d failed", i)
+				return
+			}
+			time.Sleep(s.delay)
+			_, _ = fmt.Fprintf(w, "<tr>%d</tr>", i)
+			ch <- nil
+		}
+	}()
+	return ch
+}
+
+func (chunkStream) Render(ctx context.Context, w io.Writer) error {
+	return errors.New("Render must not be called for a StreamComponent")
+}
+
+// flushRecorder records the body seen at every Flush.
+type flushRecorder struct {
+	*httptest.ResponseRecorder
+	flushes []string
+}
+
+func (f *flushRecorder) Flush() {
+	f.flushes = append(f.flushes, f.Body.String())
+	f.ResponseRecorder.Flush()
+}
+
+type streamPage struct{}
+
+func (streamPage) Page(r *http.Request) component {
+	failAt := 0
+	_, _ = fmt.Sscan(r.URL.Query().Get("fail"), &failAt)
+	return chunkStream{chunks: 5, failAt: failAt, delay: time.Millisecond}
+}
+
+func (streamPage) Props(r *http.Request) (*http.Request, error) { return r, nil }
+
+type streamPages struct {
+	streamPage `route:"/ Stream"`
+}
+
+func TestStreamComponent(t *testing.T) {
+	var gotErr error
+	mux := http.NewServeMux()
+	_, err := Mount(mux, &streamPages{}, "/", "App",
+		WithErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+			gotErr = err
+			http.Error(w, "boom", http.StatusInternalServerError)
+		}))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+
+	t.Run("chunks are flushed as they arrive", func(t *testing.T) {
+		rec := &flushRecorder{ResponseRecorder: httptest.NewRecorder()}
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+		want := []string{
+			"<tr>1</tr>",
+			"<tr>1</tr><tr>2</tr>",
+			"<tr>1</tr><tr>2</tr><tr>3</tr>",
+			"<tr>1</tr><tr>2</tr><tr>3</tr><tr>4</tr>",
+			"<tr>1</tr><tr>2</tr><tr>3</tr><tr>4</tr><tr>5</tr>",
+		}
+		if strings.Join(rec.flushes, "|") != strings.Join(want, "|") {
+			t.Errorf("flushes = %q, want %q", rec.flushes, want)
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != "text/html; charset=utf-8" {
+			t.Errorf("Content-Type = %q", ct)
+		}
+	})
+
+	t.Run("error after partial output is not sent to the error handler", func(t *testing.T) {
+		gotErr = nil
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?fail=3", http.NoBody))
+		if gotErr != nil {
+			t.Errorf("error handler called with %v", gotErr)
+		}
+		if rec.Code != http.StatusOK || rec.Body.String() != "<tr>1</tr><tr>2</tr>" {
+			t.Errorf("got %d %q, want the two chunks written before the error", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("error before any output uses the error handler", func(t *testing.T) {
+		gotErr = nil
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?fail=1", http.NoBody))
+		if gotErr == nil || !strings.Contains(gotErr.Error(), "chunk 1 failed") {
+			t.Errorf("error = %v, want chunk 1 failure", gotErr)
+		}
+		if rec.Code != http.StatusInternalServerError {
+			t.Errorf("status = %d, want 500", rec.Code)
+		}
+	})
+}
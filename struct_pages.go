@@ -7,6 +7,10 @@ import (
 	"net/http"
 	"reflect"
 	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // ErrSkipPageRender is a sentinel error that can be returned from a Props method
@@ -14,6 +18,38 @@ import (
 // implementing conditional rendering or redirects within page logic.
 var ErrSkipPageRender = errors.New("skip page render")
 
+// ComponentTimeoutError reports that rendering a component took longer than
+// the duration WithComponentTimeout allowed. It unwraps to
+// context.DeadlineExceeded.
+type ComponentTimeoutError struct {
+	Page      string // name of the page being rendered
+	Component string // component name, as passed to the timeout selector
+	Timeout   time.Duration
+}
+
+func (e *ComponentTimeoutError) Error() string {
+	return fmt.Sprintf("render %s.%s: exceeded component timeout of %s", e.Page, e.Component, e.Timeout)
+}
+
+func (e *ComponentTimeoutError) Unwrap() error { return context.DeadlineExceeded }
+
+// HTTPError is an error that carries the HTTP status code it should be
+// reported with, e.g. 400 for a malformed multipart form. The default error
+// handler responds with Code instead of 500.
+type HTTPError struct {
+	Code int
+	Err  error
+}
+
+func (e *HTTPError) Error() string {
+	if e.Err == nil {
+		return http.StatusText(e.Code)
+	}
+	return fmt.Sprintf("%d %s: %v", e.Code, http.StatusText(e.Code), e.Err)
+}
+
+func (e *HTTPError) Unwrap() error { return e.Err }
+
 // MiddlewareFunc is a function that wraps an http.Handler with additional functionality.
 // It receives both the handler to wrap and the PageNode being handled, allowing middleware
 // to access page metadata like route, title, and other properties.
@@ -29,16 +65,163 @@ type Mux interface {
 // StructPages holds the parsed page tree context for URL generation.
 // It is returned by Mount and provides URLFor and IDFor methods.
 type StructPages struct {
-	pc             *parseContext
+	pc *parseContext
+	// options are the options sp was built with, replayed by Group.
+	options        []Option
 	onError        func(http.ResponseWriter, *http.Request, error)
 	middlewares    []MiddlewareFunc
 	targetSelector TargetSelector
 	warnEmptyRoute func(*PageNode)
 	args           []any
 	urlPrefix      string
+	routePrefix    string
 	maxIDLen       int
+	maxDepth       int
+	// htmxHistoryDisabled mirrors parseContext.htmxHistoryDisabled.
+	htmxHistoryDisabled bool
+	// componentChain mirrors parseContext.componentChain.
+	componentChain []string
+	// env is the environment set by WithEnv, matched against pages'
+	// Environments methods.
+	env string
+	// preheatCtx and preheatTimeout configure WithPreheat; a nil
+	// preheatCtx disables it.
+	preheatCtx     context.Context
+	preheatTimeout time.Duration
+	// onRequest, afterRequest and afterRender are the WithOnRequest,
+	// WithAfterRequest and WithAfterRender hooks.
+	onRequest    []func(*http.Request, *PageNode)
+	afterRequest []func(*http.Request, *PageNode, time.Duration, error)
+	afterRender  []func(*PageNode, string, int, time.Duration)
+	// csrfStore and csrfCheckHTMX configure WithCSRF; a nil csrfStore
+	// disables it.
+	csrfStore     TokenStore
+	csrfCheckHTMX bool
+	// mountedAt records when Mount finished registering, for Stats.
+	mountedAt          time.Time
+	htmxConfig         HTMXConfig
+	idCollisionPolicy  IDCollisionPolicy
+	componentTimeout   func(*PageNode, string) time.Duration
+	multipartMaxMemory int64
+	cookieDecoder      CookieDecoder
+	// responseHeaders* are set by WithResponseHeaders and friends.
+	responseHeaders       map[string]string
+	responseHeadersFuncs  []func(*http.Request, *PageNode) map[string]string
+	responseHeadersPolicy ResponseHeadersPolicy
+	// mux is the router the pages were registered on, served by ServeHTTP.
+	mux Mux
+	// templateHelpersKey is set by WithTemplateHelpers.
+	templateHelpersKey any
+	// rateLimit and rateLimitCacheSize are set by WithRateLimiting and
+	// WithRateLimitCacheSize.
+	rateLimit          *rateLimitConfig
+	rateLimitCacheSize int
+	// baseURL and baseURLHeader are set by WithBaseURL and
+	// WithBaseURLFromHeader.
+	baseURL       string
+	baseURLHeader string
+	// pageValueInContext is set by WithCurrentPage.
+	pageValueInContext bool
+	propsChain         bool
+	// propsWaterfall is set by WithPropsWaterfall.
+	propsWaterfall bool
+	// readOnly marks a StructPages returned by Snapshot.
+	readOnly bool
+	// collectErrors is set by WithCollectErrors.
+	collectErrors bool
+	// defaultTitle is set by WithDefaultTitle.
+	defaultTitle func(*PageNode) string
+	// queryValidator is set by WithQueryValidator.
+	queryValidator func(*http.Request, *PageNode) error
+	// testReporter is set by WithTestMode and WithTestModeRecorder.
+	testReporter testReporter
+	// swappable is set by WithSwappableMux; live is the tree Refresh last
+	// mounted, served by ServeHTTP in place of sp's own.
+	swappable bool
+	live      atomic.Pointer[StructPages]
+	// rootMiddlewares are the middlewares register wrapped the root page
+	// in, for AddChildRoute.
+	rootMiddlewares []MiddlewareFunc
+	// singletons are the pages given to WithSingleton.
+	singletons []any
+	// plugins are the page trees mounted with RegisterPlugin.
+	plugins []*StructPages
+	// errorPage is set by RegisterErrorPage.
+	errorPage ErrorPage
+	// contextFuncs are added with WithContext.
+	contextFuncs contextFuncs
+	// errorOverride is the error handler set with SetOnError.
+	errorOverride errorOverride
+	// propsTimeout is set by WithPropsTimeout.
+	propsTimeout time.Duration
+	// lazyProps is set by WithEagerProps(false).
+	lazyProps bool
+	// defaultPage and rootRedirect are set by WithDefaultPage and
+	// WithRootRedirect; register resolves defaultPage to defaultNode and
+	// captures its handler in defaultHandler.
+	defaultPage    any
+	defaultNode    *PageNode
+	defaultHandler http.Handler
+	rootRedirect   bool
+	// recoveryComponent is set by WithRecoveryComponent.
+	recoveryComponent func(any, *http.Request) Component
+	// matcher mirrors the registered routes for Match; built on first use
+	// and dropped by resetMatcher when the tree changes.
+	matchMu  sync.Mutex
+	matcher  *http.ServeMux
+	matchErr error
+	// components is the registry RegisterGlobal adds to.
+	components     *ComponentRegistry
+	componentsOnce sync.Once
 }
 
+// ServeHTTP serves the request with the mux the pages were registered on, so
+// a StructPages can be passed straight to http.ListenAndServe or wrapped in
+// middleware. Without a mux it delegates to http.DefaultServeMux. A
+// StructPages from Parse or Validate that hasn't been mounted yet answers
+// with the error handler and ErrNotMounted.
+func (sp *StructPages) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if live := sp.live.Load(); live != nil {
+		live.ServeHTTP(w, r)
+		return
+	}
+	if sp.pc != nil && !sp.mounted() {
+		sp.onError(w, r, ErrNotMounted)
+		return
+	}
+	if sp.mux == nil {
+		http.DefaultServeMux.ServeHTTP(w, r)
+		return
+	}
+	if mux, ok := sp.mux.(*http.ServeMux); ok && sp.errorPage != nil && sp.serveUnmatched(mux, w, r) {
+		return
+	}
+	h, ok := sp.mux.(http.Handler)
+	if !ok {
+		http.Error(w, fmt.Sprintf("structpages: mux %T does not implement http.Handler", sp.mux),
+			http.StatusInternalServerError)
+		return
+	}
+	h.ServeHTTP(w, r)
+}
+
+// Handle registers handler for pattern on the mux the pages were mounted on,
+// for routes that live beside the page tree such as health checks. The
+// pattern is used as given, without the route prefix. It returns
+// ErrNotMounted before Mount.
+func (sp *StructPages) Handle(pattern string, handler http.Handler) error {
+	sp.checkWritable()
+	sp = sp.current()
+	if !sp.mounted() {
+		return ErrNotMounted
+	}
+	sp.mux.Handle(pattern, handler)
+	return nil
+}
+
+// mounted reports whether Mount has registered the page tree.
+func (sp *StructPages) mounted() bool { return !sp.mountedAt.IsZero() }
+
 // ID generates a raw HTML ID for a component method (without "#" prefix).
 // Use this for HTML id attributes.
 // It works without context by using the structpages's parseContext directly.
@@ -51,6 +234,7 @@ type StructPages struct {
 //	sp.ID(UserStatsWidget)
 //	// → "user-stats-widget" (no page prefix for standalone functions)
 func (sp *StructPages) ID(v any) (string, error) {
+	sp = sp.current()
 	return idFor(sp.pc, nil, v, true)
 }
 
@@ -66,9 +250,19 @@ func (sp *StructPages) ID(v any) (string, error) {
 //	sp.IDTarget(UserStatsWidget)
 //	// → "#user-stats-widget" (no page prefix for standalone functions)
 func (sp *StructPages) IDTarget(v any) (string, error) {
+	sp = sp.current()
 	return idFor(sp.pc, nil, v, false)
 }
 
+// IDFor is IDTarget for callers building ids from IDParams: the result has
+// the "#" prefix unless RawID is set.
+//
+//	sp.IDFor(structpages.IDParams{Method: p.UserList, Suffixes: []string{"modal", "form"}})
+//	// → "#team-management-view-user-list-modal-form"
+func (sp *StructPages) IDFor(v any) (string, error) {
+	return sp.IDTarget(v)
+}
+
 // URLFor returns the URL for a given page type. If args is provided, it'll replace
 // the path segments. Supported format is similar to http.ServeMux.
 //
@@ -97,16 +291,22 @@ func (sp *StructPages) IDTarget(v any) (string, error) {
 // func(*PageNode) bool predicate to match a specific page when
 // type-based lookup isn't enough.
 func (sp *StructPages) URLFor(page any, args ...any) (string, error) {
+	sp = sp.current()
 	// Create a context with parseContext and call the context-based URLFor
 	ctx := pcCtx.WithValue(context.Background(), sp.pc)
-	return URLFor(ctx, page, args...)
+	url, err := URLFor(ctx, page, args...)
+	if err != nil && len(sp.plugins) > 0 {
+		return sp.pluginURLFor(page, args, err)
+	}
+	return url, err
 }
 
 // Option represents a configuration option for StructPages.
 type Option func(*StructPages)
 
 // Mount parses the page tree and registers all routes onto the provided mux.
-// If mux is nil, routes are registered on http.DefaultServeMux.
+// If mux is nil, routes are registered on the mux set by WithMux, or on
+// http.DefaultServeMux when there is none.
 // Returns a StructPages that provides URLFor and IDFor methods.
 //
 // Parameters:
@@ -128,46 +328,125 @@ type Option func(*StructPages)
 //
 //	sp, err := structpages.Mount(nil, index{}, "/", "My App")
 //	http.ListenAndServe(":8080", nil)
+//
+// The returned StructPages is itself an http.Handler serving that mux:
+//
+//	sp, err := structpages.Mount(http.NewServeMux(), index{}, "/", "My App")
+//	http.ListenAndServe(":8080", loggingMiddleware(sp))
 func Mount(mux Mux, page any, route, title string, options ...Option) (*StructPages, error) {
-	if mux == nil {
-		mux = http.DefaultServeMux
+	sp, err := newStructPages(page, route, title, options)
+	if err != nil {
+		return nil, err
 	}
+	if err := sp.Mount(mux); err != nil {
+		return nil, err
+	}
+	return sp, nil
+}
 
+// newStructPages applies options and parses the page tree: everything Mount
+// does before touching a mux.
+func newStructPages(page any, route, title string, options []Option) (*StructPages, error) {
+	sp := configure(options)
+	if err := sp.parse(page, route, title); err != nil {
+		return nil, err
+	}
+	return sp, nil
+}
+
+// configure returns a StructPages with the defaults and options applied and
+// no page tree yet.
+func configure(options []Option) *StructPages {
 	sp := &StructPages{
+		options: options,
 		onError: func(w http.ResponseWriter, r *http.Request, err error) {
+			var httpErr *HTTPError
+			if errors.As(err, &httpErr) {
+				http.Error(w, http.StatusText(httpErr.Code), httpErr.Code)
+				return
+			}
 			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		},
 		targetSelector: HTMXRenderTarget,
+		maxDepth:       defaultMaxDepth,
 	}
 
 	for _, opt := range options {
 		opt(sp)
 	}
+	sp.onError = sp.errorOverride.overridable(sp.withErrorPage(sp.onError))
+	if len(sp.afterRequest) > 0 {
+		sp.onError = recordErrors(sp.onError)
+	}
+	if sp.testReporter != nil {
+		sp.onError = sp.reportErrors(sp.onError)
+	}
+	return sp
+}
 
-	// Parse page tree
-	pc, err := parsePageTree(route, page, sp.args...)
+// parse builds the page tree rooted at page and attaches it to sp.
+func (sp *StructPages) parse(page any, route, title string) error {
+	pc, err := newParseContext(sp.args...)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	pc.maxDepth = sp.maxDepth
+	pc.collectErrors = sp.collectErrors
+	if err := pc.addSingletons(sp.singletons); err != nil {
+		return err
+	}
+	if err := pc.parseRoot(route, page); err != nil {
+		return err
 	}
-	pc.root.Title = title
+	pc.root().Title = title
+	sp.applyDefaultTitles(pc.root())
 	pc.urlPrefix = sp.urlPrefix
-	if sp.maxIDLen > 0 && sp.maxIDLen != pc.maxIDLen {
-		// Re-resolve ids against the configured budget. idPath/suffix are
-		// length-independent, so only the uniqueness check must re-run.
+	pc.routePrefix = sp.routePrefix
+	pc.htmxHistoryDisabled = sp.htmxHistoryDisabled
+	pc.componentChain = sp.componentChain
+	pc.htmx = sp.htmxConfig.withDefaults()
+	pc.cookieDecoder = sp.cookieDecoder
+	if sp.multipartMaxMemory > 0 {
+		pc.multipartMaxMemory = sp.multipartMaxMemory
+	}
+	if sp.maxIDLen > 0 {
 		pc.maxIDLen = sp.maxIDLen
-		if err := pc.checkIDUniqueness(); err != nil {
-			return nil, err
-		}
+	}
+	if err := sp.checkIDCollisions(pc); err != nil {
+		return err
 	}
 	sp.pc = pc
+	return nil
+}
 
-	// Register all pages
-	middlewares := append([]MiddlewareFunc{withPcCtx(pc), extractURLParams}, sp.middlewares...)
-	if err := sp.registerPageItem(mux, pc.root, middlewares); err != nil {
-		return nil, err
+// register wires every page of the parsed tree onto mux.
+func (sp *StructPages) register(mux Mux) error {
+	var middlewares []MiddlewareFunc
+	if mw := sp.responseHeadersMiddleware(); mw != nil {
+		middlewares = append(middlewares, mw)
 	}
-
-	return sp, nil
+	middlewares = append(middlewares, withPcCtx(sp.pc, &sp.contextFuncs), extractURLParams)
+	if mw := sp.perRequestMiddleware(); mw != nil {
+		middlewares = append(middlewares, mw)
+	}
+	if sp.templateHelpersKey != nil {
+		middlewares = append(middlewares, withTemplateHelpers(sp.templateHelpersKey))
+	}
+	if sp.pageValueInContext {
+		middlewares = append(middlewares, withPageValue)
+	}
+	if sp.csrfStore != nil {
+		middlewares = append(middlewares, sp.csrfMiddleware)
+	}
+	if sp.rateLimit != nil {
+		middlewares = append(middlewares, sp.rateLimitMiddleware())
+	}
+	middlewares = append(middlewares, sp.middlewares...)
+	if err := sp.resolveDefaultPage(); err != nil {
+		return err
+	}
+	sp.rootMiddlewares = middlewares
+	return sp.registerPageItem(mux, sp.pc.root(), middlewares)
 }
 
 // WithArgs adds global dependency injection arguments that will be
@@ -202,6 +481,24 @@ func WithURLPrefix(prefix string) func(*StructPages) {
 	}
 }
 
+// WithRoutePrefix prepends prefix to every route Mount registers and to
+// every URL URLFor generates, so the same page tree can be served under
+// /v1, /api, or /staging without touching the route tags. PageNode routes
+// are left as declared. Unlike WithURLPrefix, which only rewrites URLs for
+// a prefix stripped upstream, this changes what the mux matches.
+//
+//	structpages.Mount(mux, pages{}, "/", "App", structpages.WithRoutePrefix("/v2"))
+//	// users `route:"/users"` is served at /v2/users, URLFor returns /v2/users
+func WithRoutePrefix(prefix string) func(*StructPages) {
+	return func(r *StructPages) {
+		prefix = strings.TrimRight(prefix, "/")
+		if prefix != "" && !strings.HasPrefix(prefix, "/") {
+			prefix = "/" + prefix
+		}
+		r.routePrefix = prefix
+	}
+}
+
 // WithMaxIDLength sets the character budget for generated element ids
 // (ID/IDTarget) before they degrade from the readable full-path form
 // (every ancestor field name joined, e.g. "admin-users-user-list") to
@@ -217,6 +514,19 @@ func WithMaxIDLength(n int) func(*StructPages) {
 	}
 }
 
+// defaultMaxDepth is the page nesting limit used without WithMaxDepth.
+const defaultMaxDepth = 20
+
+// WithMaxDepth limits how deeply pages may be nested. The root page is at
+// depth 0 and each level of route-tagged fields adds one; Mount fails with
+// an error naming the first field beyond the limit. WithMaxDepth(0) allows
+// only the root page. The default is 20.
+func WithMaxDepth(n int) func(*StructPages) {
+	return func(r *StructPages) {
+		r.maxDepth = n
+	}
+}
+
 // WithTargetSelector sets a custom TargetSelector function that determines
 // which component to render based on the request. The default is HTMXRenderTarget,
 // which handles HTMX partial requests automatically.
@@ -242,6 +552,50 @@ func WithTargetSelector(selector TargetSelector) func(*StructPages) {
 	}
 }
 
+// WithHTMXHistoryEnabled controls whether HTMXRenderTarget honors the
+// HX-History-Restore-Request header. htmx sends it when the browser
+// navigates back to a page whose snapshot is missing from the history
+// cache; the response replaces the whole document, so the full Page must
+// be rendered even though HX-Target may name a partial. Enabled by default.
+//
+// A page can decide for itself by declaring an HXHistoryRestore method
+// returning the RenderTarget to use. It is called only for history-restore
+// requests, with the usual dependency injection (*http.Request, WithArgs
+// values, ...); the result is passed to Props like any other target, and a
+// nil result falls back to the full Page:
+//
+//	func (p feedPage) HXHistoryRestore(r *http.Request) structpages.RenderTarget {
+//	    return feedRestoreTarget{} // Props renders it via RenderComponent(target)
+//	}
+//
+// The override applies even when the option is disabled.
+func WithHTMXHistoryEnabled(enabled bool) func(*StructPages) {
+	return func(r *StructPages) {
+		r.htmxHistoryDisabled = !enabled
+	}
+}
+
+// WithComponentTimeout bounds how long rendering a component may take. The
+// selector receives the page and the component being rendered ("Page",
+// "Details", or the function name for a standalone component; empty for a
+// component passed to RenderComponent directly) and returns the allowed
+// duration, 0 meaning no limit. The deadline is set on the context passed to
+// the component's Render method; if it is exceeded, the buffered output is
+// discarded and the error handler receives a *ComponentTimeoutError wrapping
+// context.DeadlineExceeded.
+//
+//	structpages.WithComponentTimeout(func(pn *structpages.PageNode, component string) time.Duration {
+//	    if component == "Details" {
+//	        return 2 * time.Second
+//	    }
+//	    return 0
+//	})
+func WithComponentTimeout(selector func(*PageNode, string) time.Duration) func(*StructPages) {
+	return func(r *StructPages) {
+		r.componentTimeout = selector
+	}
+}
+
 // WithErrorHandler sets a custom error handler function that will be called when
 // an error occurs during page rendering or request handling. If not set, a default
 // handler returns a generic "Internal Server Error" response.
@@ -251,6 +605,15 @@ func WithErrorHandler(onError func(http.ResponseWriter, *http.Request, error)) f
 	}
 }
 
+// WithMux sets the mux served by StructPages.ServeHTTP. Mount registers the
+// routes on it when called with a nil mux; a mux passed to Mount takes
+// precedence.
+func WithMux(mux Mux) func(*StructPages) {
+	return func(r *StructPages) {
+		r.mux = mux
+	}
+}
+
 // WithMiddlewares adds global middleware functions that will be applied to all routes.
 // Middleware is executed in the order provided, with the first middleware being the
 // outermost handler. These global middlewares run before any page-specific middlewares.
@@ -301,21 +664,15 @@ func (sp *StructPages) registerPageItem(mux Mux, page *PageNode, mw []Middleware
 	if page.Route == "" {
 		return fmt.Errorf("page item route is empty: %s", page.Name)
 	}
+	if !sp.inEnv(page) {
+		return nil
+	}
 
-	if page.Middlewares != nil {
-		res, err := sp.pc.callMethod(page, page.Middlewares)
-		if err != nil {
-			return fmt.Errorf("error calling Middlewares method on %s: %w", page.Name, err)
-		}
-		if len(res) != 1 {
-			return fmt.Errorf("middlewares method on %s did not return single result", page.Name)
-		}
-		mws, ok := res[0].Interface().([]MiddlewareFunc)
-		if !ok {
-			return fmt.Errorf("middlewares method on %s did not return []func(http.Handler, *PageNode) http.Handler", page.Name)
-		}
-		mw = append(mw, mws...)
+	mws, err := sp.pageMiddlewares(page)
+	if err != nil {
+		return err
 	}
+	mw = append(mw, mws...)
 	if page.Children != nil {
 		// nested pages has to be registered first to avoid conflicts with the parent route
 		for _, child := range page.Children {
@@ -325,6 +682,11 @@ func (sp *StructPages) registerPageItem(mux Mux, page *PageNode, mw []Middleware
 		}
 	}
 	handler := sp.buildHandler(page)
+	if page.IsRoot() {
+		if err := sp.registerRootFallback(mux, page, handler != nil, mw); err != nil {
+			return err
+		}
+	}
 	if handler == nil && len(page.Children) == 0 {
 		if sp.warnEmptyRoute != nil {
 			sp.warnEmptyRoute(page)
@@ -333,9 +695,19 @@ func (sp *StructPages) registerPageItem(mux Mux, page *PageNode, mw []Middleware
 	} else if handler == nil {
 		return nil
 	}
+	handler = sp.withRecovery(handler, page)
 	for _, middleware := range slices.Backward(mw) {
 		handler = middleware(handler, page)
 	}
+	handler = sp.withTestMode(handler, page)
+	if page.Method == http.MethodGet || page.Method == methodAll {
+		// Outermost, so bodies written by middlewares are stripped too.
+		handler = withHEAD(handler)
+	}
+	handler = sp.withRevert(handler, page)
+	if page == sp.defaultNode {
+		sp.defaultHandler = handler
+	}
 	// Pre-parse route segments for performance (done once at Mount time)
 	fullRoute := page.FullRoute()
 	if page.routeSegments == nil {
@@ -344,16 +716,98 @@ func (sp *StructPages) registerPageItem(mux Mux, page *PageNode, mw []Middleware
 			page.routeSegments = segments
 		}
 	}
-	// If method is "ALL", register without method prefix (matches all methods)
-	// Otherwise, register with "METHOD /path" format
-	pattern := fullRoute
-	if page.Method != methodAll {
-		pattern = page.Method + " " + pattern
+	mux.Handle(routePattern(sp.routePrefix, page), handler)
+	for i := range page.Aliases {
+		if err := handleAlias(mux, aliasPattern(sp.routePrefix, page, i), handler); err != nil {
+			return fmt.Errorf("page %s: %w", page.Name, err)
+		}
 	}
+	return nil
+}
+
+// handleAlias registers handler for the alias pattern, turning a conflict
+// panic from mux into an error.
+func handleAlias(mux Mux, pattern string, handler http.Handler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("alias %q: %v", pattern, r)
+		}
+	}()
 	mux.Handle(pattern, handler)
 	return nil
 }
 
+// registerPatterns registers the route and alias patterns of every page
+// Mount would register — routable and in the WithEnv environment — on mux,
+// with the handler h returns for the page and the route the pattern was
+// built from. ServeMux's conflict panics are returned as errors, so
+// Validate, Match and RegisterPlugin agree on which routes conflict.
+func (sp *StructPages) registerPatterns(mux *http.ServeMux, h func(pn *PageNode, route string) http.Handler) error {
+	var errs []error
+	for pn := range sp.pc.root().All() {
+		if pn.Route == "" || !pn.routable() || !sp.inEnv(pn) {
+			continue
+		}
+		pattern := routePattern(sp.routePrefix, pn)
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					errs = append(errs, fmt.Errorf("page %s: route %q: %v", pn.Name, pattern, r))
+				}
+			}()
+			mux.Handle(pattern, h(pn, pn.FullRoute()))
+		}()
+		for i := range pn.Aliases {
+			if err := handleAlias(mux, aliasPattern(sp.routePrefix, pn, i), h(pn, pn.aliasRoute(i))); err != nil {
+				errs = append(errs, fmt.Errorf("page %s: %w", pn.Name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// aliasPattern is routePattern for page's alias i.
+func aliasPattern(prefix string, page *PageNode, i int) string {
+	route := prefix + page.aliasRoute(i)
+	if page.Method == methodAll {
+		return route
+	}
+	return page.Method + " " + route
+}
+
+// pageMiddlewares returns the middlewares of page's Middlewares method,
+// if it has one.
+func (sp *StructPages) pageMiddlewares(page *PageNode) ([]MiddlewareFunc, error) {
+	if page.Middlewares == nil {
+		return nil, nil
+	}
+	res, err := sp.pc.callMethod(page, page.Middlewares)
+	if err != nil {
+		return nil, fmt.Errorf("error calling Middlewares method on %s: %w", page.Name, err)
+	}
+	if len(res) != 1 {
+		return nil, fmt.Errorf("middlewares method on %s did not return single result", page.Name)
+	}
+	mws, ok := res[0].Interface().([]MiddlewareFunc)
+	if !ok {
+		return nil, fmt.Errorf("middlewares method on %s did not return []func(http.Handler, *PageNode) http.Handler",
+			page.Name)
+	}
+	return mws, nil
+}
+
+// routePattern returns the mux pattern page is registered under, with the
+// WithRoutePrefix prefix applied once to the full route.
+// If method is "ALL", register without method prefix (matches all methods)
+// Otherwise, register with "METHOD /path" format
+func routePattern(prefix string, page *PageNode) string {
+	route := prefix + page.FullRoute()
+	if page.Method == methodAll {
+		return route
+	}
+	return page.Method + " " + route
+}
+
 func (sp *StructPages) buildHandler(page *PageNode) http.Handler {
 	if h := sp.asHandler(page); h != nil {
 		return h
@@ -365,17 +819,34 @@ func (sp *StructPages) buildHandler(page *PageNode) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Inject current page into context for IDFor to use with standalone functions
 		ctx := currentPageCtx.WithValue(r.Context(), page)
+		if len(sp.afterRequest) > 0 {
+			start, rec := time.Now(), &requestErr{}
+			ctx = requestErrCtx.WithValue(ctx, rec)
+			defer func() { sp.runAfterRequest(r, page, time.Since(start), rec.err) }()
+		}
 		r = r.WithContext(ctx)
 
 		// 1. Select which component to render using TargetSelector
-		target, err := sp.targetSelector(r, page)
+		target, err := sp.selectTarget(r, page)
 		if err != nil {
 			sp.onError(w, r, fmt.Errorf("error selecting target for %s: %w", page.Name, err))
 			return
 		}
+		sp.runOnRequest(r, page)
+		if err := sp.validateQuery(r, page); err != nil {
+			sp.onError(w, r, err)
+			return
+		}
 
-		// 2. Call Props with RenderTarget available for injection
-		props, err := sp.execProps(page, r, w, target)
+		// 2. Call Props with RenderTarget available for injection, after
+		// the ancestors' Props when WithPropsChain is enabled
+		var props []reflect.Value
+		if sp.propsChain {
+			r, err = sp.runPropsChain(w, r, page, target)
+		}
+		if err == nil {
+			props, err = sp.execProps(page, r, w, target)
+		}
 		if err != nil {
 			// Check if it's a render component error
 			if sp.handleRenderComponentError(w, r, err, page) {
@@ -385,7 +856,14 @@ func (sp *StructPages) buildHandler(page *PageNode) http.Handler {
 			if errors.Is(err, ErrSkipPageRender) {
 				return
 			}
-			sp.onError(w, r, fmt.Errorf("error running props for %s: %w", page.Name, err))
+			if sp.handleRedirectError(w, r, err) {
+				return
+			}
+			if errors.Is(err, errStopPolling) {
+				stopPolling(w)
+				return
+			}
+			sp.onError(w, r, bodyTooLarge(fmt.Errorf("error running props for %s: %w", page.Name, err)))
 			return
 		}
 
@@ -407,7 +885,7 @@ func (sp *StructPages) buildHandler(page *PageNode) http.Handler {
 				sp.onError(w, r, fmt.Errorf("error calling component %s.%s: %w", page.Name, mrt.method.Name, err))
 				return
 			}
-			sp.render(w, r, comp)
+			sp.render(w, r, comp, page, mrt.method.Name)
 			return
 		}
 
@@ -429,7 +907,7 @@ func (sp *StructPages) buildHandler(page *PageNode) http.Handler {
 						sp.onError(w, r, fmt.Errorf("error calling Page() fallback for %s: %w", page.Name, err))
 						return
 					}
-					sp.render(w, r, comp)
+					sp.render(w, r, comp, page, pageMethod.Name)
 					return
 				}
 			}
@@ -450,15 +928,61 @@ func (sp *StructPages) buildHandler(page *PageNode) http.Handler {
 	})
 }
 
-func (sp *StructPages) render(w http.ResponseWriter, r *http.Request, comp component) {
+// render renders comp, the component name of page, into a buffer and writes
+// it out, applying the WithComponentTimeout deadline if one is configured.
+func (sp *StructPages) render(w http.ResponseWriter, r *http.Request, comp component, page *PageNode, name string) {
+	if rendered := renderedComponentCtx.Value(r.Context()); rendered != nil {
+		*rendered = name
+	}
+	if sc, ok := comp.(StatusComponent); ok && sc.Status != 0 {
+		w = &statusWriter{ResponseWriter: w, status: sc.Status}
+	}
+	ctx := r.Context()
+	var timeout time.Duration
+	if sp.componentTimeout != nil {
+		if timeout = sp.componentTimeout(page, name); timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+	}
+	if sc, ok := comp.(StreamComponent); ok {
+		if err := sp.setHTMXHeaders(w, r, page); err != nil {
+			sp.onError(w, r, err)
+			return
+		}
+		sp.stream(ctx, w, r, sc)
+		sp.writeTrailers(w, r, page)
+		return
+	}
 	buf := getBuffer()
 	defer releaseBuffer(buf)
-	if err := comp.Render(r.Context(), buf); err != nil {
+	start := time.Now()
+	err := comp.Render(ctx, buf)
+	elapsed := time.Since(start)
+	// Render may not observe ctx, so check the deadline after it returns.
+	if timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) && r.Context().Err() == nil {
+		pageName := ""
+		if page != nil {
+			pageName = page.Name
+		}
+		sp.onError(w, r, &ComponentTimeoutError{Page: pageName, Component: name, Timeout: timeout})
+		return
+	}
+	if err == nil {
+		err = sp.setHTMXHeaders(w, r, page)
+	}
+	if err != nil {
 		sp.onError(w, r, err)
 		return
 	}
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	_, _ = w.Write(buf.Bytes())
+	sp.runAfterRender(page, name, buf.Len(), elapsed)
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	}
+	if _, err := w.Write(buf.Bytes()); err == nil {
+		sp.writeTrailers(w, r, page)
+	}
 }
 
 type httpErrHandler interface {
@@ -500,11 +1024,11 @@ func (sp *StructPages) asHandler(pn *PageNode) http.Handler {
 				// Clear the buffer since we have an error
 				bw.buf.Reset()
 				// Check if it's a render component error
-				if sp.handleRenderComponentError(bw, r, err, pn) {
+				if sp.handleRenderComponentError(bw, r, err, pn) || sp.handleRedirectError(bw, r, err) {
 					return
 				}
 				// Write error directly to the buffered writer
-				sp.onError(bw, r, err)
+				sp.onError(bw, r, bodyTooLarge(err))
 			}
 		})
 	}
@@ -549,10 +1073,10 @@ func (sp *StructPages) asHandler(pn *PageNode) http.Handler {
 				// - therefore this branch is only reachable when bw != nil
 				bw.buf.Reset()
 				// Check if it's a render component error
-				if sp.handleRenderComponentError(bw, r, err, pn) {
+				if sp.handleRenderComponentError(bw, r, err, pn) || sp.handleRedirectError(bw, r, err) {
 					return
 				}
-				sp.onError(bw, r, err)
+				sp.onError(bw, r, bodyTooLarge(err))
 				return
 			}
 		})
@@ -566,15 +1090,26 @@ func (sp *StructPages) asHandler(pn *PageNode) http.Handler {
 
 func (sp *StructPages) execProps(pn *PageNode,
 	r *http.Request, w http.ResponseWriter, renderTarget RenderTarget,
-) ([]reflect.Value, error) {
-	// Look for Props method
-	propMethod, ok := pn.Props["Props"]
-	if !ok {
+) (results []reflect.Value, err error) {
+	// Look for Props methods: just Props, or all of them with WithPropsWaterfall
+	methods := sp.propsMethods(pn)
+	if sp.lazyProps {
+		methods = neededProps(methods, renderTarget)
+	}
+	if len(methods) == 0 {
 		return nil, nil
 	}
-
-	if !propMethod.Func.IsValid() {
-		return nil, fmt.Errorf("Props method for page %s has invalid Func", pn.Name)
+	if timeout := sp.propsTimeoutFor(pn); timeout > 0 {
+		parent := r.Context()
+		ctx, cancel := context.WithTimeout(parent, timeout)
+		defer cancel()
+		r = r.WithContext(ctx)
+		defer func() {
+			// Props may not observe ctx, so check the deadline after they return.
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) && parent.Err() == nil {
+				results, err = nil, &PropsTimeoutError{Page: pn.Name, Timeout: timeout}
+			}
+		}()
 	}
 
 	// Make RenderTarget available for injection along with r and w
@@ -583,9 +1118,40 @@ func (sp *StructPages) execProps(pn *PageNode,
 	if renderTarget != nil {
 		args = append(args, reflect.ValueOf(renderTarget))
 	}
-	props, err := sp.pc.callMethod(pn, &propMethod, args...)
-	if err != nil {
-		return nil, fmt.Errorf("error calling Props method %s.Props: %w", pn.Name, err)
+	if sp.propsChain {
+		args = append(args, propsChainCtx.Value(r.Context())...)
+	}
+	for _, propMethod := range methods {
+		if !propMethod.Func.IsValid() {
+			return nil, fmt.Errorf("%s method for page %s has invalid Func", propMethod.Name, pn.Name)
+		}
+		props, err := sp.pc.callMethod(pn, &propMethod, append(args, results...)...)
+		if err != nil {
+			return nil, fmt.Errorf("error calling Props method %s.%s: %w", pn.Name, propMethod.Name, err)
+		}
+		props, err = extractError(props)
+		if err != nil {
+			return nil, err
+		}
+		if props, err = sp.pc.writeCookies(w, props); err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", pn.Name, propMethod.Name, err)
+		}
+		if len(props) > 0 && returnsResult(&propMethod) {
+			op, err := applyResult(w, props[0].Interface().(ResultProvider).Result())
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", pn.Name, propMethod.Name, err)
+			}
+			return nil, &errRenderComponent{op: op}
+		}
+		// A component returned by Props renders as with RenderComponent, a
+		// nil one leaves the choice to the render target
+		if len(props) > 0 && returnsComponent(&propMethod) {
+			if comp, ok := props[0].Interface().(component); ok {
+				return nil, &errRenderComponent{op: &renderOp{component: comp}}
+			}
+			props = props[1:]
+		}
+		results = append(results, props...)
 	}
-	return extractError(props)
+	return results, nil
 }
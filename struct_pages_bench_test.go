@@ -666,7 +666,7 @@ func BenchmarkIDGenerationV06(b *testing.B) {
 
 	// Pre-find the AdminDash node for self-render bench.
 	var admin *PageNode
-	for n := range pc.root.All() {
+	for n := range pc.root().All() {
 		if n.Name == "AdminDash" {
 			admin = n
 			break
@@ -759,7 +759,7 @@ func BenchmarkReflection(b *testing.B) {
 		if err != nil {
 			b.Fatal(err)
 		}
-		pn := pc.root
+		pn := pc.root()
 		method, ok := reflect.TypeOf(benchTestPage{}).MethodByName("TestMethod")
 		if !ok {
 			b.Fatal("method not found")
@@ -777,7 +777,7 @@ func BenchmarkReflection(b *testing.B) {
 		if err != nil {
 			b.Fatal(err)
 		}
-		pn := pc.root
+		pn := pc.root()
 		method, ok := reflect.TypeOf(benchTestPageWithDI{}).MethodByName("TestMethod")
 		if !ok {
 			b.Fatal("method not found")
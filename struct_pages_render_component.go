@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
 )
 
 // renderOp represents what to render and how to get it.
@@ -15,6 +16,26 @@ type renderOp struct {
 
 	// For methodRenderTarget from Props:
 	method *reflect.Method // The method to call on page
+
+	// formErrors is set by FormResponse and stored in the request context.
+	formErrors FormErrors
+
+	// status is the response status of a Result, 0 for the default.
+	status int
+}
+
+// componentName names the component op renders for WithComponentTimeout:
+// the method name, the bare function name, or "" for a direct component.
+func (op *renderOp) componentName() string {
+	switch {
+	case op.method != nil:
+		return op.method.Name
+	case op.callable.IsValid():
+		name := strings.TrimSuffix(formatCallable(op.callable), "-fm")
+		return name[strings.LastIndex(name, ".")+1:]
+	default:
+		return ""
+	}
 }
 
 // errRenderComponent is an internal error type that carries a renderOp.
@@ -212,6 +233,12 @@ func (sp *StructPages) handleRenderComponentError(
 	}
 
 	op := renderErr.op
+	if op.formErrors != nil {
+		r = r.WithContext(formErrorsCtx.WithValue(r.Context(), op.formErrors))
+	}
+	if op.status != 0 {
+		w = &statusWriter{ResponseWriter: w, status: op.status}
+	}
 
 	// For method expressions (not from RenderTarget), we need to resolve the page
 	if op.callable.IsValid() && op.method == nil {
@@ -263,6 +290,6 @@ func (sp *StructPages) handleRenderComponentError(
 	}
 
 	// Render the component
-	sp.render(w, r, comp)
+	sp.render(w, r, comp, page, op.componentName())
 	return true
 }
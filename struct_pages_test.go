@@ -462,7 +462,7 @@ func TestWithErrorHandler(t *testing.T) {
 		onError: customErrorHandler,
 	}
 	pc := &parseContext{}
-	pc.root = &PageNode{}
+	pc.setRoot(&PageNode{})
 
 	pn := &PageNode{
 		Name:  "ErrHandler",
@@ -1196,7 +1196,7 @@ func TestHandleRenderComponentError_NoReceiver(t *testing.T) {
 	standaloneFunc := func() component { return testComponent{"test from function"} }
 
 	err = RenderComponent(standaloneFunc)
-	handled := sp.handleRenderComponentError(rec, req, err, sp.pc.root)
+	handled := sp.handleRenderComponentError(rec, req, err, sp.pc.root())
 
 	if !handled {
 		t.Error("Expected handleRenderComponentError to handle the error")
@@ -1231,7 +1231,7 @@ func TestRenderComponent_ComponentGetter(t *testing.T) {
 	// Create a componentGetter
 	getter := myComponentGetter{data: "from component getter"}
 	err = RenderComponent(getter)
-	handled := sp.handleRenderComponentError(rec, req, err, sp.pc.root)
+	handled := sp.handleRenderComponentError(rec, req, err, sp.pc.root())
 
 	if !handled {
 		t.Error("Expected handleRenderComponentError to handle the error")
@@ -1264,7 +1264,7 @@ func TestHandleRenderComponentError_PageNotFound(t *testing.T) {
 
 	// Try to render component from unregistered page
 	err = RenderComponent(unregisteredPage.SomeComponent)
-	handled := sp.handleRenderComponentError(rec, req, err, sp.pc.root)
+	handled := sp.handleRenderComponentError(rec, req, err, sp.pc.root())
 
 	if !handled {
 		t.Error("Expected handleRenderComponentError to handle the error")
@@ -1298,7 +1298,7 @@ func TestHandleRenderComponentError_ComponentCallError(t *testing.T) {
 
 	// Trigger component render error
 	err = RenderComponent(errorComponentPage.ErrorComponent)
-	handled := sp.handleRenderComponentError(rec, req, err, sp.pc.root)
+	handled := sp.handleRenderComponentError(rec, req, err, sp.pc.root())
 
 	if !handled {
 		t.Error("Expected handleRenderComponentError to handle the error")
@@ -1324,7 +1324,7 @@ func TestHandleRenderComponentError_WithArgs(t *testing.T) {
 
 	// Trigger component render with args
 	err = RenderComponent(argsComponentTestPage.ComponentWithArgs, "arg1", 42)
-	handled := sp.handleRenderComponentError(rec, req, err, sp.pc.root)
+	handled := sp.handleRenderComponentError(rec, req, err, sp.pc.root())
 
 	if !handled {
 		t.Error("Expected handleRenderComponentError to handle the error")
@@ -1370,7 +1370,7 @@ func TestRenderComponent_InsufficientArgs(t *testing.T) {
 	err = RenderComponent((*argsComponentTestPage).ComponentWithArgs, "only-one-arg")
 
 	// This should be handled gracefully, not panic
-	handled := sp.handleRenderComponentError(rec, req, err, sp.pc.root)
+	handled := sp.handleRenderComponentError(rec, req, err, sp.pc.root())
 
 	if !handled {
 		t.Error("Expected handleRenderComponentError to handle the error")
@@ -1478,7 +1478,7 @@ func TestRenderComponent_StandaloneFunctionInsufficientArgs(t *testing.T) {
 	// Try to call a standalone function with insufficient args
 	// Should now be handled gracefully with validation
 	err = RenderComponent(standaloneComponentFunc, "only-one-arg")
-	handled := sp.handleRenderComponentError(rec, req, err, sp.pc.root)
+	handled := sp.handleRenderComponentError(rec, req, err, sp.pc.root())
 
 	if !handled {
 		t.Error("Expected handleRenderComponentError to handle the error")
@@ -1527,7 +1527,7 @@ func TestRenderComponent_TypeMismatch(t *testing.T) {
 		},
 	}
 
-	handled := sp.handleRenderComponentError(rec, req, err, sp.pc.root)
+	handled := sp.handleRenderComponentError(rec, req, err, sp.pc.root())
 
 	if !handled {
 		t.Error("Expected handleRenderComponentError to handle the error")
@@ -1572,7 +1572,7 @@ func TestHandleRenderComponentError_BoundMethodWithArgs(t *testing.T) {
 
 	// Trigger component render with bound method - this should work without panic
 	err = RenderComponent(boundMethod, "bound", 99)
-	handled := sp.handleRenderComponentError(rec, req, err, sp.pc.root)
+	handled := sp.handleRenderComponentError(rec, req, err, sp.pc.root())
 
 	if !handled {
 		t.Error("Expected handleRenderComponentError to handle the error")
@@ -2215,7 +2215,7 @@ func TestExecuteRenderOp_Errors(t *testing.T) {
 		args:     []reflect.Value{},
 	}
 
-	_, err = sp.executeRenderOp(op, sp.pc.root)
+	_, err = sp.executeRenderOp(op, sp.pc.root())
 	if err == nil {
 		t.Fatal("Expected error for function not returning component")
 	}
@@ -2232,7 +2232,7 @@ func TestExecuteRenderOp_Errors(t *testing.T) {
 		args:     []reflect.Value{},
 	}
 
-	_, err = sp.executeRenderOp(op2, sp.pc.root)
+	_, err = sp.executeRenderOp(op2, sp.pc.root())
 	if err == nil {
 		t.Fatal("Expected error for function returning multiple values")
 	}
@@ -2257,7 +2257,7 @@ func TestExecuteRenderOp_Errors(t *testing.T) {
 
 	// Test renderOp with nothing set
 	op4 := &renderOp{}
-	_, err = sp.executeRenderOp(op4, sp.pc.root)
+	_, err = sp.executeRenderOp(op4, sp.pc.root())
 	if err == nil {
 		t.Fatal("Expected error for empty renderOp")
 	}
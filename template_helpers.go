@@ -0,0 +1,82 @@
+package structpages
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// TemplateHelpers are URLFor, ID and IDTarget bound to one request, for
+// templates that would rather not pass ctx to every call. The methods panic
+// where the context functions return an error, so a bad reference fails the
+// render instead of producing a broken link. A TemplateHelpers is created
+// per request and is only valid for that request.
+type TemplateHelpers struct {
+	ctx context.Context
+}
+
+// URLFor is MustURLFor with the request's context.
+func (h *TemplateHelpers) URLFor(page any, args ...any) string {
+	return MustURLFor(h.ctx, page, args...)
+}
+
+// ID is like the ID function with the request's context, but panics if the
+// id can't be generated.
+func (h *TemplateHelpers) ID(v any) string {
+	id, err := ID(h.ctx, v)
+	if err != nil {
+		panic(fmt.Sprintf("structpages: ID(%s): %v", describeRef(v), err))
+	}
+	return id
+}
+
+// IDTarget is like the IDTarget function with the request's context, but
+// panics if the selector can't be generated.
+func (h *TemplateHelpers) IDTarget(v any) string {
+	id, err := IDTarget(h.ctx, v)
+	if err != nil {
+		panic(fmt.Sprintf("structpages: IDTarget(%s): %v", describeRef(v), err))
+	}
+	return id
+}
+
+// WithTemplateHelpers stores a *TemplateHelpers for each request in its
+// context under key, which must be comparable and should be of an unexported
+// type, as for context.WithValue. Components get it back with
+// GetTemplateHelpers:
+//
+//	type helpersKey struct{}
+//
+//	structpages.Mount(mux, pages{}, "/", "App",
+//	    structpages.WithTemplateHelpers(helpersKey{}))
+//
+//	// in a templ file
+//	{{ h, _ := structpages.GetTemplateHelpers(ctx, helpersKey{}) }}
+//	<a href={ h.URLFor(UserListPage{}) }>Users</a>
+//
+// The helpers resolve pages in the tree of the StructPages the request was
+// routed by, relative to the page being served.
+func WithTemplateHelpers(key any) func(*StructPages) {
+	return func(sp *StructPages) {
+		sp.templateHelpersKey = key
+	}
+}
+
+// GetTemplateHelpers returns the helpers WithTemplateHelpers stored in ctx
+// under key.
+func GetTemplateHelpers(ctx context.Context, key any) (*TemplateHelpers, bool) {
+	h, ok := ctx.Value(key).(*TemplateHelpers)
+	return h, ok
+}
+
+// withTemplateHelpers stores the request's TemplateHelpers under key. It
+// runs after withPcCtx and extractURLParams, so the helpers see the tree and
+// the path parameters.
+func withTemplateHelpers(key any) MiddlewareFunc {
+	return func(next http.Handler, node *PageNode) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h := &TemplateHelpers{ctx: currentPageCtx.WithValue(r.Context(), node)}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), key, h)))
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package structpages
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+type helpersKey struct{}
+
+type helpersListPage struct{}
+
+func (helpersListPage) Page() component { return testComponent{"list"} }
+
+type helpersUserPage struct{}
+
+func (helpersUserPage) Page() component {
+	return fnComponent(func(ctx context.Context, w io.Writer) error {
+		h, ok := GetTemplateHelpers(ctx, helpersKey{})
+		if !ok {
+			return fmt.Errorf("no template helpers in ctx")
+		}
+		_, err := fmt.Fprintf(w, "%s %s %s", h.URLFor(helpersListPage{}), h.URLFor(helpersUserPage{}),
+			h.IDTarget(helpersUserPage.Detail))
+		return err
+	})
+}
+
+func (helpersUserPage) Detail() component { return testComponent{"detail"} }
+
+type helpersPages struct {
+	List helpersListPage `route:"/users List"`
+	User helpersUserPage `route:"/users/{id} User"`
+}
+
+func TestWithTemplateHelpers(t *testing.T) {
+	mux := http.NewServeMux()
+	if _, err := Mount(mux, &helpersPages{}, "/", "App", WithTemplateHelpers(helpersKey{})); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	if _, err := Mount(mux, &helpersPages{}, "/", "Admin", WithTemplateHelpers(helpersKey{}),
+		WithRoutePrefix("/admin")); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		prefix := ""
+		if i%2 == 1 {
+			prefix = "/admin"
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s/users/%d", prefix, i), http.NoBody))
+			want := fmt.Sprintf("%s/users %s/users/%d #user-detail", prefix, prefix, i)
+			if got := rec.Body.String(); got != want {
+				t.Errorf("body = %q, want %q", got, want)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestGetTemplateHelpers_Missing(t *testing.T) {
+	if _, ok := GetTemplateHelpers(context.Background(), helpersKey{}); ok {
+		t.Error("GetTemplateHelpers found helpers in a bare context")
+	}
+}
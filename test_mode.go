@@ -0,0 +1,139 @@
+package structpages
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"slices"
+	"sync"
+)
+
+// TB is the part of testing.TB that WithTestMode and CaptureErrors use, so
+// that the package does not link testing into production binaries.
+// *testing.T, *testing.B and *testing.F satisfy it.
+type TB interface {
+	Helper()
+	Errorf(format string, args ...any)
+	Logf(format string, args ...any)
+	Cleanup(func())
+}
+
+// testReporter receives the panics and errors of a StructPages mounted with
+// WithTestMode or WithTestModeRecorder.
+type testReporter interface {
+	reportPanic(err error, stack []byte)
+	reportError(err error)
+}
+
+// WithTestMode makes sp safe to drive from integration tests: a panic in a
+// page handler or middleware is recovered, reported with t.Errorf, stack
+// included, and answered with a 500 instead of crashing the test binary,
+// and every error reaching the error handler is logged with t.Logf before
+// the handler runs, so it shows up next to the test that caused it.
+//
+//	sp, err := structpages.Mount(mux, pages{}, "/", "App", structpages.WithTestMode(t))
+//
+// Panics are recovered outside WithRecoveryComponent, so a recovery
+// component still renders for the panics it handles.
+func WithTestMode(t TB) func(*StructPages) {
+	return func(sp *StructPages) {
+		sp.testReporter = tbReporter{t}
+	}
+}
+
+// WithTestModeRecorder is WithTestMode for code without a TB, such
+// as benchmarks or a test server: panics and errors are collected in rec
+// instead of reported.
+//
+//	rec := &structpages.TestModeRecorder{}
+//	sp, err := structpages.Mount(mux, pages{}, "/", "App", structpages.WithTestModeRecorder(rec))
+func WithTestModeRecorder(rec *TestModeRecorder) func(*StructPages) {
+	return func(sp *StructPages) {
+		sp.testReporter = rec
+	}
+}
+
+type tbReporter struct{ t TB }
+
+func (r tbReporter) reportPanic(err error, stack []byte) {
+	r.t.Errorf("structpages: %v\n%s", err, stack)
+}
+
+func (r tbReporter) reportError(err error) {
+	r.t.Logf("structpages: %v", err)
+}
+
+// TestModeRecorder collects the panics and errors of pages mounted with
+// WithTestModeRecorder. It is safe for concurrent use.
+type TestModeRecorder struct {
+	mu     sync.Mutex
+	errors []error
+	panics int
+}
+
+func (rec *TestModeRecorder) reportPanic(err error, _ []byte) {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	rec.errors = append(rec.errors, err)
+	rec.panics++
+}
+
+func (rec *TestModeRecorder) reportError(err error) {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	rec.errors = append(rec.errors, err)
+}
+
+// Errors returns the recorded errors in the order they happened. A
+// recovered panic is recorded as an error wrapping ErrPagePanicked.
+func (rec *TestModeRecorder) Errors() []error {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	return slices.Clone(rec.errors)
+}
+
+// Panics returns how many panics were recovered.
+func (rec *TestModeRecorder) Panics() int {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	return rec.panics
+}
+
+// ErrPagePanicked is wrapped by the errors WithTestMode and
+// WithTestModeRecorder report for recovered panics.
+var ErrPagePanicked = errors.New("structpages: page panicked")
+
+// withTestMode recovers panics from the handler of pn, middlewares
+// included, for WithTestMode.
+func (sp *StructPages) withTestMode(next http.Handler, pn *PageNode) http.Handler {
+	if sp.testReporter == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := &statusRecorder{ResponseWriter: w}
+		defer func() {
+			v := recover()
+			if v == nil {
+				return
+			}
+			if v == http.ErrAbortHandler {
+				panic(v)
+			}
+			sp.testReporter.reportPanic(fmt.Errorf("%w: %s: %v", ErrPagePanicked, pn.Name, v), debug.Stack())
+			if rec.status == 0 {
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			}
+		}()
+		next.ServeHTTP(rec, r)
+	})
+}
+
+// reportErrors returns onError reporting each error to sp.testReporter
+// before handling it.
+func (sp *StructPages) reportErrors(onError errorHandler) errorHandler {
+	return func(w http.ResponseWriter, r *http.Request, err error) {
+		sp.testReporter.reportError(err)
+		onError(w, r, err)
+	}
+}
@@ -0,0 +1,108 @@
+package structpages
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeTB records Errorf and Logf calls instead of failing the test.
+type fakeTB struct {
+	testing.TB
+	mu     sync.Mutex
+	errors []string
+	logs   []string
+}
+
+func (tb *fakeTB) Helper() {}
+
+func (tb *fakeTB) Errorf(format string, args ...any) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	tb.errors = append(tb.errors, fmt.Sprintf(format, args...))
+}
+
+func (tb *fakeTB) Logf(format string, args ...any) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	tb.logs = append(tb.logs, fmt.Sprintf(format, args...))
+}
+
+type testModePanicPage struct{}
+
+func (testModePanicPage) Props() (string, error) { panic("props exploded") }
+
+func (testModePanicPage) Page(string) component { return testComponent{"never"} }
+
+type failingComponent struct{}
+
+func (failingComponent) Render(context.Context, io.Writer) error { return errors.New("render failed") }
+
+type testModeErrorPage struct{}
+
+func (testModeErrorPage) Page() component { return failingComponent{} }
+
+type testModePages struct {
+	Panic testModePanicPage `route:"/panic Panic"`
+	Error testModeErrorPage `route:"/error Error"`
+}
+
+func TestWithTestMode(t *testing.T) {
+	tb := &fakeTB{TB: t}
+	mux := http.NewServeMux()
+	if _, err := Mount(mux, &testModePages{}, "/", "App", WithTestMode(tb)); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/panic", http.NoBody))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("panic: status = %d, want 500", rec.Code)
+	}
+	if len(tb.errors) != 1 || !strings.Contains(tb.errors[0], "Panic: props exploded") {
+		t.Errorf("panic: Errorf calls = %q, want one naming the page and panic", tb.errors)
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/error", http.NoBody))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("error: status = %d, want 500", rec.Code)
+	}
+	if len(tb.logs) != 1 || !strings.Contains(tb.logs[0], "render failed") {
+		t.Errorf("error: Logf calls = %q, want one with the render error", tb.logs)
+	}
+	if len(tb.errors) != 1 {
+		t.Errorf("error: Errorf calls = %q, want only the earlier panic", tb.errors)
+	}
+}
+
+func TestWithTestModeRecorder(t *testing.T) {
+	rec := &TestModeRecorder{}
+	mux := http.NewServeMux()
+	if _, err := Mount(mux, &testModePages{}, "/", "App", WithTestModeRecorder(rec)); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	for _, path := range []string{"/panic", "/error", "/panic"} {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("GET %s: status = %d, want 500", path, w.Code)
+		}
+	}
+	errs := rec.Errors()
+	if len(errs) != 3 || rec.Panics() != 2 {
+		t.Fatalf("recorded %d errors, %d panics: %v; want 3 and 2", len(errs), rec.Panics(), errs)
+	}
+	panicked := []bool{true, false, true}
+	for i, err := range errs {
+		if errors.Is(err, ErrPagePanicked) != panicked[i] {
+			t.Errorf("errors[%d] = %v; wraps ErrPagePanicked = %v, want %v", i, err, !panicked[i], panicked[i])
+		}
+	}
+}
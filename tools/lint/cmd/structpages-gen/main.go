@@ -0,0 +1,91 @@
+// structpages-gen generates type-safe URLFor helpers for every page
+// mounted by a structpages.Mount call.
+//
+// Usage:
+//
+//	//go:generate structpages-gen -in pages.go -out pages_urls.gen.go
+//
+// The package containing -in determines the output package. Mount calls
+// are looked up in that package by default; pass extra package patterns
+// as arguments when the page tree is mounted elsewhere. Type errors in
+// the loaded packages are tolerated so call sites that already use the
+// generated helpers don't block regeneration.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jackielii/structpages/tools/lint"
+	"github.com/jackielii/structpages/tools/lint/gen"
+	"golang.org/x/tools/go/packages"
+)
+
+func main() {
+	in := flag.String("in", "", "Go source file whose package receives the generated helpers (required)")
+	out := flag.String("out", "", "output file (default: <in>_urls.gen.go)")
+	funcName := flag.String("func-name", gen.DefaultURLFuncName,
+		"fmt format for generated function names; %s receives the page type name")
+	tags := flag.String("tags", "", "comma-separated build tags to load packages under")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: structpages-gen -in file.go [-out file.gen.go] [-func-name fmt] [packages...]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *in == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *out == "" {
+		base := (*in)[:len(*in)-len(filepath.Ext(*in))]
+		*out = base + "_urls.gen.go"
+	}
+
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedSyntax |
+			packages.NeedTypesInfo | packages.NeedDeps | packages.NeedImports |
+			packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedModule,
+	}
+	if *tags != "" {
+		cfg.BuildFlags = []string{"-tags=" + *tags}
+	}
+	patterns := append([]string{"file=" + *in}, flag.Args()...)
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "load: %v\n", err)
+		os.Exit(2)
+	}
+	if len(pkgs) == 0 || pkgs[0].Types == nil {
+		fmt.Fprintf(os.Stderr, "structpages-gen: no package found for %s\n", *in)
+		os.Exit(2)
+	}
+	for _, pkg := range pkgs {
+		for _, e := range pkg.Errors {
+			if e.Kind != packages.TypeError {
+				fmt.Fprintf(os.Stderr, "%s\n", e)
+				os.Exit(1)
+			}
+		}
+	}
+
+	tree, diags := lint.BuildTree(pkgs)
+	for _, d := range diags {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", d.Pos, d.Message)
+	}
+	if len(tree.Roots) == 0 {
+		fmt.Fprintln(os.Stderr, "structpages-gen: no structpages.Mount(...) call found in scope")
+		os.Exit(1)
+	}
+
+	src, err := gen.Generate(tree, pkgs[0].Types, gen.WithURLFuncName(*funcName))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "structpages-gen: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil { //nolint:gosec // generated source is meant to be readable
+		fmt.Fprintf(os.Stderr, "structpages-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
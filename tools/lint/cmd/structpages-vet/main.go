@@ -0,0 +1,17 @@
+// structpages-vet checks structpages `route` struct tags for malformed
+// patterns, invalid HTTP methods, unclosed braces and duplicate
+// parameter names. It speaks the go vet tool protocol:
+//
+//	go vet -vettool=$(which structpages-vet) ./...
+//
+// and also runs standalone: structpages-vet [packages...]
+package main
+
+import (
+	"github.com/jackielii/structpages/tools/lint"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(lint.RouteTagAnalyzer)
+}
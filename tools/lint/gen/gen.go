@@ -0,0 +1,213 @@
+// Package gen generates type-safe URLFor helpers for a structpages page
+// tree.
+//
+// The page tree is reconstructed statically by [lint.BuildTree], so the
+// generator sees exactly the pages structpages-lint validates against.
+// For every page type that is mounted once, two helpers are emitted:
+//
+//	// URLForHomePage returns the URL for homePage, panicking on error.
+//	func URLForHomePage(sp *structpages.StructPages, args ...any) string
+//
+//	// MustURLForHomePage resolves against the request context (the form
+//	// templ components use), panicking on error.
+//	func MustURLForHomePage(ctx context.Context, args ...any) string
+//
+// Both forward args unchanged to URLFor, so map, positional, and
+// key/value-pair parameters all work. Types mounted more than once are
+// ambiguous for a bare type lookup and are skipped with a comment in the
+// output; use the []any chain form of URLFor for those.
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/types"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jackielii/structpages/tools/lint"
+)
+
+// DefaultURLFuncName is the default fmt format for generated function
+// names. The single %s verb receives the page type name with its first
+// letter upper-cased.
+const DefaultURLFuncName = "URLFor%s"
+
+type config struct {
+	funcName string
+}
+
+// Option configures Generate.
+type Option func(*config)
+
+// WithURLFuncName sets the fmt format used to name generated functions.
+// The format must contain exactly one %s verb, which receives the page
+// type name with its first letter upper-cased; the context-based variant
+// is the same name prefixed with "Must". The default is
+// DefaultURLFuncName ("URLFor%s").
+func WithURLFuncName(format string) Option {
+	return func(c *config) {
+		c.funcName = format
+	}
+}
+
+// Generate renders a Go source file in package pkg containing URLFor
+// helpers for every uniquely mounted page type in tree. Page types from
+// other packages are imported; unexported types from other packages
+// cannot be referenced and are skipped.
+func Generate(tree *lint.PageTree, pkg *types.Package, opts ...Option) ([]byte, error) {
+	cfg := &config{funcName: DefaultURLFuncName}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if strings.Count(cfg.funcName, "%s") != 1 {
+		return nil, fmt.Errorf("URL func name format %q must contain exactly one %%s", cfg.funcName)
+	}
+
+	pages, skipped := collectPages(tree, pkg)
+
+	imports := map[string]string{
+		"context":                          "context",
+		"github.com/jackielii/structpages": "structpages",
+	}
+	qualifier := func(p *types.Package) string {
+		if p == nil || p.Path() == pkg.Path() {
+			return ""
+		}
+		imports[p.Path()] = p.Name()
+		return p.Name()
+	}
+
+	var body bytes.Buffer
+	names := map[string]string{}
+	for _, pg := range pages {
+		typeName := pg.typ.Obj().Name()
+		name := fmt.Sprintf(cfg.funcName, exportName(typeName))
+		if prev, ok := names[name]; ok {
+			return nil, fmt.Errorf("generated function name %s is used by both %s and %s; "+
+				"use WithURLFuncName to choose a distinct format", name, prev, types.TypeString(pg.typ, nil))
+		}
+		names[name] = types.TypeString(pg.typ, nil)
+		lit := types.TypeString(pg.typ, qualifier) + "{}"
+		fmt.Fprintf(&body, `
+// %[1]s returns the URL for %[2]s (%[3]s), panicking on error.
+func %[1]s(sp *structpages.StructPages, args ...any) string {
+	u, err := sp.URLFor(%[4]s, args...)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// Must%[1]s returns the URL for %[2]s (%[3]s) resolved against the
+// request context, panicking on error. Use it from templ components.
+func Must%[1]s(ctx context.Context, args ...any) string {
+	u, err := structpages.URLFor(ctx, %[4]s, args...)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+`, name, typeName, pg.route, lit)
+	}
+	for _, s := range skipped {
+		fmt.Fprintf(&body, "\n// %s skipped: %s\n", s.typeName, s.reason)
+	}
+
+	paths := make([]string, 0, len(imports))
+	for p := range imports {
+		paths = append(paths, p)
+	}
+	slices.Sort(paths)
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by structpages-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\nimport (\n", pkg.Name())
+	// Standard library first, then everything else, goimports-style.
+	std := func(p string) bool { return !strings.Contains(strings.Split(p, "/")[0], ".") }
+	slices.SortStableFunc(paths, func(a, b string) int {
+		switch {
+		case std(a) == std(b):
+			return 0
+		case std(a):
+			return -1
+		default:
+			return 1
+		}
+	})
+	for i, p := range paths {
+		if i > 0 && std(paths[i-1]) != std(p) {
+			out.WriteString("\n")
+		}
+		if imports[p] == p[strings.LastIndex(p, "/")+1:] {
+			fmt.Fprintf(&out, "\t%q\n", p)
+		} else {
+			fmt.Fprintf(&out, "\t%s %q\n", imports[p], p)
+		}
+	}
+	out.WriteString(")\n")
+	out.Write(body.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated source: %w", err)
+	}
+	return src, nil
+}
+
+type page struct {
+	typ   *types.Named
+	route string
+}
+
+type skippedPage struct {
+	typeName string
+	reason   string
+}
+
+// collectPages returns the page types helpers are generated for, in tree
+// walk order, plus the ones skipped and why.
+func collectPages(tree *lint.PageTree, pkg *types.Package) ([]page, []skippedPage) {
+	var order []*types.Named
+	routes := map[*types.Named][]string{}
+	for _, root := range tree.Roots {
+		for node := range root.All {
+			if node.Type == nil {
+				continue
+			}
+			if _, ok := routes[node.Type]; !ok {
+				order = append(order, node.Type)
+			}
+			routes[node.Type] = append(routes[node.Type], node.FullRoute)
+		}
+	}
+
+	var pages []page
+	var skipped []skippedPage
+	for _, typ := range order {
+		obj := typ.Obj()
+		typeName := types.TypeString(typ, nil)
+		switch {
+		case len(routes[typ]) > 1:
+			skipped = append(skipped, skippedPage{typeName, fmt.Sprintf(
+				"mounted %d times (%s); use the []any chain form of URLFor",
+				len(routes[typ]), strings.Join(routes[typ], ", "))})
+		case typ.TypeParams().Len() > 0:
+			skipped = append(skipped, skippedPage{typeName, "generic page types are not supported"})
+		case obj.Pkg() != nil && obj.Pkg().Path() != pkg.Path() && !obj.Exported():
+			skipped = append(skipped, skippedPage{typeName, "unexported type in another package"})
+		default:
+			pages = append(pages, page{typ: typ, route: routes[typ][0]})
+		}
+	}
+	return pages, skipped
+}
+
+// exportName upper-cases the first letter of s.
+func exportName(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
@@ -0,0 +1,149 @@
+package gen
+
+import (
+	"bytes"
+	"flag"
+	"go/types"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jackielii/structpages/tools/lint"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/txtar"
+)
+
+var update = flag.Bool("update", false, "rewrite want.gen.go sections in testdata/*.txtar")
+
+// TestGenerate runs every testdata/*.txtar fixture: the archive is
+// extracted into a temporary module (with $REPO in go.mod pointing at
+// this checkout), the generated source is compared with want.gen.go, and
+// the module is then run so the generated helpers are proven to compile
+// and to produce the URLs listed in want.out.
+func TestGenerate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs the go tool")
+	}
+	repo, err := filepath.Abs("../../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fixtures, err := filepath.Glob("testdata/*.txtar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fixture := range fixtures {
+		t.Run(strings.TrimSuffix(filepath.Base(fixture), ".txtar"), func(t *testing.T) {
+			ar, err := txtar.ParseFile(fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+			dir := t.TempDir()
+			var wantGen, wantOut []byte
+			var opts []Option
+			for _, f := range ar.Files {
+				switch f.Name {
+				case "want.gen.go":
+					wantGen = f.Data
+					continue
+				case "want.out":
+					wantOut = f.Data
+					continue
+				case "flags":
+					opts = append(opts, WithURLFuncName(strings.TrimSpace(string(f.Data))))
+					continue
+				}
+				data := bytes.ReplaceAll(f.Data, []byte("$REPO"), []byte(repo))
+				path := filepath.Join(dir, f.Name)
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, data, 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got := generate(t, dir, opts...)
+			if *update {
+				for i := range ar.Files {
+					if ar.Files[i].Name == "want.gen.go" {
+						ar.Files[i].Data = got
+					}
+				}
+				if err := os.WriteFile(fixture, txtar.Format(ar), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			} else if !bytes.Equal(got, wantGen) {
+				t.Errorf("generated source mismatch\n--- got ---\n%s\n--- want ---\n%s", got, wantGen)
+			}
+
+			if err := os.WriteFile(filepath.Join(dir, "pages_urls.gen.go"), got, 0o600); err != nil {
+				t.Fatal(err)
+			}
+			cmd := exec.Command("go", "run", ".")
+			cmd.Dir = dir
+			cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=mod")
+			out, err := cmd.CombinedOutput()
+			if err != nil {
+				t.Fatalf("go run: %v\n%s", err, out)
+			}
+			if string(out) != string(wantOut) {
+				t.Errorf("output mismatch\n--- got ---\n%s--- want ---\n%s", out, wantOut)
+			}
+		})
+	}
+}
+
+func generate(t *testing.T, dir string, opts ...Option) []byte {
+	t.Helper()
+	cfg := &packages.Config{
+		Dir: dir,
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedSyntax |
+			packages.NeedTypesInfo | packages.NeedDeps | packages.NeedImports |
+			packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedModule,
+		Env: append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=mod"),
+	}
+	pkgs, err := packages.Load(cfg, "file="+filepath.Join(dir, "pages.go"))
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	for _, pkg := range pkgs {
+		for _, e := range pkg.Errors {
+			// main.go refers to the not-yet-generated helpers.
+			if e.Kind != packages.TypeError {
+				t.Fatalf("load %s: %v", pkg.PkgPath, e)
+			}
+		}
+	}
+	tree, diags := lint.BuildTree(pkgs)
+	if len(diags) > 0 {
+		t.Fatalf("BuildTree diagnostics: %v", diags)
+	}
+	src, err := Generate(tree, pkgs[0].Types, opts...)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	return src
+}
+
+func TestGenerate_InvalidFuncName(t *testing.T) {
+	pkg := types.NewPackage("ex", "main")
+	_, err := Generate(&lint.PageTree{}, pkg, WithURLFuncName("URLFor"))
+	if err == nil || !strings.Contains(err.Error(), "exactly one %s") {
+		t.Fatalf("err = %v, want format error", err)
+	}
+}
+
+func TestExportName(t *testing.T) {
+	for in, want := range map[string]string{
+		"homePage": "HomePage",
+		"Users":    "Users",
+		"élan":     "Élan",
+	} {
+		if got := exportName(in); got != want {
+			t.Errorf("exportName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
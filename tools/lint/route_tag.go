@@ -0,0 +1,135 @@
+package lint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// RouteTagAnalyzer reports malformed `route` struct tags: the kind of
+// mistake that otherwise only surfaces as a ServeMux panic or a silent
+// mismatch when the tree is mounted. Unlike NewAnalyzer it needs no
+// page tree, so it runs per package under go vet:
+//
+//	go vet -vettool=$(which structpages-vet) ./...
+var RouteTagAnalyzer = &analysis.Analyzer{
+	Name: "routetag",
+	Doc: "checks structpages route struct tags for malformed patterns, " +
+		"invalid HTTP methods, unclosed braces and duplicate parameter names",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runRouteTag,
+}
+
+func runRouteTag(pass *analysis.Pass) (any, error) {
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	ins.Preorder([]ast.Node{(*ast.StructType)(nil)}, func(n ast.Node) {
+		for _, field := range n.(*ast.StructType).Fields.List {
+			if field.Tag == nil {
+				continue
+			}
+			raw, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				continue
+			}
+			route, ok := reflect.StructTag(raw).Lookup("route")
+			if !ok {
+				continue
+			}
+			for _, problem := range checkRouteTag(route) {
+				pass.Report(analysis.Diagnostic{
+					Pos:      field.Tag.Pos(),
+					End:      field.Tag.End(),
+					Category: "route-tag",
+					Message:  fmt.Sprintf("[route-tag] route %q: %s", route, problem),
+				})
+			}
+		}
+	})
+	return nil, nil
+}
+
+// checkRouteTag returns the problems found in a route tag value, split
+// the same way the runtime splits it (parseTag).
+func checkRouteTag(route string) []string {
+	_, urlPath, _ := parseTag(route)
+	if fields := strings.Fields(route); len(fields) > 1 && !isHTTPMethod(strings.ToUpper(fields[0])) &&
+		looksLikeMethod(fields[0]) {
+		return []string{fmt.Sprintf("invalid HTTP method %q", fields[0])}
+	}
+	if !strings.HasPrefix(urlPath, "/") {
+		return []string{fmt.Sprintf("path %q must start with /", urlPath)}
+	}
+	return checkRoutePattern(urlPath)
+}
+
+// looksLikeMethod reports whether s is an all-uppercase word, which in
+// the first position of a tag is meant as an HTTP method.
+func looksLikeMethod(s string) bool {
+	for _, r := range s {
+		if !unicode.IsUpper(r) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// checkRoutePattern applies http.ServeMux's wildcard rules to a path:
+// every {name} is a whole segment with a Go identifier for a name,
+// {name...} and {$} come last, and no name repeats.
+func checkRoutePattern(pattern string) []string {
+	var problems []string
+	seen := map[string]bool{}
+	segments := strings.Split(pattern[1:], "/")
+	for i, seg := range segments {
+		last := i == len(segments)-1
+		open := strings.Index(seg, "{")
+		if open < 0 {
+			if strings.Contains(seg, "}") {
+				problems = append(problems, fmt.Sprintf("unmatched } in segment %q", seg))
+			}
+			continue
+		}
+		if !strings.HasSuffix(seg, "}") {
+			if !strings.Contains(seg[open:], "}") {
+				problems = append(problems, fmt.Sprintf("unclosed { in segment %q", seg))
+			} else {
+				problems = append(problems, fmt.Sprintf("wildcard %q must be a whole path segment", seg))
+			}
+			continue
+		}
+		if open > 0 || strings.Count(seg, "{") > 1 {
+			problems = append(problems, fmt.Sprintf("wildcard %q must be a whole path segment", seg))
+			continue
+		}
+		name := seg[1 : len(seg)-1]
+		if name == "$" {
+			if !last {
+				problems = append(problems, "{$} must be at the end of the path")
+			}
+			continue
+		}
+		if base, ok := strings.CutSuffix(name, "..."); ok {
+			name = base
+			if !last {
+				problems = append(problems, fmt.Sprintf("{%s...} must be at the end of the path", name))
+			}
+		}
+		if !token.IsIdentifier(name) {
+			problems = append(problems, fmt.Sprintf("bad wildcard name %q", name))
+			continue
+		}
+		if seen[name] {
+			problems = append(problems, fmt.Sprintf("duplicate parameter name %q", name))
+		}
+		seen[name] = true
+	}
+	return problems
+}
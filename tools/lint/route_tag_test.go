@@ -0,0 +1,11 @@
+package lint
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestRouteTagAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), RouteTagAnalyzer, "routetag")
+}
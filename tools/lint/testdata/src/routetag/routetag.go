@@ -0,0 +1,24 @@
+package routetag
+
+type page struct{}
+
+type pages struct {
+	Index   page `route:"/{$} Home"`
+	Item    page `route:"GET /items/{id} Item"`
+	Files   page `route:"/files/{path...} Files"`
+	Plain   page `route:"/about"`
+	Section page `route:"/section/ Section"`
+	All     page `route:"ALL /all All"`
+	Lower   page `route:"post /lower Lower"`
+	Other   page `json:"other"`
+
+	BadMethod   page `route:"GTE /x X"`             // want `invalid HTTP method "GTE"`
+	NoSlash     page `route:"about About"`          // want `path "about" must start with /`
+	Unclosed    page `route:"GET /path/{id Title"`  // want `unclosed \{ in segment "\{id"`
+	Unmatched   page `route:"/path/id} Title"`      // want `unmatched \} in segment "id\}"`
+	Partial     page `route:"/user-{id} Title"`     // want `wildcard "user-\{id\}" must be a whole path segment`
+	Duplicate   page `route:"/a/{id}/b/{id} Title"` // want `duplicate parameter name "id"`
+	BadName     page `route:"/a/{my-id} Title"`     // want `bad wildcard name "my-id"`
+	EarlyRest   page `route:"/a/{rest...}/b Title"` // want `\{rest...\} must be at the end of the path`
+	EarlyDollar page `route:"/{$}/b Title"`         // want `\{\$\} must be at the end of the path`
+}
@@ -0,0 +1,41 @@
+package structpages
+
+import (
+	"log"
+	"net/http"
+	"reflect"
+)
+
+var httpHeaderType = reflect.TypeFor[http.Header]()
+
+// writeTrailers calls the page's Trailers method, if any, once the body has
+// been written, and sends the header it returns as HTTP trailers:
+//
+//	func (p exportPage) Trailers(r *http.Request) (http.Header, error) {
+//	    return http.Header{"X-Checksum": {p.sum(r)}}, nil
+//	}
+//
+// The status is already on the wire, so an error is logged rather than
+// given to the error handler. Clients that ignore trailers just miss the
+// values.
+func (sp *StructPages) writeTrailers(w http.ResponseWriter, r *http.Request, pn *PageNode) {
+	if pn == nil || pn.trailers == nil {
+		return
+	}
+	// Flushing sends the response chunked; a Content-Length body can't carry
+	// trailers the server wasn't told about before the headers.
+	_ = http.NewResponseController(w).Flush()
+	res, err := sp.pc.callMethod(pn, pn.trailers, reflect.ValueOf(r), reflect.ValueOf(w))
+	if err == nil {
+		res, err = extractError(res)
+	}
+	if err != nil {
+		log.Printf("structpages: error calling Trailers method on %s: %v", pn.Name, err)
+		return
+	}
+	for k, vs := range res[0].Interface().(http.Header) {
+		for _, v := range vs {
+			w.Header().Add(http.TrailerPrefix+k, v)
+		}
+	}
+}
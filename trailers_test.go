@@ -0,0 +1,96 @@
+package structpages
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+type trailersPage struct{}
+
+func (trailersPage) Page() component { return testComponent{"report body"} }
+
+func (trailersPage) Trailers(r *http.Request) (http.Header, error) {
+	if r.URL.Query().Has("fail") {
+		return nil, errors.New("checksum unavailable")
+	}
+	return http.Header{"X-Checksum": {"abc123"}}, nil
+}
+
+func TestTrailers(t *testing.T) {
+	mux := http.NewServeMux()
+	if _, err := Mount(mux, &struct {
+		trailersPage `route:"/report Report"`
+	}{}, "/", "App"); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "/report")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = res.Body.Close() }()
+	if got := res.Trailer.Get("X-Checksum"); got != "" {
+		t.Errorf("trailer known before the body was read: %q", got)
+	}
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != "report body" {
+		t.Errorf("body = %q, want %q", body, "report body")
+	}
+	if got := res.Trailer.Get("X-Checksum"); got != "abc123" {
+		t.Errorf("X-Checksum trailer = %q, want abc123", got)
+	}
+	if got := res.Header.Get("X-Checksum"); got != "" {
+		t.Errorf("X-Checksum sent as a header too: %q", got)
+	}
+}
+
+func TestTrailers_Error(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	mux := http.NewServeMux()
+	if _, err := Mount(mux, &struct {
+		trailersPage `route:"/report Report"`
+	}{}, "/", "App"); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/report?fail", http.NoBody))
+	if rec.Code != http.StatusOK || rec.Body.String() != "report body" {
+		t.Errorf("response = %d %q, want the rendered page", rec.Code, rec.Body.String())
+	}
+	if len(rec.Result().Trailer) != 0 {
+		t.Errorf("trailers = %v, want none", rec.Result().Trailer)
+	}
+	if !strings.Contains(buf.String(), "Trailers method on") || !strings.Contains(buf.String(), "checksum unavailable") {
+		t.Errorf("log = %q, want the Trailers error", buf.String())
+	}
+}
+
+type badTrailersPage struct{}
+
+func (badTrailersPage) Page() component { return testComponent{""} }
+
+func (badTrailersPage) Trailers() http.Header { return nil }
+
+func TestTrailers_BadSignature(t *testing.T) {
+	_, err := Mount(http.NewServeMux(), &struct {
+		badTrailersPage `route:"/ Home"`
+	}{}, "/", "App")
+	if err == nil || !strings.Contains(err.Error(), "must return (http.Header, error)") {
+		t.Errorf("Mount error = %v, want the Trailers signature error", err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strings"
 
 	"github.com/jackielii/ctxkey"
@@ -19,7 +20,16 @@ var (
 
 // encodePathSegment converts a value to string and URL-encodes it for use in path segments.
 // For wildcard segments ({path...}), slashes are preserved as they're part of the path.
+// Slices (other than []byte) are encoded element by element and joined with
+// commas, the form PathValues splits back apart.
 func encodePathSegment(value any, isWildcard bool) string {
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() != reflect.Uint8 {
+		parts := make([]string, rv.Len())
+		for i := range parts {
+			parts[i] = encodePathSegment(rv.Index(i).Interface(), isWildcard)
+		}
+		return strings.Join(parts, ",")
+	}
 	s := fmt.Sprint(value)
 	if isWildcard {
 		// For wildcards, split on slashes, escape each part, then rejoin
@@ -33,10 +43,13 @@ func encodePathSegment(value any, isWildcard bool) string {
 	return url.PathEscape(s)
 }
 
-func withPcCtx(pc *parseContext) MiddlewareFunc {
+// withPcCtx stores the parse context and the matched page for URLFor, IDFor
+// and CurrentPage, ahead of every other middleware.
+func withPcCtx(pc *parseContext, funcs *contextFuncs) MiddlewareFunc {
 	return func(next http.Handler, node *PageNode) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ctx := pcCtx.WithValue(r.Context(), pc)
+			ctx := currentPageCtx.WithValue(pcCtx.WithValue(r.Context(), pc), pc.currentNode(node))
+			ctx = funcs.apply(ctx)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
@@ -95,14 +108,33 @@ func extractURLParams(next http.Handler, node *PageNode) http.Handler {
 //
 // Positional and key/value-pairs forms also work (see formatPathSegments
 // in url_for.go for the full detection order) but require the call site
-// to track parameter position or name conventions.
+// to track parameter position or name conventions. For a route with
+// several parameters, a single []string or []any argument is expanded
+// into positional arguments:
+//
+//	URLFor(ctx, Comment{}, []string{"u1", "p1", "c1"})
 //
 // You can pass []any as the page to join multiple path segments
 // together — strings are concatenated as-is, which is the form used to
 // append a query-string template to a typed page lookup. You can also
 // pass a func(*PageNode) bool predicate to match a specific page when
 // type-based lookup isn't enough.
+//
+// Parameter values are percent-encoded, so "123/hack" stays one segment:
+// /products/123%2Fhack. Use URLForStrict to reject such values instead.
 func URLFor(ctx context.Context, page any, args ...any) (string, error) {
+	return urlFor(ctx, page, false, args)
+}
+
+// URLForStrict is like URLFor, but a parameter value that is empty or
+// contains '/', '?' or '#' — outside a {name...} wildcard, which takes
+// slashes — fails with a *PathParamError instead of being percent-encoded,
+// for values that are never expected to need escaping.
+func URLForStrict(ctx context.Context, page any, args ...any) (string, error) {
+	return urlFor(ctx, page, true, args)
+}
+
+func urlFor(ctx context.Context, page any, strict bool, args []any) (string, error) {
 	pc := pcCtx.Value(ctx)
 	if pc == nil {
 		return "", errors.New("parse context not found in context")
@@ -126,12 +158,49 @@ func URLFor(ctx context.Context, page any, args ...any) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	path, err := formatPathSegments(ctx, pattern, args...)
+	return pc.formatURL(ctx, pattern, strict, args)
+}
+
+// AliasURLFor is like URLFor, but returns the URL of the page's route alias
+// at index alias in the list its Aliases method returns, instead of the
+// primary route. page is a page value, Ref, predicate or []any chain of
+// page values.
+//
+//	structpages.AliasURLFor(ctx, aboutPage{}, 0) // → "/about-us"
+func AliasURLFor(ctx context.Context, page any, alias int, args ...any) (string, error) {
+	pc := pcCtx.Value(ctx)
+	if pc == nil {
+		return "", errors.New("parse context not found in context")
+	}
+	if s, ok := page.(string); ok {
+		page = Ref(s)
+	}
+	chain, ok := page.([]any)
+	if !ok {
+		chain = []any{page}
+	}
+	if len(chain) == 0 {
+		return "", errors.New("AliasURLFor: page chain is empty")
+	}
+	node, err := pc.resolveChain(chain)
+	if err != nil {
+		return "", err
+	}
+	if alias < 0 || alias >= len(node.Aliases) {
+		return "", fmt.Errorf("page %s has %d aliases, no alias %d", node.Name, len(node.Aliases), alias)
+	}
+	return pc.formatURL(ctx, node.aliasRoute(alias), false, args)
+}
+
+// formatURL fills the parameters of the route pattern from args and
+// applies the route and URL prefixes.
+func (p *parseContext) formatURL(ctx context.Context, pattern string, strict bool, args []any) (string, error) {
+	path, err := formatPath(ctx, pattern, strict, args)
 	if err != nil {
 		return "", fmt.Errorf("urlfor: %w", err)
 	}
 	result := strings.Replace(path, "{$}", "", 1)
-	return applyURLPrefix(pc.urlPrefix, result), nil
+	return applyURLPrefix(p.urlPrefix, applyURLPrefix(p.routePrefix, result)), nil
 }
 
 // applyURLPrefix prepends the configured URL prefix to a generated path.
@@ -160,13 +229,102 @@ func applyURLPrefix(prefix, path string) string {
 	return prefix + path
 }
 
+// ErrInvalidPathParam is wrapped by the *PathParamError URLForStrict
+// returns for a parameter value it can't substitute.
+var ErrInvalidPathParam = errors.New("structpages: invalid path parameter")
+
+// PathParamError reports a path parameter value URLForStrict refused: empty, or
+// holding a character that would change the route the URL matches.
+type PathParamError struct {
+	Param  string
+	Value  string
+	Reason string
+}
+
+func (e *PathParamError) Error() string {
+	return fmt.Sprintf("path parameter %s: %s", e.Param, e.Reason)
+}
+
+func (e *PathParamError) Unwrap() error { return ErrInvalidPathParam }
+
+// checkPathParam returns a *PathParamError if value can't be substituted
+// for the parameter name without escaping. Slices are checked by element,
+// as encodePathSegment joins them.
+func checkPathParam(name string, value any, isWildcard bool) error {
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() != reflect.Uint8 {
+		for i := range rv.Len() {
+			if err := checkPathParam(name, rv.Index(i).Interface(), isWildcard); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	s := fmt.Sprint(value)
+	if s == "" {
+		return &PathParamError{Param: name, Value: s, Reason: "value is empty"}
+	}
+	forbidden := "/?#"
+	if isWildcard {
+		forbidden = "?#"
+	}
+	if i := strings.IndexAny(s, forbidden); i >= 0 {
+		return &PathParamError{Param: name, Value: s, Reason: fmt.Sprintf("value %q contains %q", s, s[i])}
+	}
+	return nil
+}
+
+// expandArgs turns a lone []string or []any argument into positional
+// arguments when the pattern has several parameters; for a single
+// parameter it stays one argument, a comma-joined list. A map argument
+// can't be combined with either.
+func expandArgs(args []any, params int) ([]any, error) {
+	if len(args) == 1 && params > 1 {
+		var expanded []any
+		switch a := args[0].(type) {
+		case []string:
+			for _, s := range a {
+				expanded = append(expanded, s)
+			}
+		case []any:
+			expanded = a
+		default:
+			return args, nil
+		}
+		if len(expanded) > params {
+			return nil, fmt.Errorf("%d values for %d parameters: %v", len(expanded), params, args[0])
+		}
+		return expanded, nil
+	}
+	var hasMap, hasSlice bool
+	for _, arg := range args {
+		switch arg.(type) {
+		case map[string]any:
+			hasMap = true
+		case []string, []any:
+			hasSlice = true
+		}
+	}
+	if hasMap && hasSlice {
+		return nil, fmt.Errorf("map and slice arguments can't be combined: %v", args)
+	}
+	return args, nil
+}
+
 // formatPathSegments formats URL pattern segments with provided arguments,
 // using pre-extracted parameters from context if available.
 // For more sophisticated path parsing, see Go's standard library implementation
 // at go/src/net/http/pattern.go which handles edge cases like escaped braces.
+func formatPathSegments(ctx context.Context, pattern string, args ...any) (string, error) {
+	return formatPath(ctx, pattern, false, args)
+}
+
+// formatPath implements formatPathSegments. With strict set, a value that
+// can't be substituted as is — empty, or containing a '/', '?' or '#' that
+// would change which route the URL matches — is an error instead of being
+// escaped.
 //
 //nolint:gocognit,gocyclo // This function handles multiple cases for flexible argument passing
-func formatPathSegments(ctx context.Context, pattern string, args ...any) (string, error) {
+func formatPath(ctx context.Context, pattern string, strict bool, args []any) (string, error) {
 	// Use cached segment parsing if parseContext is available
 	pc := pcCtx.Value(ctx)
 	var segments []segment
@@ -179,6 +337,17 @@ func formatPathSegments(ctx context.Context, pattern string, args ...any) (strin
 	if err != nil {
 		return pattern, fmt.Errorf("pattern %s: %w", pattern, err)
 	}
+	fill := func(idx int, value any) error {
+		seg := &segments[idx]
+		if strict {
+			if err := checkPathParam(seg.name, value, seg.wildcard); err != nil {
+				return fmt.Errorf("pattern %s: %w", pattern, err)
+			}
+		}
+		seg.value = encodePathSegment(value, seg.wildcard)
+		seg.filled = true
+		return nil
+	}
 	indicies := make([]int, 0, len(segments)/2+1)
 	for i, segment := range segments {
 		if segment.param {
@@ -188,6 +357,9 @@ func formatPathSegments(ctx context.Context, pattern string, args ...any) (strin
 	if len(args) == 0 && len(indicies) == 0 {
 		return pattern, nil // no args and no params, return the pattern as is
 	}
+	if args, err = expandArgs(args, len(indicies)); err != nil {
+		return pattern, fmt.Errorf("pattern %s: %w", pattern, err)
+	}
 
 	// Try to use pre-extracted parameters from context if no args provided
 	if len(args) == 0 && len(indicies) > 0 {
@@ -238,8 +410,9 @@ func formatPathSegments(ctx context.Context, pattern string, args ...any) (strin
 		for _, idx := range indicies {
 			name := segments[idx].name
 			if value, ok := arg[name]; ok {
-				segments[idx].value = encodePathSegment(value, segments[idx].wildcard)
-				segments[idx].filled = true
+				if err := fill(idx, value); err != nil {
+					return pattern, err
+				}
 			}
 			// If value not in args map, it should keep the pre-filled value from context
 		}
@@ -255,9 +428,9 @@ func formatPathSegments(ctx context.Context, pattern string, args ...any) (strin
 		case len(args) == len(indicies):
 			for i, arg := range args {
 				// Always override with provided args when count matches exactly
-				idx := indicies[i]
-				segments[idx].value = encodePathSegment(arg, segments[idx].wildcard)
-				segments[idx].filled = true
+				if err := fill(indicies[i], arg); err != nil {
+					return pattern, err
+				}
 			}
 		case len(args)%2 == 0 && len(args) >= 2:
 			// Check if all even-indexed args are strings AND at least one matches a parameter name
@@ -290,8 +463,9 @@ func formatPathSegments(ctx context.Context, pattern string, args ...any) (strin
 				for _, idx := range indicies {
 					name := segments[idx].name
 					if value, ok := m[name]; ok {
-						segments[idx].value = encodePathSegment(value, segments[idx].wildcard)
-						segments[idx].filled = true
+						if err := fill(idx, value); err != nil {
+							return pattern, err
+						}
 					} else if !segments[idx].filled {
 						// Only error if no value from context either
 						return pattern, fmt.Errorf("pattern %s: argument %s not found in provided args: %v", pattern, name, args)
@@ -316,8 +490,9 @@ func formatPathSegments(ctx context.Context, pattern string, args ...any) (strin
 			argIdx := 0
 			for _, idx := range indicies {
 				if !segments[idx].filled && argIdx < len(args) {
-					segments[idx].value = encodePathSegment(args[argIdx], segments[idx].wildcard)
-					segments[idx].filled = true
+					if err := fill(idx, args[argIdx]); err != nil {
+						return pattern, err
+					}
 					argIdx++
 				}
 			}
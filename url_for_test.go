@@ -1465,3 +1465,106 @@ func TestURLFor_containerResolvesToIndexChild(t *testing.T) {
 		t.Errorf("index-less container: got %q, want %q", got, "/services")
 	}
 }
+
+type commentPage struct{}
+
+func (commentPage) Page() component { return testComponent{"comment"} }
+
+type userFilePage struct{}
+
+func (userFilePage) Page() component { return testComponent{"file"} }
+
+type sliceArgPages struct {
+	Comment  commentPage  `route:"/users/{userId}/posts/{postId}/comments/{commentId} Comment"`
+	UserFile userFilePage `route:"/users/{userId}/files/{path...} File"`
+}
+
+func TestURLFor_SliceExpandsToPositional(t *testing.T) {
+	sp, err := Parse(&sliceArgPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	tests := []struct {
+		name    string
+		page    any
+		args    []any
+		want    string
+		wantErr string
+	}{
+		{name: "[]string", page: commentPage{}, args: []any{[]string{"u1", "p1", "c1"}},
+			want: "/users/u1/posts/p1/comments/c1"},
+		{name: "[]any mixed types", page: commentPage{}, args: []any{[]any{"u1", 42, int64(7)}},
+			want: "/users/u1/posts/42/comments/7"},
+		{name: "map", page: commentPage{}, args: []any{map[string]any{"userId": "u1", "postId": "p1", "commentId": 3}},
+			want: "/users/u1/posts/p1/comments/3"},
+		{name: "trailing wildcard", page: userFilePage{}, args: []any{[]string{"u1", "docs/a b.txt"}},
+			want: "/users/u1/files/docs/a%20b.txt"},
+		{name: "too many values", page: commentPage{}, args: []any{[]string{"u1", "p1", "c1", "x"}},
+			wantErr: "4 values for 3 parameters"},
+		{name: "slice mixed with map", page: commentPage{}, args: []any{[]string{"u1"}, map[string]any{"postId": "p1"}},
+			wantErr: "can't be combined"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sp.URLFor(tt.page, tt.args...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("URLFor = %q, %v; want error containing %q", got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("URLFor = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestURLFor_InvalidPathParam(t *testing.T) {
+	sp, err := Parse(&sliceArgPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	ctx := sp.PageContext(context.Background())
+	tests := []struct {
+		name    string
+		page    any
+		args    []any
+		escaped string
+		param   string
+		reason  string
+	}{
+		{"slash", commentPage{}, []any{"u1", "123/hack", "c1"},
+			"/users/u1/posts/123%2Fhack/comments/c1", "postId", `contains '/'`},
+		{"query", commentPage{}, []any{"u1", "p1", "c1?x=1"},
+			"/users/u1/posts/p1/comments/c1%3Fx=1", "commentId", `contains '?'`},
+		{"fragment in wildcard", userFilePage{}, []any{"u1", "docs/a#b"},
+			"/users/u1/files/docs/a%23b", "path", `contains '#'`},
+		{"empty", commentPage{}, []any{map[string]any{"userId": "", "postId": "p1", "commentId": "c1"}},
+			"/users//posts/p1/comments/c1", "userId", "value is empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := URLFor(ctx, tt.page, tt.args...)
+			if err != nil || got != tt.escaped {
+				t.Errorf("URLFor = %q, %v; want %q", got, err, tt.escaped)
+			}
+			_, err = URLForStrict(ctx, tt.page, tt.args...)
+			var paramErr *PathParamError
+			if !errors.As(err, &paramErr) || !errors.Is(err, ErrInvalidPathParam) {
+				t.Fatalf("URLForStrict error = %v, want a *PathParamError", err)
+			}
+			if paramErr.Param != tt.param || !strings.Contains(err.Error(), tt.reason) {
+				t.Errorf("URLForStrict error = %q (param %s), want param %s and %q", err, paramErr.Param, tt.param, tt.reason)
+			}
+		})
+	}
+
+	got, err := URLForStrict(ctx, userFilePage{}, "u1", "docs/a b.txt")
+	if want := "/users/u1/files/docs/a%20b.txt"; err != nil || got != want {
+		t.Errorf("URLForStrict with slashes in a wildcard = %q, %v; want %q", got, err, want)
+	}
+}
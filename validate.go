@@ -0,0 +1,208 @@
+package structpages
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// Validate parses the page tree and checks it is structurally sound without
+// registering anything: route patterns must not conflict under
+// http.ServeMux rules, and every parameter of the Props, ServeHTTP,
+// Middlewares, and HXHistoryRestore methods must be satisfiable, either from
+// the per-request values (*http.Request, http.ResponseWriter, RenderTarget,
+// *PageNode) or from WithArgs. All problems found are reported together.
+//
+// The returned *StructPages already resolves URLFor, ID, and IDTarget. Call
+// its Mount method later to register the validated tree:
+//
+//	sp, err := structpages.Validate(index{}, "/", "My App", structpages.WithArgs(db))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	// ... later, when serving
+//	if err := sp.Mount(mux); err != nil {
+//	    log.Fatal(err)
+//	}
+func Validate(page any, route, title string, options ...Option) (*StructPages, error) {
+	sp, err := newStructPages(page, route, title, options)
+	if err != nil {
+		return nil, err
+	}
+	if err := errors.Join(sp.checkRoutes(), sp.checkArgs()); err != nil {
+		return nil, err
+	}
+	return sp, nil
+}
+
+// Mount registers the page tree of a StructPages returned by Validate onto
+// mux. If mux is nil, routes are registered on the mux set by WithMux, or on
+// http.DefaultServeMux when there is none.
+func (sp *StructPages) Mount(mux Mux) error {
+	sp.checkWritable()
+	if sp.pc == nil {
+		return errors.New("structpages: Mount called on a StructPages without a parsed page tree; use Validate")
+	}
+	if mux == nil {
+		mux = sp.mux
+	}
+	if mux == nil {
+		mux = http.DefaultServeMux
+	}
+	sp.mux = mux
+	if err := sp.register(mux); err != nil {
+		return err
+	}
+	sp.preheat(mux)
+	sp.mountedAt = time.Now()
+	return nil
+}
+
+// checkRoutes registers every page on a scratch http.ServeMux and returns
+// the conflicts.
+func (sp *StructPages) checkRoutes() error {
+	return routeConflicts(sp)
+}
+
+// requestArgTypes are the values injected per request alongside the PageNode
+// and registered args.
+var requestArgTypes = []reflect.Type{
+	reflect.TypeOf((*http.Request)(nil)),
+	reflect.TypeOf((*http.ResponseWriter)(nil)).Elem(),
+	renderTargetType,
+	multipartFormType,
+	fileHeadersType,
+	pathParamsType,
+	urlPointerType,
+	urlValuesType,
+	headerPointerType,
+}
+
+// checkArgs verifies that methods called with dependency injection at
+// request (or registration) time can have all their parameters filled.
+// Component methods are not checked: they receive Props results, not
+// injected values.
+func (sp *StructPages) checkArgs() error {
+	var errs []error
+	for pn := range sp.pc.root().All() {
+		if pn.Middlewares != nil {
+			errs = append(errs, sp.checkMethodArgs(pn, pn.Middlewares, nil))
+		}
+		scope := slices.Clip(sp.propsScope(pn))
+		for _, m := range sp.propsMethods(pn) {
+			errs = append(errs, sp.checkMethodArgs(pn, &m, scope))
+			scope = appendResultTypes(scope, m)
+		}
+		if pn.hxHistoryRestore != nil {
+			errs = append(errs, sp.checkMethodArgs(pn, pn.hxHistoryRestore, requestArgTypes[:1]))
+		}
+		if pn.hxPolling != nil {
+			errs = append(errs, sp.checkMethodArgs(pn, pn.hxPolling, requestArgTypes[:2]))
+		}
+		if pn.hxSwap != nil {
+			errs = append(errs, sp.checkMethodArgs(pn, pn.hxSwap, requestArgTypes[:2]))
+		}
+		for _, m := range pn.hxTriggers {
+			errs = append(errs, sp.checkMethodArgs(pn, m, requestArgTypes[:2]))
+		}
+		if pn.trailers != nil {
+			errs = append(errs, sp.checkMethodArgs(pn, pn.trailers, requestArgTypes[:2]))
+		}
+		if pn.validateQuery != nil {
+			errs = append(errs, sp.checkMethodArgs(pn, pn.validateQuery, requestArgTypes[:1]))
+		}
+		if m, ok := extendedServeHTTP(pn); ok {
+			errs = append(errs, sp.checkMethodArgs(pn, m, requestArgTypes))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// propsScope returns the per-request types available to pn's Props: the
+// request values plus, with WithPropsChain, the ancestors' Props results.
+func (sp *StructPages) propsScope(pn *PageNode) []reflect.Type {
+	if !sp.propsChain {
+		return requestArgTypes
+	}
+	scope := slices.Clone(requestArgTypes)
+	for _, a := range pn.Ancestors() {
+		for _, m := range sp.propsMethods(a) {
+			scope = appendResultTypes(scope, m)
+		}
+	}
+	return scope
+}
+
+// appendResultTypes appends the non-error result types of m to scope.
+func appendResultTypes(scope []reflect.Type, m reflect.Method) []reflect.Type {
+	errorType := reflect.TypeOf((*error)(nil)).Elem()
+	for i := range m.Type.NumOut() {
+		if out := m.Type.Out(i); out != errorType {
+			scope = append(scope, out)
+		}
+	}
+	return scope
+}
+
+func (sp *StructPages) checkMethodArgs(pn *PageNode, method *reflect.Method, scope []reflect.Type) error {
+	var errs []error
+	pnType := reflect.TypeOf(pn)
+	perRequest := sp.perRequestTypes()
+	for i := 1; i < method.Type.NumIn(); i++ {
+		argType := method.Type.In(i)
+		if argType == pnType || argType == pnType.Elem() || inScope(argType, scope) || isCookieJar(argType) ||
+			inScope(argType, perRequest) {
+			continue
+		}
+		val, err := sp.pc.resolveRegistered(argType)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("page %s: method %s: %w", pn.Name, formatMethod(method), err))
+			continue
+		}
+		if !val.IsValid() {
+			errs = append(errs, fmt.Errorf("page %s: method %s requires argument of type %s, but not found",
+				pn.Name, formatMethod(method), argType.String()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// inScope reports whether t is filled by one of the per-request types,
+// either exactly or, for interfaces, by a type implementing it.
+func inScope(t reflect.Type, scope []reflect.Type) bool {
+	for _, s := range scope {
+		if s == t || (t.Kind() == reflect.Interface && s.Implements(t)) {
+			return true
+		}
+	}
+	return false
+}
+
+// extendedServeHTTP returns pn's ServeHTTP method when it takes injected
+// arguments beyond (http.ResponseWriter, *http.Request), mirroring asHandler.
+func extendedServeHTTP(pn *PageNode) (*reflect.Method, bool) {
+	if !pn.hasServeHTTP() {
+		return nil, false
+	}
+	v := pn.Value
+	if v.Type().Implements(handlerType) || v.Type().Implements(errHandlerType) {
+		return nil, false
+	}
+	st, pt := v.Type(), v.Type()
+	if st.Kind() == reflect.Pointer {
+		st = st.Elem()
+	} else {
+		pt = reflect.PointerTo(st)
+	}
+	method, ok := st.MethodByName("ServeHTTP")
+	if !ok || isPromotedMethod(&method) {
+		method, _ = pt.MethodByName("ServeHTTP")
+	}
+	if method.Type.NumIn() <= 3 { // receiver, http.ResponseWriter, *http.Request
+		return nil, false
+	}
+	return &method, true
+}
@@ -0,0 +1,117 @@
+package structpages
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type validateStore struct{ name string }
+
+type validateListPage struct{}
+
+func (validateListPage) Props(r *http.Request, store *validateStore) (string, error) {
+	return "list from " + store.name, nil
+}
+
+func (validateListPage) Page(s string) component { return testComponent{s} }
+
+type validateItemPage struct{}
+
+func (validateItemPage) Page() component { return testComponent{"item"} }
+
+type validateActionPage struct{}
+
+func (validateActionPage) ServeHTTP(w http.ResponseWriter, r *http.Request, store *validateStore) {
+	_, _ = w.Write([]byte("action " + store.name))
+}
+
+type validatePages struct {
+	List   validateListPage   `route:"/{$} List"`
+	Item   validateItemPage   `route:"/items/{id} Item"`
+	Action validateActionPage `route:"POST /action Action"`
+}
+
+func TestValidate_MountRegistersRoutes(t *testing.T) {
+	sp, err := Validate(&validatePages{}, "/", "App", WithArgs(&validateStore{name: "db"}))
+	if err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	u, err := sp.URLFor(validateItemPage{}, "42")
+	if err != nil || u != "/items/42" {
+		t.Fatalf("URLFor before Mount = %q, %v; want /items/42", u, err)
+	}
+
+	mux := http.NewServeMux()
+	if err := sp.Mount(mux); err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	tests := []struct {
+		method, path, want string
+	}{
+		{http.MethodGet, "/", "list from db"},
+		{http.MethodGet, "/items/1", "item"},
+		{http.MethodPost, "/action", "action db"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, http.NoBody))
+		if rec.Code != http.StatusOK || rec.Body.String() != tt.want {
+			t.Errorf("%s %s = %d %q, want 200 %q", tt.method, tt.path, rec.Code, rec.Body.String(), tt.want)
+		}
+	}
+}
+
+func TestValidate_MissingArgs(t *testing.T) {
+	_, err := Validate(&validatePages{}, "/", "App")
+	if err == nil {
+		t.Fatal("Validate succeeded without *validateStore registered")
+	}
+	for _, want := range []string{
+		"validateListPage.Props requires argument of type *structpages.validateStore",
+		"validateActionPage.ServeHTTP requires argument of type *structpages.validateStore",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+}
+
+type validateConflictPages struct {
+	ByID   validateItemPage `route:"GET /items/{id} ByID"`
+	ByName validateItemPage `route:"GET /items/{name} ByName"`
+}
+
+func TestValidate_RouteConflict(t *testing.T) {
+	_, err := Validate(&validateConflictPages{}, "/", "App")
+	if err == nil || !strings.Contains(err.Error(), "conflicts with") {
+		t.Fatalf("Validate err = %v, want route conflict", err)
+	}
+}
+
+func TestStructPages_Mount_WithoutValidate(t *testing.T) {
+	if err := (&StructPages{}).Mount(http.NewServeMux()); err == nil {
+		t.Error("Mount on zero StructPages succeeded, want error")
+	}
+}
+
+type validateEnvItemPage struct{}
+
+func (validateEnvItemPage) Page() component { return testComponent{"item"} }
+
+func (validateEnvItemPage) Environments() []string { return []string{"development"} }
+
+type validateEnvConflictPages struct {
+	ByID   validateItemPage    `route:"GET /items/{id} ByID"`
+	ByName validateEnvItemPage `route:"GET /items/{name} ByName"`
+}
+
+func TestValidate_RouteConflictOutsideEnv(t *testing.T) {
+	if _, err := Validate(&validateEnvConflictPages{}, "/", "App", WithEnv("production")); err != nil {
+		t.Errorf("Validate in production = %v, want the development-only page skipped", err)
+	}
+	if _, err := Validate(&validateEnvConflictPages{}, "/", "App", WithEnv("development")); err == nil {
+		t.Error("Validate in development accepted conflicting routes")
+	}
+}
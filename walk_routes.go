@@ -0,0 +1,72 @@
+package structpages
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// WalkRoutes calls fn for every route Mount registers, in registration
+// order: a page's children before the page itself, then the routes of
+// registered plugins. pattern is the full path pattern, including any
+// WithRoutePrefix, and method the route method, "ALL" for routes without
+// one. Pages excluded by WithEnv are skipped. WalkRoutes stops at the first
+// error from fn and returns it.
+//
+//	err := sp.WalkRoutes(func(pn *structpages.PageNode, pattern, method string) error {
+//	    spec.AddPath(method, pattern, pn.Title)
+//	    return nil
+//	})
+func (sp *StructPages) WalkRoutes(fn func(pn *PageNode, pattern, method string) error) error {
+	sp = sp.current()
+	return sp.walkPages(func(owner *StructPages, pn *PageNode) error {
+		if pn.Route == "" || !pn.routable() {
+			return nil
+		}
+		return fn(pn, owner.routePrefix+pn.FullRoute(), pn.Method)
+	})
+}
+
+// WalkComponents calls fn for every component method of every page, in the
+// page order of WalkRoutes and by name within a page. It stops at the first
+// error from fn and returns it.
+func (sp *StructPages) WalkComponents(fn func(pn *PageNode, componentName string, method *reflect.Method) error) error {
+	sp = sp.current()
+	return sp.walkPages(func(_ *StructPages, pn *PageNode) error {
+		for _, name := range slices.Sorted(maps.Keys(pn.Components)) {
+			method := pn.Components[name]
+			if err := fn(pn, name, &method); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// walkPages calls fn for the pages of sp and its plugins that are in the
+// environment, children first, with the StructPages each page belongs to.
+func (sp *StructPages) walkPages(fn func(owner *StructPages, pn *PageNode) error) error {
+	var visit func(pn *PageNode) error
+	visit = func(pn *PageNode) error {
+		if !sp.inEnv(pn) {
+			return nil
+		}
+		for _, child := range pn.Children {
+			if err := visit(child); err != nil {
+				return err
+			}
+		}
+		return fn(sp, pn)
+	}
+	if sp.pc != nil {
+		if err := visit(sp.pc.root()); err != nil {
+			return err
+		}
+	}
+	for _, ps := range sp.plugins {
+		if err := ps.walkPages(fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
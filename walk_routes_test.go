@@ -0,0 +1,79 @@
+package structpages
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestWalkRoutes(t *testing.T) {
+	sp, err := Mount(http.NewServeMux(), &listPages{}, "/", "App", WithRoutePrefix("/v1"))
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	var visited []string
+	err = sp.WalkRoutes(func(pn *PageNode, pattern, method string) error {
+		visited = append(visited, method+" "+pattern+" "+pn.Name)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WalkRoutes: %v", err)
+	}
+	// listAdminPages has no component, so /v1/admin is not a route.
+	want := []string{
+		"ALL /v1/admin/users/{id} User",
+		"ALL /v1/admin/users Users",
+		"ALL /v1/about About",
+		"POST /v1/submit Submit",
+	}
+	if !slices.Equal(visited, want) {
+		t.Errorf("WalkRoutes visited %q, want %q", visited, want)
+	}
+
+	stop := errors.New("stop")
+	visited = nil
+	err = sp.WalkRoutes(func(pn *PageNode, pattern, method string) error {
+		visited = append(visited, pn.Name)
+		if pn.Name == "Users" {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) || !slices.Equal(visited, []string{"User", "Users"}) {
+		t.Errorf("WalkRoutes = %v after %q, want stop after [User Users]", err, visited)
+	}
+}
+
+func TestWalkComponents(t *testing.T) {
+	sp, err := Mount(http.NewServeMux(), &listPages{}, "/", "App")
+	if err != nil {
+		t.Fatalf("Mount: %v", err)
+	}
+	var visited []string
+	err = sp.WalkComponents(func(pn *PageNode, name string, method *reflect.Method) error {
+		if method.Name != name {
+			t.Errorf("%s.%s: method is %s", pn.Name, name, method.Name)
+		}
+		visited = append(visited, pn.Name+"."+name)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WalkComponents: %v", err)
+	}
+	want := []string{"User.Edit", "User.Page", "Users.Page", "About.Page", "Submit.Edit", "Submit.Page"}
+	if !slices.Equal(visited, want) {
+		t.Errorf("WalkComponents visited %q, want %q", visited, want)
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = sp.WalkComponents(func(*PageNode, string, *reflect.Method) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("WalkComponents = %v after %d calls, want stop after 1", err, calls)
+	}
+}